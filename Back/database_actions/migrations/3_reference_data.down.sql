@@ -0,0 +1,6 @@
+ALTER TABLE breeds
+    DROP FOREIGN KEY fk_breeds_species,
+    DROP FOREIGN KEY fk_breeds_pet_size;
+
+DROP TABLE IF EXISTS pet_sizes;
+DROP TABLE IF EXISTS species;
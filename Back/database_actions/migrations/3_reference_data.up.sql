@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS species (
+    code VARCHAR(50) PRIMARY KEY,
+    label VARCHAR(100) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS pet_sizes (
+    code VARCHAR(20) PRIMARY KEY,
+    label VARCHAR(100) NOT NULL
+);
+
+INSERT INTO species (code, label) VALUES
+    ('dog', 'Chien'),
+    ('cat', 'Chat');
+
+INSERT INTO pet_sizes (code, label) VALUES
+    ('small', 'Petit'),
+    ('medium', 'Moyen'),
+    ('tall', 'Grand');
+
+UPDATE breeds SET species = LOWER(TRIM(species)), pet_size = LOWER(TRIM(pet_size));
+UPDATE breeds SET species = 'dog' WHERE species IN ('chien', 'dogs');
+UPDATE breeds SET species = 'cat' WHERE species IN ('chat', 'cats');
+UPDATE breeds SET pet_size = 'small' WHERE pet_size IN ('petit', 'petite');
+UPDATE breeds SET pet_size = 'medium' WHERE pet_size IN ('moyen', 'moyenne');
+UPDATE breeds SET pet_size = 'tall' WHERE pet_size IN ('grand', 'grande', 'large');
+
+-- Les valeurs restantes ne sont pas copiées dans les tables de référence :
+-- une valeur qu'aucune variante connue ne couvre fait échouer l'ajout des
+-- clés étrangères (erreur 1452), et doit être corrigée à la main avant de
+-- relancer la migration, le schéma ramené à la version 2
+ALTER TABLE breeds
+    ADD CONSTRAINT fk_breeds_species FOREIGN KEY (species) REFERENCES species (code) ON UPDATE CASCADE,
+    ADD CONSTRAINT fk_breeds_pet_size FOREIGN KEY (pet_size) REFERENCES pet_sizes (code) ON UPDATE CASCADE;
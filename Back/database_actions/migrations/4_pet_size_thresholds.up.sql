@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS pet_size_thresholds (
+    species VARCHAR(50) NOT NULL,
+    pet_size VARCHAR(20) NOT NULL,
+    max_weight INT NULL,
+    PRIMARY KEY (species, pet_size),
+    CONSTRAINT fk_thresholds_species FOREIGN KEY (species) REFERENCES species (code) ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT fk_thresholds_pet_size FOREIGN KEY (pet_size) REFERENCES pet_sizes (code) ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+INSERT INTO pet_size_thresholds (species, pet_size, max_weight) VALUES
+    ('dog', 'small', 10000),
+    ('dog', 'medium', 25000),
+    ('dog', 'tall', NULL),
+    ('cat', 'small', 3500),
+    ('cat', 'medium', 6000),
+    ('cat', 'tall', NULL);
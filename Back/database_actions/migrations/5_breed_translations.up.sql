@@ -0,0 +1,7 @@
+CREATE TABLE IF NOT EXISTS breed_translations (
+    breed_id INT NOT NULL,
+    locale VARCHAR(8) NOT NULL,
+    display_name VARCHAR(255) NOT NULL,
+    PRIMARY KEY (breed_id, locale),
+    CONSTRAINT fk_translations_breed FOREIGN KEY (breed_id) REFERENCES breeds (id) ON DELETE CASCADE
+);
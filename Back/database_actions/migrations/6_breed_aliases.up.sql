@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS breed_aliases (
+    alias VARCHAR(100) NOT NULL PRIMARY KEY,
+    breed_id INT NOT NULL,
+    INDEX idx_aliases_breed (breed_id),
+    CONSTRAINT fk_aliases_breed FOREIGN KEY (breed_id) REFERENCES breeds (id) ON DELETE CASCADE
+);
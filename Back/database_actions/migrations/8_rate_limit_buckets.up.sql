@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS rate_limit_buckets (
+    bucket_key VARCHAR(191) NOT NULL PRIMARY KEY,
+    tokens DOUBLE NOT NULL,
+    updated_at DATETIME(6) NOT NULL,
+    INDEX idx_rate_limit_updated (updated_at)
+);
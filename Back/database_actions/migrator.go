@@ -2,28 +2,76 @@ package database_actions
 
 import (
 	"database/sql"
+	"embed"
 	"errors"
 	"fmt"
+	"io/fs"
 	"strconv"
 	"strings"
 
+	mysqlDriver "github.com/go-sql-driver/mysql"
 	"github.com/golang-migrate/migrate/v4"
 	"github.com/golang-migrate/migrate/v4/database"
 	"github.com/golang-migrate/migrate/v4/database/mysql"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/source"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
 )
 
 var driver database.Driver
 
+// Les migrations sont embarquées dans le binaire, qui connaît ainsi la
+// version de schéma qu'il attend
+//
+//go:embed migrations/*.sql
+var migrations embed.FS
+
+func migrationSource() (source.Driver, error) {
+	return iofs.New(migrations, "migrations")
+}
+
+// LatestVersion retourne la version de la dernière migration embarquée
+func LatestVersion() (uint, error) {
+	src, err := migrationSource()
+	if err != nil {
+		return 0, fmt.Errorf("error while reading embedded migrations: %w", err)
+	}
+	defer src.Close()
+
+	version, err := src.First()
+	if err != nil {
+		return 0, fmt.Errorf("error while reading embedded migrations: %w", err)
+	}
+	for {
+		next, err := src.Next(version)
+		if errors.Is(err, fs.ErrNotExist) {
+			return version, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("error while reading embedded migrations: %w", err)
+		}
+		version = next
+	}
+}
+
 // InitMigrator initiates values essential for migrations
 func InitMigrator(dsnMigrate string) error {
 	var err error
-	db, err := sql.Open("mysql", dsnMigrate)
+	// Les fichiers de migration contiennent plusieurs requêtes
+	cfg, err := mysqlDriver.ParseDSN(dsnMigrate)
+	if err != nil {
+		return fmt.Errorf("error while parsing migration dsn: %w", err)
+	}
+	cfg.MultiStatements = true
+
+	db, err := sql.Open("mysql", cfg.FormatDSN())
 	if err != nil {
 		return fmt.Errorf("error while opening db connection: %w", err)
 	}
 	driver, err = mysql.WithInstance(db, &mysql.Config{})
 	if err != nil {
+		// InitMigrator est relancé tant que MySQL démarre : chaque échec
+		// libère sa connexion
+		db.Close()
 		return fmt.Errorf("error while instanciating migration driver: %w", err)
 	}
 
@@ -34,11 +82,11 @@ func InitMigrator(dsnMigrate string) error {
 //
 // Default 'steps' as 0 (runs all migrations)
 func RunMigrate(migrationType string, steps int) (string, error) {
-	m, err := migrate.NewWithDatabaseInstance(
-		"file://database_actions/migrations",
-		"mysql",
-		driver,
-	)
+	src, err := migrationSource()
+	if err != nil {
+		return "", fmt.Errorf("error while reading embedded migrations: %w", err)
+	}
+	m, err := migrate.NewWithInstance("iofs", src, "mysql", driver)
 	if err != nil {
 		return "", fmt.Errorf("error while instanciating new migration ("+migrationType+") with DB : %w", err)
 	}
@@ -2,75 +2,452 @@ package internal
 
 import (
 	"database/sql"
-	"fmt"
+	"encoding/json"
+	"errors"
+	"io/fs"
 	"net/http"
+	"time"
 
 	charmLog "github.com/charmbracelet/log"
-	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/database_actions"
+	"github.com/japhy-tech/backend-test/internal/auth"
+	"github.com/japhy-tech/backend-test/internal/config"
+	"github.com/japhy-tech/backend-test/internal/deprecation"
+	"github.com/japhy-tech/backend-test/internal/graphql"
+	"github.com/japhy-tech/backend-test/internal/grpcapi"
 	"github.com/japhy-tech/backend-test/internal/handlers"
+	"github.com/japhy-tech/backend-test/internal/health"
+	"github.com/japhy-tech/backend-test/internal/i18n"
+	"github.com/japhy-tech/backend-test/internal/logging"
+	"github.com/japhy-tech/backend-test/internal/metrics"
+	"github.com/japhy-tech/backend-test/internal/problem"
+	"github.com/japhy-tech/backend-test/internal/ratelimit"
+	"github.com/japhy-tech/backend-test/internal/replicas"
 	"github.com/japhy-tech/backend-test/internal/repository"
+	"github.com/japhy-tech/backend-test/internal/retry"
 	"github.com/japhy-tech/backend-test/internal/service"
+	"github.com/japhy-tech/backend-test/internal/spa"
+	"github.com/japhy-tech/backend-test/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
 )
 
+// readRetryBackoff espace les nouvelles tentatives d'une lecture : la
+// requête HTTP attend, l'attente reste donc courte
+var readRetryBackoff = retry.Backoff{Initial: 50 * time.Millisecond, Max: 500 * time.Millisecond}
+
 type App struct {
 	logger      *charmLog.Logger
 	db          *sql.DB
-	breedRepo   *repository.BreedRepository
-	breedHandler *handlers.BreedHandler
+	breedRepo   repository.BreedRepositoryInterface
+	translationRepo *repository.BreedTranslationRepository
+	aliasRepo   *repository.BreedAliasRepository
+	v1          *v1Handlers
 	csvService  *service.CSVService
+	referenceService *service.ReferenceService
+	sizeClassifier *service.SizeClassifier
+	legacyPolicy deprecation.Policy
+	graphql     *graphql.Handler
+	graphiql    bool
+	grpc        *grpcapi.Server
+	apiKeyRepo  *repository.APIKeyRepository
+	authenticator *auth.Authenticator
+	access      *auth.Middleware
+	limiter     *ratelimit.Limiter
+	rateLimitStore ratelimit.Store
+	health      *health.Checker
+	metrics     *metrics.Metrics
+	replicas    *replicas.Set
+	front       fs.FS
+	frontPolicy string
 }
 
-func NewApp(logger *charmLog.Logger, db *sql.DB) *App {
-	breedRepo := repository.NewBreedRepository(db)
+func NewApp(logger *charmLog.Logger, db *sql.DB, replicaDBs []*sql.DB, cfg config.Config) *App {
+	// Les appels au repository des races sont mesurés et tracés, quelle que
+	// soit l'API qui les fait ; ses lectures sont relancées sur une erreur
+	// passagère et réparties entre les réplicas à jour
+	appMetrics := metrics.New(db)
+	readers := replicas.New(db, replicaDBs, replicas.Options{
+		MaxLag:       cfg.DBReplicaMaxLag,
+		CheckTimeout: cfg.DBReplicaCheckTimeout,
+		Logger:       logger,
+	})
+	readers.Start(cfg.DBReplicaCheckInterval)
+	breedRepo := metrics.NewBreedRepository(tracing.NewBreedRepository(
+		retry.NewBreedRepository(repository.NewReplicatedBreedRepository(db, readers), cfg.DBReadAttempts, readRetryBackoff),
+	), appMetrics)
+	speciesRepo := repository.NewSpeciesRepository(db)
+	petSizeRepo := repository.NewPetSizeRepository(db)
+	sizeThresholdRepo := repository.NewSizeThresholdRepository(db)
+	translationRepo := repository.NewBreedTranslationRepository(db)
+	aliasRepo := repository.NewBreedAliasRepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
 	
 	csvService := service.NewCSVService()
+	referenceService := service.NewReferenceService(speciesRepo, petSizeRepo)
+	sizeClassifier := service.NewSizeClassifier(sizeThresholdRepo, cfg.EnforcePetSizeConsistency)
+	translationService := service.NewBreedTranslationService(translationRepo)
+	authenticator := auth.NewAuthenticator(apiKeyRepo, auth.JWTConfig{
+		Secret:   cfg.AuthJWTSecret,
+		Issuer:   cfg.AuthJWTIssuer,
+		Audience: cfg.AuthJWTAudience,
+	})
+	
+	// Un seau inutilisé depuis la plus longue fenêtre (une heure) est plein
+	var rateLimitStore ratelimit.Store = ratelimit.NewMemoryStore(time.Hour)
+	if cfg.RateLimitStore == "database" {
+		rateLimitStore = ratelimit.NewDatabaseStore(repository.NewRateLimitRepository(db), time.Hour, logger)
+	}
+	limiter := ratelimit.NewLimiter(rateLimitStore, map[ratelimit.Class]ratelimit.Limit{
+		ratelimit.Read:   {Requests: cfg.RateLimitReadPerMinute, Window: time.Minute},
+		ratelimit.Write:  {Requests: cfg.RateLimitWritePerMinute, Window: time.Minute},
+		ratelimit.Import: {Requests: cfg.RateLimitImportPerHour, Window: time.Hour},
+		ratelimit.IP:     {Requests: cfg.RateLimitIPPerMinute, Window: time.Minute},
+	}, logger)
 	
-	breedHandler := handlers.NewBreedHandler(breedRepo, logger)
+	// Handlers de l'API v1
+	v1 := &v1Handlers{
+		breeds:             handlers.NewBreedHandler(breedRepo, referenceService, sizeClassifier, translationService, cfg.MaxPageLimit, logger),
+		translations:       handlers.NewBreedTranslationHandler(breedRepo, translationRepo, logger),
+		aliases:            handlers.NewBreedAliasHandler(breedRepo, aliasRepo, logger),
+		species:            handlers.NewReferenceHandler(speciesRepo, problem.CodeSpeciesNotFound, logger),
+		petSizes:           handlers.NewReferenceHandler(petSizeRepo, problem.CodePetSizeNotFound, logger),
+		sizeClassification: handlers.NewSizeClassificationHandler(sizeThresholdRepo, referenceService, logger),
+		apiKeys:            handlers.NewAPIKeyHandler(apiKeyRepo, logger),
+	}
+	
+	// Endpoint GraphQL, sur les mêmes repositories et services que l'API REST
+	graphqlHandler, err := graphql.NewHandler(breedRepo, referenceService, sizeClassifier, translationService, cfg.MaxPageLimit, graphql.Limits{
+		MaxDepth:      cfg.GraphQLMaxDepth,
+		MaxComplexity: cfg.GraphQLMaxComplexity,
+	}, logger)
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	
+	// Sondes : la base doit répondre et porter le schéma des migrations
+	// embarquées dans ce binaire
+	latestVersion, err := database_actions.LatestVersion()
+	if err != nil {
+		logger.Fatal(err.Error())
+	}
+	schemaRepo := repository.NewSchemaRepository(db)
+	checker := health.NewChecker(cfg.ReadinessTimeout,
+		health.Database(schemaRepo),
+		health.Migrations(schemaRepo, latestVersion),
+	)
+	
+	// Build du front, servi à côté de l'API s'il est configuré
+	var front fs.FS
+	switch {
+	case cfg.FrontDir != "":
+		front, err = spa.Dir(cfg.FrontDir)
+	case cfg.FrontEmbedded:
+		front, err = spa.Embedded()
+	}
+	if err != nil {
+		logger.Warn("Le front ne sera pas servi", "error", err, "dir", cfg.FrontDir)
+		front = nil
+	}
 	
 	return &App{
 		logger:       logger,
 		db:           db,
 		breedRepo:    breedRepo,
-		breedHandler: breedHandler,
+		translationRepo: translationRepo,
+		aliasRepo:    aliasRepo,
+		v1:           v1,
 		csvService:   csvService,
+		referenceService: referenceService,
+		sizeClassifier: sizeClassifier,
+		legacyPolicy: deprecation.Policy{
+			Since:     cfg.LegacyDeprecatedSince,
+			Sunset:    cfg.LegacySunset,
+			Successor: "/v1",
+		},
+		graphql:      graphqlHandler,
+		graphiql:     cfg.Development(),
+		grpc:         grpcapi.NewServer(breedRepo, referenceService, sizeClassifier, cfg.MaxPageLimit, logger),
+		apiKeyRepo:   apiKeyRepo,
+		authenticator: authenticator,
+		access:       auth.NewMiddleware(authenticator, logger),
+		limiter:      limiter,
+		rateLimitStore: rateLimitStore,
+		health:       checker,
+		metrics:      appMetrics,
+		replicas:     readers,
+		front:        front,
+		frontPolicy:  cfg.FrontContentSecurityPolicy,
+	}
+}
+
+// BeginShutdown fait échouer /readyz et la santé gRPC, pour que l'instance
+// soit retirée du trafic avant l'arrêt des serveurs
+func (a *App) BeginShutdown() {
+	a.health.Shutdown()
+	a.grpc.Shutdown()
+}
+
+// Close attend la fin des tâches de fond de l'application, à appeler une
+// fois les serveurs arrêtés et avant de fermer la base
+func (a *App) Close() {
+	a.replicas.Close()
+	if store, ok := a.rateLimitStore.(*ratelimit.DatabaseStore); ok {
+		store.Close()
+	}
+}
+
+// EnsureBootstrapKey enregistre la clé admin fournie au démarrage si elle ne
+// l'est pas déjà ; une clé révoquée depuis le reste
+func (a *App) EnsureBootstrapKey(key string) error {
+	if key == "" {
+		return nil
+	}
+	
+	prefix := key
+	if len(prefix) > 12 {
+		prefix = prefix[:12]
+	}
+	
+	err := a.apiKeyRepo.Create(&repository.APIKey{Name: "bootstrap", Prefix: prefix, Hash: auth.HashKey(key), Role: string(auth.RoleAdmin)})
+	if err != nil && !errors.Is(err, repository.ErrDuplicateName) {
+		return err
 	}
+	return nil
 }
 
-func (a *App) RegisterRoutes(r *mux.Router) {
-	// Routes pour les races
-	r.HandleFunc("/breeds", a.breedHandler.GetAllBreeds).Methods(http.MethodGet)
-	r.HandleFunc("/breeds", a.breedHandler.CreateBreed).Methods(http.MethodPost)
-	r.HandleFunc("/breeds/{id:[0-9]+}", a.breedHandler.GetBreedByID).Methods(http.MethodGet)
-	r.HandleFunc("/breeds/{id:[0-9]+}", a.breedHandler.UpdateBreed).Methods(http.MethodPut)
-	r.HandleFunc("/breeds/{id:[0-9]+}", a.breedHandler.DeleteBreed).Methods(http.MethodDelete)
-	r.HandleFunc("/import-breeds", a.ImportBreedsFromCSV).Methods(http.MethodPost)
+// ImportResponse est le corps de la réponse de POST /import-breeds
+type ImportResponse struct {
+	Message string `json:"message"`
+	Count   int    `json:"count"`
 }
 
 func (a *App) ImportBreedsFromCSV(w http.ResponseWriter, r *http.Request) {
-	a.logger.Info("Début de l'import des races depuis le CSV")
+	logger := logging.FromContext(r.Context(), a.logger)
+	logger.Info("Début de l'import des races depuis le CSV")
+	run := a.metrics.StartImport("breeds")
+	defer run.Finish()
 	
 	// Pour lire les races depuis le fichier CSV
+	_, parse := tracing.Start(r.Context(), "csv.parse", attribute.String("csv.file", "./breeds.csv"))
 	breeds, err := a.csvService.ReadBreedsFromCSV("./breeds.csv")
+	parse.SetAttributes(attribute.Int("csv.rows", len(breeds)))
+	tracing.End(parse, err)
 	if err != nil {
-		a.logger.Error("Erreur lors de la lecture du CSV", "error", err)
-		http.Error(w, "Erreur lors de la lecture du fichier CSV: "+err.Error(), http.StatusInternalServerError)
+		a.internalError(w, r, "Erreur lors de la lecture du CSV", err)
 		return
 	}
 	
-	a.logger.Info("Races lues depuis le CSV", "count", len(breeds))
+	logger.Info("Races lues depuis le CSV", "count", len(breeds))
+	run.Read(len(breeds))
+	
+	// Pour rejeter un fichier dont les poids ne sont visiblement pas en grammes
+	err = a.csvService.ValidateWeightMagnitudes(breeds)
+	if err != nil {
+		logger.Warn("Poids invalides dans le CSV", "error", err)
+		run.Reject(len(breeds))
+		problem.Write(w, r, problem.Localized(http.StatusBadRequest, problem.CodeInvalidCSV, i18n.FromError(err)))
+		return
+	}
+	
+	// Pour rejeter les espèces et tailles inconnues avant tout accès en écriture
+	refs, err := a.referenceService.Snapshot()
+	if err != nil {
+		a.internalError(w, r, "Erreur lors du chargement des données de référence", err)
+		return
+	}
+	
+	err = a.csvService.ValidateReferences(breeds, refs)
+	if err != nil {
+		logger.Warn("Données de référence invalides dans le CSV", "error", err)
+		run.Reject(len(breeds))
+		problem.Write(w, r, problem.Localized(http.StatusBadRequest, problem.CodeInvalidCSV, i18n.FromError(err)))
+		return
+	}
+	
+	// Pour déduire les tailles manquantes et contrôler leur cohérence avec les poids
+	classification, err := a.sizeClassifier.Snapshot()
+	if err != nil {
+		a.internalError(w, r, "Erreur lors du chargement des seuils de taille", err)
+		return
+	}
+	
+	err = a.csvService.ApplySizeClassification(breeds, classification)
+	if err != nil {
+		logger.Warn("Tailles incohérentes dans le CSV", "error", err)
+		run.Reject(len(breeds))
+		problem.Write(w, r, problem.Localized(http.StatusBadRequest, problem.CodeInvalidCSV, i18n.FromError(err)))
+		return
+	}
 	
 	// Afin d'importer les races dans la base de données
-	err = a.breedRepo.ImportFromCSV(breeds)
+	ctx, store := tracing.Start(r.Context(), "csv.import", attribute.Int("import.rows", len(breeds)))
+	stats, err := a.breedRepo.ImportFromCSV(ctx, breeds)
+	store.SetAttributes(tracing.StatsAttributes(stats)...)
+	tracing.End(store, err)
 	if err != nil {
-		a.logger.Error("Erreur lors de l'import en base", "error", err)
-		http.Error(w, "Erreur lors de l'import en base de données: "+err.Error(), http.StatusInternalServerError)
+		if details := problem.FromRepositoryError(err, problem.CodeBreedNotFound); details != nil {
+			problem.Write(w, r, details)
+			return
+		}
+		a.internalError(w, r, "Erreur lors de l'import en base", err)
 		return
 	}
 	
-	a.logger.Info("Import des races terminé avec succès", "count", len(breeds))
+	run.Succeed(stats)
+	logger.Info("Import des races terminé avec succès", "count", len(breeds), "inserted", stats.Inserted, "updated", stats.Updated)
 	
+	lang := i18n.FromRequest(r)
+	i18n.SetContentLanguage(w, lang)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusOK)
-	response := fmt.Sprintf(`{"message": "Import des races terminé avec succès", "count": %d}`, len(breeds))
-	w.Write([]byte(response))
+	json.NewEncoder(w).Encode(ImportResponse{
+		Message: i18n.T(lang, i18n.ImportSucceeded),
+		Count:   len(breeds),
+	})
+}
+
+// ImportBreedTranslationsFromCSV importe les noms d'affichage du fichier
+// breed_translations.csv (colonnes name, locale, display_name) ; les races
+// doivent avoir été importées auparavant
+func (a *App) ImportBreedTranslationsFromCSV(w http.ResponseWriter, r *http.Request) {
+	logger := logging.FromContext(r.Context(), a.logger)
+	logger.Info("Début de l'import des traductions depuis le CSV")
+	run := a.metrics.StartImport("translations")
+	defer run.Finish()
+	
+	_, parse := tracing.Start(r.Context(), "csv.parse", attribute.String("csv.file", "./breed_translations.csv"))
+	rows, err := a.csvService.ReadBreedTranslationsFromCSV("./breed_translations.csv")
+	parse.SetAttributes(attribute.Int("csv.rows", len(rows)))
+	tracing.End(parse, err)
+	if err != nil {
+		a.internalError(w, r, "Erreur lors de la lecture du CSV des traductions", err)
+		return
+	}
+	run.Read(len(rows))
+	
+	// Pour retrouver l'ID de chaque race à partir de son identifiant ; lu sur
+	// la base principale pour voir les races tout juste importées
+	breeds, err := a.breedRepo.GetAll(repository.ReadPrimary(r.Context()), repository.BreedFilter{})
+	if err != nil {
+		a.internalError(w, r, "Erreur lors de la récupération des races", err)
+		return
+	}
+	
+	translations, err := a.csvService.ResolveBreedTranslations(rows, breeds)
+	if err != nil {
+		logger.Warn("Traductions invalides dans le CSV", "error", err)
+		run.Reject(len(rows))
+		problem.Write(w, r, problem.Localized(http.StatusBadRequest, problem.CodeInvalidCSV, i18n.FromError(err)))
+		return
+	}
+	
+	_, store := tracing.Start(r.Context(), "csv.import", attribute.Int("import.rows", len(translations)))
+	stats, err := a.translationRepo.Import(translations)
+	store.SetAttributes(tracing.StatsAttributes(stats)...)
+	tracing.End(store, err)
+	if err != nil {
+		a.internalError(w, r, "Erreur lors de l'import des traductions en base", err)
+		return
+	}
+	
+	run.Succeed(stats)
+	logger.Info("Import des traductions terminé avec succès", "count", len(translations), "inserted", stats.Inserted, "updated", stats.Updated)
+	
+	lang := i18n.FromRequest(r)
+	i18n.SetContentLanguage(w, lang)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ImportResponse{
+		Message: i18n.T(lang, i18n.TranslationImportSucceeded),
+		Count:   len(translations),
+	})
+}
+
+// ImportBreedAliasesFromCSV importe les alias du fichier breed_aliases.csv
+// (colonnes name, alias) ; un alias qui désignerait deux races fait échouer
+// tout l'import
+func (a *App) ImportBreedAliasesFromCSV(w http.ResponseWriter, r *http.Request) {
+	logger := logging.FromContext(r.Context(), a.logger)
+	logger.Info("Début de l'import des alias depuis le CSV")
+	run := a.metrics.StartImport("aliases")
+	defer run.Finish()
+	
+	_, parse := tracing.Start(r.Context(), "csv.parse", attribute.String("csv.file", "./breed_aliases.csv"))
+	rows, err := a.csvService.ReadBreedAliasesFromCSV("./breed_aliases.csv")
+	parse.SetAttributes(attribute.Int("csv.rows", len(rows)))
+	tracing.End(parse, err)
+	if err != nil {
+		a.internalError(w, r, "Erreur lors de la lecture du CSV des alias", err)
+		return
+	}
+	run.Read(len(rows))
+	
+	// Pour retrouver l'ID de chaque race et détecter les conflits avec les
+	// noms et alias existants, sur la base principale comme pour les
+	// traductions
+	breeds, err := a.breedRepo.GetAll(repository.ReadPrimary(r.Context()), repository.BreedFilter{})
+	if err != nil {
+		a.internalError(w, r, "Erreur lors de la récupération des races", err)
+		return
+	}
+	
+	existing, err := a.aliasRepo.List()
+	if err != nil {
+		a.internalError(w, r, "Erreur lors de la récupération des alias", err)
+		return
+	}
+	
+	aliases, err := a.csvService.ResolveBreedAliases(rows, breeds, existing)
+	if err != nil {
+		logger.Warn("Alias invalides dans le CSV", "error", err)
+		run.Reject(len(rows))
+		problem.Write(w, r, problem.Localized(http.StatusBadRequest, problem.CodeInvalidCSV, i18n.FromError(err)))
+		return
+	}
+	
+	_, store := tracing.Start(r.Context(), "csv.import", attribute.Int("import.rows", len(aliases)))
+	stats, err := a.aliasRepo.Import(aliases)
+	store.SetAttributes(tracing.StatsAttributes(stats)...)
+	tracing.End(store, err)
+	if err != nil {
+		if details := problem.FromRepositoryError(err, problem.CodeBreedNotFound); details != nil {
+			problem.Write(w, r, details)
+			return
+		}
+		a.internalError(w, r, "Erreur lors de l'import des alias en base", err)
+		return
+	}
+	
+	run.Succeed(stats)
+	logger.Info("Import des alias terminé avec succès", "count", len(aliases), "inserted", stats.Inserted)
+	
+	lang := i18n.FromRequest(r)
+	i18n.SetContentLanguage(w, lang)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	json.NewEncoder(w).Encode(ImportResponse{
+		Message: i18n.T(lang, i18n.AliasImportSucceeded),
+		Count:   len(aliases),
+	})
+}
+
+// ExportBreedAliasesToCSV renvoie tous les alias au format de l'import
+func (a *App) ExportBreedAliasesToCSV(w http.ResponseWriter, r *http.Request) {
+	aliases, err := a.aliasRepo.List()
+	if err != nil {
+		a.internalError(w, r, "Erreur lors de la récupération des alias", err)
+		return
+	}
+	
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", `attachment; filename="breed_aliases.csv"`)
+	if err := a.csvService.WriteBreedAliasesCSV(w, aliases); err != nil {
+		logging.FromContext(r.Context(), a.logger).Error("Erreur lors de l'export des alias", "error", err)
+	}
+}
+
+// internalError logge l'erreur et renvoie une 500 au détail générique
+func (a *App) internalError(w http.ResponseWriter, r *http.Request, message string, err error) {
+	logging.FromContext(r.Context(), a.logger).Error(message, "error", err)
+	problem.Write(w, r, problem.Internal())
 }
\ No newline at end of file
@@ -0,0 +1,682 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/charmbracelet/log"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/auth"
+	"github.com/japhy-tech/backend-test/internal/config"
+	"github.com/japhy-tech/backend-test/internal/openapi"
+)
+
+var (
+	breedColumns   = []string{"id", "species", "pet_size", "name", "average_male_adult_weight", "average_female_adult_weight"}
+	resolveColumns = append(append([]string{}, breedColumns...), "by_alias")
+	routeVariable  = regexp.MustCompile(`\{([^}:]+):[^}]+\}`)
+)
+
+const testJWTSecret = "secret-de-test"
+
+// bearer retourne l'en-tête Authorization d'un jeton valide pour un rôle
+func bearer(t *testing.T, role auth.Role) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":  "test-" + string(role),
+		"role": string(role),
+		"exp":  time.Now().Add(time.Hour).Unix(),
+	}).SignedString([]byte(testJWTSecret))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return "Bearer " + token
+}
+
+// testIPBudget est le nombre de requêtes par minute d'une même adresse
+const testIPBudget = 30
+
+// newTestRouter monte l'API sur une base simulée ; options ajuste la
+// configuration de test
+func newTestRouter(t *testing.T, options ...func(*config.Config)) (*mux.Router, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Erreur lors de la création du mock: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	logger := log.NewWithOptions(nil, log.Options{})
+	cfg := config.Config{
+		Environment:           "development",
+		MaxPageLimit:          100,
+		LegacyDeprecatedSince: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC),
+		LegacySunset:          time.Date(2027, 4, 30, 0, 0, 0, 0, time.UTC),
+		GraphQLMaxDepth:       5,
+		GraphQLMaxComplexity:  1000,
+		AuthJWTSecret:         testJWTSecret,
+		// Une seule écriture par minute pour éprouver la limitation ; les
+		// lectures ne sont pas limitées
+		RateLimitWritePerMinute: 1,
+		RateLimitIPPerMinute:    testIPBudget,
+	}
+	for _, option := range options {
+		option(&cfg)
+	}
+	app := NewApp(logger, db, nil, cfg)
+
+	r := mux.NewRouter()
+	app.RegisterRoutes(r)
+	return r, mock
+}
+
+func loadSpec(t *testing.T) *openapi.Spec {
+	t.Helper()
+
+	spec, err := openapi.Load()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return spec
+}
+
+// Chaque route enregistrée doit être documentée avec sa méthode ; les anciens
+// chemins sans version le sont à travers leur équivalent /v1
+func TestOpenAPI_DocumentsEveryRoute(t *testing.T) {
+	router, _ := newTestRouter(t)
+	spec := loadSpec(t)
+
+	err := router.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		if route.GetHandler() == nil {
+			// Sous-routeur (/v1, anciens chemins)
+			return nil
+		}
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			return err
+		}
+		template = routeVariable.ReplaceAllString(template, "{$1}")
+
+		methods, err := route.GetMethods()
+		if err != nil {
+			t.Errorf("route %s sans méthode", template)
+			return nil
+		}
+		for _, method := range methods {
+			if !spec.HasOperation(method, template) && !spec.HasOperation(method, "/v1"+template) {
+				t.Errorf("%s %s n'est pas documentée dans openapi.json", method, template)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Erreur lors du parcours des routes: %v", err)
+	}
+}
+
+// Les réponses réelles, succès comme erreurs, doivent respecter les schémas
+// de la spécification
+func TestOpenAPI_ResponsesMatchSpec(t *testing.T) {
+	spec := loadSpec(t)
+
+	cases := []struct {
+		name   string
+		method string
+		target string
+		body   string
+		header map[string]string
+		// role est le rôle de l'appelant, admin par défaut ; anonymous
+		// n'envoie aucun identifiant
+		role      auth.Role
+		anonymous bool
+		expect    func(mock sqlmock.Sqlmock)
+		status    int
+	}{
+		{
+			name:   "liste des races",
+			method: http.MethodGet,
+			target: "/v1/breeds?species=dog&weight_min=10&weight_max=30&unit=kg&sex=both",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM breeds").WillReturnRows(sqlmock.NewRows(breedColumns).
+					AddRow(1, "dog", "medium", "border_collie", 20000, 18000))
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "liste des races traduites",
+			method: http.MethodGet,
+			target: "/v1/breeds?lang=fr",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM breeds").WillReturnRows(sqlmock.NewRows(breedColumns).
+					AddRow(1, "dog", "medium", "border_collie", 20000, 18000))
+				mock.ExpectQuery("FROM breed_translations").WillReturnRows(sqlmock.NewRows([]string{"breed_id", "display_name"}).
+					AddRow(1, "Border Collie"))
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "paramètres invalides",
+			method: http.MethodGet,
+			target: "/v1/breeds?limit=0&sex=unknown",
+			header: map[string]string{"Accept-Language": "en"},
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "erreur interne",
+			method: http.MethodGet,
+			target: "/v1/breeds",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM breeds").WillReturnError(sqlmock.ErrCancelled)
+			},
+			status: http.StatusInternalServerError,
+		},
+		{
+			name:   "détail d'une race",
+			method: http.MethodGet,
+			target: "/v1/breeds/1?unit=lb",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM breeds WHERE id").WillReturnRows(sqlmock.NewRows(breedColumns).
+					AddRow(1, "dog", "medium", "border_collie", 20000, 18000))
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "race inconnue",
+			method: http.MethodGet,
+			target: "/v1/breeds/999",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM breeds WHERE id").WillReturnRows(sqlmock.NewRows(breedColumns))
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "résolution d'un alias",
+			method: http.MethodGet,
+			target: "/v1/breeds/resolve?name=Yorkie",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("UNION ALL").WillReturnRows(sqlmock.NewRows(resolveColumns).
+					AddRow(7, "dog", "small", "yorkshire_terrier", 3000, 2800, 1))
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "résolution sans nom",
+			method: http.MethodGet,
+			target: "/v1/breeds/resolve",
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "création d'une race",
+			method: http.MethodPost,
+			target: "/v1/breeds",
+			body:   `{"species": "dog", "name": "border_collie", "average_male_adult_weight": "20kg", "average_female_adult_weight": 18000}`,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM species").WillReturnRows(sqlmock.NewRows([]string{"code", "label"}).AddRow("dog", "Chien"))
+				mock.ExpectQuery("FROM pet_sizes").WillReturnRows(sqlmock.NewRows([]string{"code", "label"}).AddRow("medium", "Moyen"))
+				mock.ExpectQuery("FROM pet_size_thresholds").WillReturnRows(sqlmock.NewRows([]string{"species", "pet_size", "max_weight"}).
+					AddRow("dog", "medium", nil))
+				mock.ExpectExec("INSERT INTO breeds").WillReturnResult(sqlmock.NewResult(12, 1))
+			},
+			status: http.StatusCreated,
+		},
+		{
+			name:   "création avec corps invalide",
+			method: http.MethodPost,
+			target: "/v1/breeds",
+			body:   `{"name":`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "suppression d'une race",
+			method: http.MethodDelete,
+			target: "/v1/breeds/1",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("DELETE FROM breeds").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "traductions d'une race",
+			method: http.MethodGet,
+			target: "/v1/breeds/1/translations",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM breeds WHERE id").WillReturnRows(sqlmock.NewRows(breedColumns).
+					AddRow(1, "dog", "medium", "border_collie", 20000, 18000))
+				mock.ExpectQuery("FROM breed_translations").WillReturnRows(sqlmock.NewRows([]string{"breed_id", "locale", "display_name"}).
+					AddRow(1, "fr", "Border Collie"))
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "alias d'une race",
+			method: http.MethodGet,
+			target: "/v1/breeds/7/aliases",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM breeds WHERE id").WillReturnRows(sqlmock.NewRows(breedColumns).
+					AddRow(7, "dog", "small", "yorkshire_terrier", 3000, 2800))
+				mock.ExpectQuery("FROM breed_aliases").WillReturnRows(sqlmock.NewRows([]string{"alias", "breed_id", "name"}).
+					AddRow("yorkie", 7, "yorkshire_terrier"))
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "alias déjà utilisé",
+			method: http.MethodPost,
+			target: "/v1/breeds/1/aliases",
+			body:   `{"alias": "Yorkie"}`,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM breeds WHERE id").WillReturnRows(sqlmock.NewRows(breedColumns).
+					AddRow(1, "dog", "medium", "border_collie", 20000, 18000))
+				mock.ExpectQuery("UNION ALL").WillReturnRows(sqlmock.NewRows(resolveColumns).
+					AddRow(7, "dog", "small", "yorkshire_terrier", 3000, 2800, 1))
+			},
+			status: http.StatusConflict,
+		},
+		{
+			name:   "export des alias",
+			method: http.MethodGet,
+			target: "/v1/export-breed-aliases",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM breed_aliases").WillReturnRows(sqlmock.NewRows([]string{"alias", "breed_id", "name"}).
+					AddRow("yorkie", 7, "yorkshire_terrier"))
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "liste des espèces",
+			method: http.MethodGet,
+			target: "/v1/species",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM species").WillReturnRows(sqlmock.NewRows([]string{"code", "label"}).
+					AddRow("cat", "Chat").AddRow("dog", "Chien"))
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "taille inconnue",
+			method: http.MethodGet,
+			target: "/v1/pet-sizes/huge",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM pet_sizes").WillReturnRows(sqlmock.NewRows([]string{"code", "label"}))
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "seuils de taille",
+			method: http.MethodGet,
+			target: "/v1/size-classification",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM pet_size_thresholds").WillReturnRows(sqlmock.NewRows([]string{"species", "pet_size", "max_weight"}).
+					AddRow("dog", "small", 10000).AddRow("dog", "tall", nil))
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "sonde de vie",
+			method:    http.MethodGet,
+			target:    "/livez",
+			anonymous: true,
+			status:    http.StatusOK,
+		},
+		{
+			name:   "sonde de disponibilité",
+			method: http.MethodGet,
+			target: "/readyz",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM schema_migrations").WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(8, false))
+			},
+			anonymous: true,
+			status:    http.StatusOK,
+		},
+		{
+			name:   "migration interrompue",
+			method: http.MethodGet,
+			target: "/readyz",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM schema_migrations").WillReturnRows(sqlmock.NewRows([]string{"version", "dirty"}).AddRow(8, true))
+			},
+			anonymous: true,
+			status:    http.StatusServiceUnavailable,
+		},
+		{
+			name:      "appel sans identifiants",
+			method:    http.MethodGet,
+			target:    "/v1/breeds",
+			anonymous: true,
+			status:    http.StatusUnauthorized,
+		},
+		{
+			name:   "rôle insuffisant",
+			method: http.MethodDelete,
+			target: "/v1/breeds/1",
+			role:   auth.RoleEditor,
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "suppression d'une traduction par un editor",
+			method: http.MethodDelete,
+			target: "/v1/breeds/1/translations/fr",
+			role:   auth.RoleEditor,
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "suppression d'un alias par un editor",
+			method: http.MethodDelete,
+			target: "/v1/breeds/1/aliases/colley",
+			role:   auth.RoleEditor,
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "suppression d'une espèce par un editor",
+			method: http.MethodDelete,
+			target: "/v1/species/cat",
+			role:   auth.RoleEditor,
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "suppression d'une taille par un editor",
+			method: http.MethodDelete,
+			target: "/v1/pet-sizes/small",
+			role:   auth.RoleEditor,
+			status: http.StatusForbidden,
+		},
+		{
+			name:   "liste des clés d'API",
+			method: http.MethodGet,
+			target: "/v1/api-keys",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM api_keys").WillReturnRows(sqlmock.NewRows([]string{"id", "name", "prefix", "key_hash", "role", "created_at", "revoked_at"}).
+					AddRow(2, "back-office", "jpk_0123abcd", "hash", "editor", time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC), nil).
+					AddRow(1, "bootstrap", "jpk_dev_admi", "hash", "admin", time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC), time.Date(2026, 10, 18, 8, 0, 0, 0, time.UTC)))
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "création d'une clé d'API",
+			method: http.MethodPost,
+			target: "/v1/api-keys",
+			body:   `{"name": "back-office", "role": "editor"}`,
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("INSERT INTO api_keys").WillReturnResult(sqlmock.NewResult(3, 1))
+			},
+			status: http.StatusCreated,
+		},
+		{
+			name:   "clé d'API au rôle inconnu",
+			method: http.MethodPost,
+			target: "/v1/api-keys",
+			body:   `{"name": "back-office", "role": "root"}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "révocation d'une clé inconnue",
+			method: http.MethodDelete,
+			target: "/v1/api-keys/99",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("UPDATE api_keys SET revoked_at").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "espèce dans une autre casse",
+			method: http.MethodGet,
+			target: "/v1/species/Cat",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("FROM species WHERE code").WithArgs("cat").WillReturnRows(sqlmock.NewRows([]string{"code", "label"}).AddRow("cat", "Chat"))
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "suppression d'une taille dans une autre casse",
+			method: http.MethodDelete,
+			target: "/v1/pet-sizes/SMALL",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("DELETE FROM pet_sizes").WithArgs("small").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "métriques",
+			method: http.MethodGet,
+			target: "/metrics",
+			role:   auth.RoleReader,
+			status: http.StatusOK,
+		},
+		{
+			name:      "métriques sans identifiants",
+			method:    http.MethodGet,
+			target:    "/metrics",
+			anonymous: true,
+			status:    http.StatusUnauthorized,
+		},
+		{
+			name:      "spécification sans identifiants",
+			method:    http.MethodGet,
+			target:    "/openapi.json",
+			anonymous: true,
+			status:    http.StatusOK,
+		},
+		{
+			name:   "documentation",
+			method: http.MethodGet,
+			target: "/docs",
+			status: http.StatusOK,
+		},
+		{
+			name:   "requête GraphQL",
+			method: http.MethodPost,
+			target: "/graphql",
+			body:   `{"query": "{ breeds(page: {limit: 10}) { id name petSize averageMaleAdultWeight(unit: KG) } breed(id: 999) { id } }"}`,
+			expect: func(mock sqlmock.Sqlmock) {
+				// graphql-go résout les champs d'une requête dans un ordre
+				// quelconque
+				mock.MatchExpectationsInOrder(false)
+				mock.ExpectQuery("FROM breeds WHERE 1=1").WillReturnRows(sqlmock.NewRows(breedColumns).
+					AddRow(1, "dog", "medium", "border_collie", 20000, 18000))
+				mock.ExpectQuery("FROM breeds WHERE id").WillReturnRows(sqlmock.NewRows(breedColumns))
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "requête GraphQL invalide",
+			method: http.MethodPost,
+			target: "/graphql",
+			body:   `{"query": "{ breeds { unknown } }"}`,
+			status: http.StatusBadRequest,
+		},
+		{
+			name:   "GraphiQL",
+			method: http.MethodGet,
+			target: "/graphql",
+			status: http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			router, mock := newTestRouter(t)
+			if tc.expect != nil {
+				tc.expect(mock)
+			}
+
+			req := httptest.NewRequest(tc.method, tc.target, strings.NewReader(tc.body))
+			if !tc.anonymous {
+				role := tc.role
+				if role == "" {
+					role = auth.RoleAdmin
+				}
+				req.Header.Set("Authorization", bearer(t, role))
+			}
+			for name, value := range tc.header {
+				req.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			if w.Code != tc.status {
+				t.Fatalf("attendu %d, obtenu %d: %s", tc.status, w.Code, w.Body.String())
+			}
+			if err := spec.ValidateResponse(tc.method, req.URL.Path, w.Code, w.Header().Get("Content-Type"), w.Body.Bytes()); err != nil {
+				t.Errorf("réponse non conforme à la spécification: %v\n%s", err, w.Body.String())
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Toutes les attentes du mock n'ont pas été satisfaites: %v", err)
+			}
+		})
+	}
+}
+
+// Les anciens chemins répondent comme /v1, avec les en-têtes de dépréciation
+func TestLegacyRoutes_Deprecated(t *testing.T) {
+	router, mock := newTestRouter(t)
+	mock.ExpectQuery("FROM breeds WHERE id").WillReturnRows(sqlmock.NewRows(breedColumns).
+		AddRow(1, "dog", "medium", "border_collie", 20000, 18000))
+	mock.ExpectQuery("FROM breeds WHERE id").WillReturnRows(sqlmock.NewRows(breedColumns).
+		AddRow(1, "dog", "medium", "border_collie", 20000, 18000))
+
+	legacy := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/breeds/1", nil)
+	req.Header.Set("Authorization", bearer(t, auth.RoleReader))
+	router.ServeHTTP(legacy, req)
+	current := httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/v1/breeds/1", nil)
+	req.Header.Set("Authorization", bearer(t, auth.RoleReader))
+	router.ServeHTTP(current, req)
+
+	if legacy.Code != http.StatusOK || legacy.Body.String() != current.Body.String() {
+		t.Fatalf("l'ancien chemin devrait répondre comme /v1, obtenu %d: %s", legacy.Code, legacy.Body.String())
+	}
+	if got := legacy.Header().Get("Deprecation"); got != "@1792368000" {
+		t.Errorf("Deprecation attendu @1792368000, obtenu %q", got)
+	}
+	if got := legacy.Header().Get("Sunset"); got != "Fri, 30 Apr 2027 00:00:00 GMT" {
+		t.Errorf("Sunset inattendu %q", got)
+	}
+	if got := legacy.Header().Get("Link"); got != `</v1/breeds/1>; rel="successor-version"` {
+		t.Errorf("Link inattendu %q", got)
+	}
+	if current.Header().Get("Deprecation") != "" {
+		t.Errorf("les routes /v1 ne devraient pas être dépréciées")
+	}
+
+	// Une méthode non prévue reste une 405, une version inconnue une 404
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPatch, "/v1/breeds", nil))
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("attendu 405, obtenu %d", w.Code)
+	}
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v2/breeds", nil))
+	if w.Code != http.StatusNotFound {
+		t.Errorf("attendu 404, obtenu %d", w.Code)
+	}
+}
+
+// Le budget d'écriture est partagé entre /v1 et les anciens chemins, et son
+// dépassement est documenté
+// Avec le front servi, un chemin inconnu sous un préfixe de l'API, versionné
+// ou non, reste une 404 de l'API au lieu de servir index.html
+func TestFront_LeavesAPIPrefixes(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "index.html"), []byte("<!doctype html>"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	router, _ := newTestRouter(t, func(cfg *config.Config) {
+		cfg.FrontDir = dir
+		cfg.Environment = "production"
+	})
+
+	// Sans GraphiQL, GET /graphql n'est pas une route du front
+	req := httptest.NewRequest(http.MethodGet, "/graphql", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusMethodNotAllowed || !strings.HasPrefix(w.Header().Get("Content-Type"), "application/problem+json") {
+		t.Errorf("/graphql: attendu une 405 de l'API, obtenu %d %s", w.Code, w.Header().Get("Content-Type"))
+	}
+
+	for _, target := range []string{"/breeds/abc", "/v1/breeds/abc", "/species/cat/extra", "/import-breeds/x", "/docs/x", "/metrics/x"} {
+		req = httptest.NewRequest(http.MethodGet, target, nil)
+		w = httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusNotFound || !strings.HasPrefix(w.Header().Get("Content-Type"), "application/problem+json") {
+			t.Errorf("%s: attendu une 404 de l'API, obtenu %d %s", target, w.Code, w.Header().Get("Content-Type"))
+		}
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/breeds-catalog", nil)
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), "<!doctype html>") {
+		t.Errorf("attendu index.html pour une route du front, obtenu %d", w.Code)
+	}
+}
+
+func TestRateLimit_WriteBudget(t *testing.T) {
+	spec := loadSpec(t)
+	router, _ := newTestRouter(t)
+	token := bearer(t, auth.RoleAdmin)
+
+	send := func(target string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, target, strings.NewReader("{"))
+		req.Header.Set("Authorization", token)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := send("/v1/breeds"); w.Code != http.StatusBadRequest || w.Header().Get("RateLimit-Remaining") != "0" {
+		t.Fatalf("attendu 400 avec RateLimit-Remaining 0, obtenu %d %v", w.Code, w.Header())
+	}
+
+	w := send("/breeds")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("attendu 429, obtenu %d: %s", w.Code, w.Body.String())
+	}
+	if w.Header().Get("Retry-After") != "60" {
+		t.Errorf("Retry-After attendu 60, obtenu %q", w.Header().Get("Retry-After"))
+	}
+	if err := spec.ValidateResponse(http.MethodPost, "/v1/breeds", w.Code, w.Header().Get("Content-Type"), w.Body.Bytes()); err != nil {
+		t.Errorf("réponse non conforme à la spécification: %v\n%s", err, w.Body.String())
+	}
+}
+
+// Les jetons refusés épuisent le budget de l'adresse, qui est vérifié avant
+// l'authentification
+func TestRateLimit_RejectedCredentialsUseAddressBudget(t *testing.T) {
+	router, _ := newTestRouter(t)
+	forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "intrus", "role": "admin"}).SignedString([]byte("mauvais-secret"))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/v1/breeds", nil)
+		req.Header.Set("Authorization", "Bearer "+forged)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	for i := 0; i < testIPBudget; i++ {
+		if w := send(); w.Code != http.StatusUnauthorized {
+			t.Fatalf("essai %d : attendu 401, obtenu %d", i+1, w.Code)
+		}
+	}
+
+	w := send()
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("Retry-After") == "" {
+		t.Fatalf("attendu 429 avec Retry-After, obtenu %d %v", w.Code, w.Header())
+	}
+}
@@ -0,0 +1,246 @@
+// Package auth authentifie les appels à l'API, par clé d'API ou par jeton
+// JWT, et contrôle le rôle requis par chaque route
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/japhy-tech/backend-test/internal/repository"
+)
+
+// Role détermine ce qu'un appelant peut faire ; chaque rôle inclut les droits
+// des rôles inférieurs
+type Role string
+
+const (
+	// RoleReader lit les races et les données de référence
+	RoleReader Role = "reader"
+	// RoleEditor crée et modifie les races, alias, traductions et références
+	RoleEditor Role = "editor"
+	// RoleAdmin supprime les races, lance les imports et gère les clés
+	RoleAdmin Role = "admin"
+)
+
+// Roles liste les rôles, du moins au plus privilégié
+var Roles = []Role{RoleReader, RoleEditor, RoleAdmin}
+
+// ParseRole retourne le rôle correspondant au nom donné
+func ParseRole(name string) (Role, bool) {
+	for _, role := range Roles {
+		if string(role) == name {
+			return role, true
+		}
+	}
+	return "", false
+}
+
+func (r Role) rank() int {
+	for i, role := range Roles {
+		if role == r {
+			return i
+		}
+	}
+	return -1
+}
+
+// Allows indique si le rôle donne les droits du rôle requis
+func (r Role) Allows(required Role) bool {
+	return r.rank() >= 0 && r.rank() >= required.rank()
+}
+
+// Méthodes d'authentification d'un Principal
+const (
+	MethodAPIKey = "api_key"
+	MethodJWT    = "jwt"
+)
+
+// Principal est l'appelant authentifié
+type Principal struct {
+	// Subject identifie l'appelant : "api_key:<id>" pour une clé, le claim
+	// sub pour un jeton
+	Subject string
+	Name    string
+	Role    Role
+	Method  string
+}
+
+type contextKey struct{}
+
+// WithPrincipal retourne un contexte portant l'appelant authentifié
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, principal)
+}
+
+// FromContext retourne l'appelant authentifié, nil s'il n'y en a pas
+func FromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(contextKey{}).(*Principal)
+	return principal
+}
+
+// Subject retourne l'identifiant de l'appelant pour les logs, vide s'il n'y
+// en a pas
+func Subject(ctx context.Context) string {
+	if principal := FromContext(ctx); principal != nil {
+		return principal.Subject
+	}
+	return ""
+}
+
+// Erreurs d'authentification, à tester avec errors.Is
+var (
+	ErrMissingCredentials = errors.New("aucune clé d'API ni aucun jeton fourni")
+	ErrInvalidCredentials = errors.New("clé d'API ou jeton invalide")
+)
+
+// KeyStore retrouve les clés d'API actives par leur empreinte
+type KeyStore interface {
+	FindActiveByHash(hash string) (*repository.APIKey, error)
+}
+
+// JWTConfig décrit les jetons acceptés ; sans secret, seuls les clés d'API
+// sont acceptées
+type JWTConfig struct {
+	// Secret est la clé HMAC partagée avec l'émetteur des jetons (HS256)
+	Secret string
+	// Issuer et Audience, s'ils sont renseignés, doivent correspondre aux
+	// claims iss et aud
+	Issuer   string
+	Audience string
+}
+
+// Authenticator vérifie les clés d'API en base et les jetons localement
+type Authenticator struct {
+	keys   KeyStore
+	config JWTConfig
+	parser *jwt.Parser
+}
+
+// NewAuthenticator crée un authentificateur
+func NewAuthenticator(keys KeyStore, config JWTConfig) *Authenticator {
+	options := []jwt.ParserOption{
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+	}
+	if config.Issuer != "" {
+		options = append(options, jwt.WithIssuer(config.Issuer))
+	}
+	if config.Audience != "" {
+		options = append(options, jwt.WithAudience(config.Audience))
+	}
+
+	return &Authenticator{
+		keys:   keys,
+		config: config,
+		parser: jwt.NewParser(options...),
+	}
+}
+
+// Authenticate identifie l'appelant à partir de l'en-tête Authorization
+// (Bearer suivi d'une clé ou d'un jeton) ou de l'en-tête X-API-Key ; une
+// erreur qui n'est ni ErrMissingCredentials ni ErrInvalidCredentials provient
+// du stockage des clés
+func (a *Authenticator) Authenticate(authorization, apiKey string) (*Principal, error) {
+	credential := strings.TrimSpace(apiKey)
+	if credential == "" {
+		scheme, value, found := strings.Cut(strings.TrimSpace(authorization), " ")
+		if found && strings.EqualFold(scheme, "Bearer") {
+			credential = strings.TrimSpace(value)
+		} else if authorization != "" {
+			return nil, ErrInvalidCredentials
+		}
+	}
+	if credential == "" {
+		return nil, ErrMissingCredentials
+	}
+
+	// Un jeton JWT compte trois segments séparés par des points, ce qui
+	// n'arrive jamais dans une clé générée par GenerateKey
+	if strings.Count(credential, ".") == 2 {
+		return a.verifyToken(credential)
+	}
+	return a.verifyKey(credential)
+}
+
+func (a *Authenticator) verifyKey(key string) (*Principal, error) {
+	stored, err := a.keys.FindActiveByHash(HashKey(key))
+	if errors.Is(err, repository.ErrNotFound) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	role, ok := ParseRole(stored.Role)
+	if !ok {
+		return nil, fmt.Errorf("%w: rôle inconnu '%s' pour la clé %d", ErrInvalidCredentials, stored.Role, stored.ID)
+	}
+
+	return &Principal{
+		Subject: "api_key:" + strconv.Itoa(stored.ID),
+		Name:    stored.Name,
+		Role:    role,
+		Method:  MethodAPIKey,
+	}, nil
+}
+
+// claims sont les claims attendus dans un jeton : sub, exp et le rôle
+type claims struct {
+	Role string `json:"role"`
+	jwt.RegisteredClaims
+}
+
+func (a *Authenticator) verifyToken(token string) (*Principal, error) {
+	if a.config.Secret == "" {
+		return nil, ErrInvalidCredentials
+	}
+
+	var parsed claims
+	_, err := a.parser.ParseWithClaims(token, &parsed, func(*jwt.Token) (interface{}, error) {
+		return []byte(a.config.Secret), nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCredentials, err)
+	}
+
+	role, ok := ParseRole(parsed.Role)
+	if !ok || parsed.Subject == "" {
+		return nil, fmt.Errorf("%w: claims sub ou role absents ou invalides", ErrInvalidCredentials)
+	}
+
+	return &Principal{
+		Subject: parsed.Subject,
+		Name:    parsed.Subject,
+		Role:    role,
+		Method:  MethodJWT,
+	}, nil
+}
+
+// keyPrefix distingue les clés de l'API des autres secrets, dans les logs
+// comme dans les outils de détection de fuites
+const keyPrefix = "jpk_"
+
+// GenerateKey génère une nouvelle clé d'API et son préfixe affichable, qui
+// permet de la reconnaître une fois la clé elle-même oubliée
+func GenerateKey() (key, prefix string, err error) {
+	buf := make([]byte, 24)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("erreur lors de la génération de la clé: %w", err)
+	}
+	key = keyPrefix + hex.EncodeToString(buf)
+	return key, key[:len(keyPrefix)+8], nil
+}
+
+// HashKey retourne l'empreinte stockée en base ; les clés étant aléatoires
+// et longues, un SHA-256 suffit et permet la recherche par index
+func HashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
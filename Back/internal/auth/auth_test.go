@@ -0,0 +1,186 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/japhy-tech/backend-test/internal/repository"
+)
+
+const testSecret = "secret-de-test"
+
+type mockKeys struct {
+	keys map[string]repository.APIKey
+	err  error
+}
+
+func (m *mockKeys) FindActiveByHash(hash string) (*repository.APIKey, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	key, ok := m.keys[hash]
+	if !ok {
+		return nil, &repository.Error{Kind: repository.ErrNotFound, Entity: "api_key"}
+	}
+	return &key, nil
+}
+
+func newTestAuthenticator() *Authenticator {
+	return NewAuthenticator(&mockKeys{keys: map[string]repository.APIKey{
+		HashKey("jpk_editor"): {ID: 3, Name: "back-office", Role: "editor"},
+	}}, JWTConfig{Secret: testSecret, Issuer: "japhy"})
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, key interface{}, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(method, claims).SignedString(key)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return token
+}
+
+func TestRoleAllows(t *testing.T) {
+	if !RoleAdmin.Allows(RoleEditor) || !RoleEditor.Allows(RoleReader) || !RoleReader.Allows(RoleReader) {
+		t.Error("un rôle doit inclure les droits des rôles inférieurs")
+	}
+	if RoleReader.Allows(RoleEditor) || RoleEditor.Allows(RoleAdmin) || Role("root").Allows(RoleReader) {
+		t.Error("un rôle ne doit pas donner les droits des rôles supérieurs")
+	}
+}
+
+func TestAuthenticate_APIKey(t *testing.T) {
+	authenticator := newTestAuthenticator()
+
+	for _, tc := range []struct{ authorization, apiKey string }{
+		{apiKey: "jpk_editor"},
+		{authorization: "Bearer jpk_editor"},
+		{authorization: "bearer  jpk_editor "},
+	} {
+		principal, err := authenticator.Authenticate(tc.authorization, tc.apiKey)
+		if err != nil {
+			t.Fatalf("%+v: %v", tc, err)
+		}
+		if principal.Subject != "api_key:3" || principal.Role != RoleEditor || principal.Method != MethodAPIKey {
+			t.Errorf("appelant inattendu: %+v", principal)
+		}
+	}
+
+	if _, err := authenticator.Authenticate("", ""); !errors.Is(err, ErrMissingCredentials) {
+		t.Errorf("attendu ErrMissingCredentials, obtenu %v", err)
+	}
+	if _, err := authenticator.Authenticate("", "jpk_unknown"); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("attendu ErrInvalidCredentials, obtenu %v", err)
+	}
+	if _, err := authenticator.Authenticate("Basic dXNlcjpwYXNz", ""); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("attendu ErrInvalidCredentials pour un autre schéma, obtenu %v", err)
+	}
+}
+
+func TestAuthenticate_StoreError(t *testing.T) {
+	authenticator := NewAuthenticator(&mockKeys{err: errors.New("connexion perdue")}, JWTConfig{})
+
+	_, err := authenticator.Authenticate("", "jpk_editor")
+	if err == nil || errors.Is(err, ErrInvalidCredentials) || errors.Is(err, ErrMissingCredentials) {
+		t.Errorf("l'erreur du stockage doit être remontée telle quelle, obtenu %v", err)
+	}
+}
+
+func TestAuthenticate_JWT(t *testing.T) {
+	authenticator := newTestAuthenticator()
+	expires := time.Now().Add(time.Hour).Unix()
+
+	token := sign(t, jwt.SigningMethodHS256, []byte(testSecret), jwt.MapClaims{"sub": "alice", "role": "admin", "iss": "japhy", "exp": expires})
+	principal, err := authenticator.Authenticate("Bearer "+token, "")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if principal.Subject != "alice" || principal.Role != RoleAdmin || principal.Method != MethodJWT {
+		t.Errorf("appelant inattendu: %+v", principal)
+	}
+
+	invalid := map[string]string{
+		"expiré":            sign(t, jwt.SigningMethodHS256, []byte(testSecret), jwt.MapClaims{"sub": "alice", "role": "admin", "iss": "japhy", "exp": time.Now().Add(-time.Minute).Unix()}),
+		"sans expiration":   sign(t, jwt.SigningMethodHS256, []byte(testSecret), jwt.MapClaims{"sub": "alice", "role": "admin", "iss": "japhy"}),
+		"mauvais secret":    sign(t, jwt.SigningMethodHS256, []byte("autre"), jwt.MapClaims{"sub": "alice", "role": "admin", "iss": "japhy", "exp": expires}),
+		"mauvais émetteur":  sign(t, jwt.SigningMethodHS256, []byte(testSecret), jwt.MapClaims{"sub": "alice", "role": "admin", "iss": "autre", "exp": expires}),
+		"rôle inconnu":      sign(t, jwt.SigningMethodHS256, []byte(testSecret), jwt.MapClaims{"sub": "alice", "role": "root", "iss": "japhy", "exp": expires}),
+		"algorithme refusé": sign(t, jwt.SigningMethodHS512, []byte(testSecret), jwt.MapClaims{"sub": "alice", "role": "admin", "iss": "japhy", "exp": expires}),
+		"non signé":         sign(t, jwt.SigningMethodNone, jwt.UnsafeAllowNoneSignatureType, jwt.MapClaims{"sub": "alice", "role": "admin", "iss": "japhy", "exp": expires}),
+	}
+	for name, token := range invalid {
+		if _, err := authenticator.Authenticate("Bearer "+token, ""); !errors.Is(err, ErrInvalidCredentials) {
+			t.Errorf("%s: attendu ErrInvalidCredentials, obtenu %v", name, err)
+		}
+	}
+
+	// Sans secret configuré, aucun jeton n'est accepté
+	withoutSecret := NewAuthenticator(&mockKeys{}, JWTConfig{})
+	if _, err := withoutSecret.Authenticate("Bearer "+token, ""); !errors.Is(err, ErrInvalidCredentials) {
+		t.Errorf("attendu ErrInvalidCredentials sans secret, obtenu %v", err)
+	}
+}
+
+func TestGenerateKey(t *testing.T) {
+	key, prefix, err := GenerateKey()
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	other, _, _ := GenerateKey()
+	if key == other || len(key) != 52 || prefix != key[:12] {
+		t.Errorf("clé inattendue %q (préfixe %q)", key, prefix)
+	}
+	if HashKey(key) == HashKey(other) || len(HashKey(key)) != 64 {
+		t.Error("les empreintes doivent être distinctes et en hexadécimal")
+	}
+}
+
+func TestRequire(t *testing.T) {
+	middleware := NewMiddleware(newTestAuthenticator(), log.NewWithOptions(nil, log.Options{}))
+
+	var seen *Principal
+	handler := middleware.Require(RoleEditor, func(w http.ResponseWriter, r *http.Request) {
+		seen = FromContext(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	tests := []struct {
+		name   string
+		header map[string]string
+		status int
+	}{
+		{name: "sans identifiants", status: http.StatusUnauthorized},
+		{name: "clé inconnue", header: map[string]string{APIKeyHeader: "jpk_unknown"}, status: http.StatusUnauthorized},
+		{name: "rôle insuffisant", header: map[string]string{"Authorization": "Bearer " + sign(t, jwt.SigningMethodHS256, []byte(testSecret), jwt.MapClaims{"sub": "bob", "role": "reader", "iss": "japhy", "exp": time.Now().Add(time.Hour).Unix()})}, status: http.StatusForbidden},
+		{name: "rôle suffisant", header: map[string]string{APIKeyHeader: "jpk_editor"}, status: http.StatusNoContent},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			seen = nil
+			req := httptest.NewRequest(http.MethodPost, "/v1/breeds", nil)
+			for name, value := range tc.header {
+				req.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tc.status {
+				t.Fatalf("attendu %d, obtenu %d: %s", tc.status, w.Code, w.Body.String())
+			}
+			if tc.status == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+				t.Error("en-tête WWW-Authenticate attendu")
+			}
+			if (tc.status == http.StatusNoContent) != (seen != nil) {
+				t.Errorf("l'appelant ne doit être transmis qu'en cas de succès, obtenu %+v", seen)
+			}
+		})
+	}
+}
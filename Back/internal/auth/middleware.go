@@ -0,0 +1,96 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/i18n"
+	"github.com/japhy-tech/backend-test/internal/logging"
+	"github.com/japhy-tech/backend-test/internal/problem"
+)
+
+// APIKeyHeader est l'en-tête portant une clé d'API, à défaut de
+// l'en-tête Authorization
+const APIKeyHeader = "X-API-Key"
+
+// Middleware contrôle l'accès aux routes de l'API HTTP
+type Middleware struct {
+	authenticator *Authenticator
+	logger        *charmLog.Logger
+}
+
+// NewMiddleware crée le contrôle d'accès des routes HTTP
+func NewMiddleware(authenticator *Authenticator, logger *charmLog.Logger) *Middleware {
+	return &Middleware{
+		authenticator: authenticator,
+		logger:        logger,
+	}
+}
+
+// Require authentifie l'appelant, vérifie qu'il a au moins le rôle requis et
+// le place dans le contexte de la requête ; les écritures sont journalisées
+// avec l'appelant pour l'audit
+func (m *Middleware) Require(role Role, next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := logging.FromContext(r.Context(), m.logger)
+
+		principal, err := m.authenticator.Authenticate(r.Header.Get("Authorization"), r.Header.Get(APIKeyHeader))
+		if err != nil {
+			if !errors.Is(err, ErrMissingCredentials) && !errors.Is(err, ErrInvalidCredentials) {
+				logger.Error("Erreur lors de la vérification de la clé d'API", "error", err)
+				problem.Write(w, r, problem.Internal())
+				return
+			}
+			logger.Warn("Authentification refusée", "error", err, "path", r.URL.Path)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="breeds"`)
+			problem.Write(w, r, Unauthenticated(err))
+			return
+		}
+
+		// La suite de la requête, jusqu'à sa ligne de journal d'accès, est
+		// journalisée avec l'appelant
+		ctx := logging.WithPrincipal(WithPrincipal(r.Context(), principal), principal.Subject)
+		logger = logging.FromContext(ctx, m.logger)
+
+		if !principal.Role.Allows(role) {
+			logger.Warn("Accès refusé", "role", principal.Role, "required", role, "path", r.URL.Path)
+			problem.Write(w, r, Forbidden(principal, role))
+			return
+		}
+
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			logger.Info("Accès en écriture", "name", principal.Name, "role", principal.Role, "path", r.URL.Path)
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// Authorize vérifie que l'appelant du contexte a au moins le rôle requis,
+// pour les contrôles plus fins que la route (mutations GraphQL) ; retourne
+// nil si l'accès est autorisé
+func Authorize(ctx context.Context, role Role) *problem.Details {
+	principal := FromContext(ctx)
+	if principal == nil {
+		return Unauthenticated(ErrMissingCredentials)
+	}
+	if !principal.Role.Allows(role) {
+		return Forbidden(principal, role)
+	}
+	return nil
+}
+
+// Unauthenticated crée la 401 correspondant à une erreur d'authentification
+func Unauthenticated(err error) *problem.Details {
+	if errors.Is(err, ErrMissingCredentials) {
+		return problem.Localized(http.StatusUnauthorized, problem.CodeUnauthenticated, i18n.New(i18n.CredentialsMissing))
+	}
+	return problem.Localized(http.StatusUnauthorized, problem.CodeUnauthenticated, i18n.New(i18n.CredentialsInvalid))
+}
+
+// Forbidden crée la 403 d'un appelant dont le rôle est insuffisant
+func Forbidden(principal *Principal, required Role) *problem.Details {
+	return problem.Localized(http.StatusForbidden, problem.CodeForbidden, i18n.New(i18n.RoleRequired, string(required), string(principal.Role)))
+}
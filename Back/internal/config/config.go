@@ -0,0 +1,283 @@
+package config
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultFrontPolicy autorise les scripts et styles du build du front, et
+// l'appel du jeu à random.org
+const defaultFrontPolicy = "default-src 'self'; img-src 'self' data:; style-src 'self' 'unsafe-inline'; " +
+	"connect-src 'self' https://www.random.org; frame-ancestors 'none'"
+
+// Config regroupe les réglages de l'API lus depuis l'environnement
+type Config struct {
+	// Environment vaut "development" dans le docker-compose de développement,
+	// qui active les outils de mise au point (GraphiQL) ; "production" par
+	// défaut
+	Environment string
+
+	// LogFormat vaut "text" (terminal) ou "json" (collecte des logs en
+	// production) ; LogLevel est le niveau minimal journalisé (debug, info,
+	// warn, error)
+	LogFormat string
+	LogLevel  string
+
+	// EnforcePetSizeConsistency rejette les créations, mises à jour et imports
+	// dont la taille déclarée contredit les poids
+	EnforcePetSizeConsistency bool
+
+	// MaxPageLimit est la valeur maximale acceptée pour le paramètre limit
+	MaxPageLimit int
+
+	// LegacyDeprecatedSince et LegacySunset sont annoncés dans les en-têtes
+	// Deprecation et Sunset des anciens chemins sans version
+	LegacyDeprecatedSince time.Time
+	LegacySunset          time.Time
+
+	// GraphQLMaxDepth et GraphQLMaxComplexity bornent les requêtes GraphQL
+	// avant leur exécution
+	GraphQLMaxDepth      int
+	GraphQLMaxComplexity int
+
+	// GRPCPort est le port du serveur gRPC, séparé de celui de l'API HTTP
+	GRPCPort string
+
+	// AuthJWTSecret, AuthJWTIssuer et AuthJWTAudience décrivent les jetons
+	// JWT acceptés (HS256) ; sans secret, seules les clés d'API le sont
+	AuthJWTSecret   string
+	AuthJWTIssuer   string
+	AuthJWTAudience string
+
+	// AuthBootstrapKey est une clé admin enregistrée au démarrage, pour
+	// pouvoir créer les premières clés d'API
+	AuthBootstrapKey string
+
+	// RateLimitStore vaut "memory" (budget propre à chaque instance) ou
+	// "database" (budget partagé entre les instances)
+	RateLimitStore string
+
+	// Budgets de requêtes par client : lectures et écritures par minute,
+	// imports CSV par heure, et toutes les requêtes d'une adresse IP par
+	// minute ; 0 désactive la limite
+	RateLimitReadPerMinute  int
+	RateLimitWritePerMinute int
+	RateLimitImportPerHour  int
+	RateLimitIPPerMinute    int
+
+	// CORSAllowedOrigins liste les origines dont les navigateurs peuvent
+	// appeler l'API, par défaut le serveur Vite du front
+	CORSAllowedOrigins   []string
+	CORSAllowedMethods   []string
+	CORSAllowedHeaders   []string
+	CORSAllowCredentials bool
+	// CORSMaxAge est la durée de mise en cache des requêtes préalables
+	CORSMaxAge time.Duration
+
+	// ContentSecurityPolicy et FrameOptions sont renvoyés avec chaque réponse
+	ContentSecurityPolicy string
+	FrameOptions          string
+
+	// FrontDir est le dossier d'un build du front (ex: ../Front/dist) servi
+	// par l'API ; à défaut, FrontEmbedded sert celui embarqué dans le binaire
+	FrontDir      string
+	FrontEmbedded bool
+	// FrontContentSecurityPolicy remplace ContentSecurityPolicy pour les
+	// fichiers du front
+	FrontContentSecurityPolicy string
+
+	// Délais du serveur HTTP : lecture de la requête (en-têtes puis corps),
+	// écriture de la réponse et connexions inactives, pour qu'un client lent
+	// ne garde pas une connexion indéfiniment
+	HTTPReadHeaderTimeout time.Duration
+	HTTPReadTimeout       time.Duration
+	HTTPWriteTimeout      time.Duration
+	HTTPIdleTimeout       time.Duration
+	HTTPMaxHeaderBytes    int
+
+	// ShutdownTimeout borne l'attente des requêtes en cours à l'arrêt
+	ShutdownTimeout time.Duration
+	// ShutdownDrainDelay laisse le temps, entre l'échec de /readyz et la
+	// fermeture des ports, de retirer l'instance du trafic
+	ShutdownDrainDelay time.Duration
+
+	// ReadinessTimeout borne chaque vérification de /readyz
+	ReadinessTimeout time.Duration
+
+	// Pool de connexions MySQL : connexions ouvertes et inactives au plus,
+	// durée de vie d'une connexion et durée d'inactivité avant fermeture
+	DBMaxOpenConns    int
+	DBMaxIdleConns    int
+	DBConnMaxLifetime time.Duration
+	DBConnMaxIdleTime time.Duration
+
+	// DBStartupTimeout borne l'attente de MySQL au démarrage, relancé de
+	// DBRetryInitialDelay à DBRetryMaxDelay ; DBReadAttempts est le nombre
+	// de tentatives d'une lecture interrompue par une erreur passagère
+	DBStartupTimeout    time.Duration
+	DBRetryInitialDelay time.Duration
+	DBRetryMaxDelay     time.Duration
+	DBReadAttempts      int
+
+	// DBReplicaDSNs liste les réplicas de lecture ; une réplique est écartée
+	// des lectures si son retard dépasse DBReplicaMaxLag, vérifié toutes les
+	// DBReplicaCheckInterval ; chaque vérification est bornée par
+	// DBReplicaCheckTimeout
+	DBReplicaDSNs          []string
+	DBReplicaMaxLag        time.Duration
+	DBReplicaCheckInterval time.Duration
+	DBReplicaCheckTimeout  time.Duration
+
+	// TracingExporter vaut "none", "otlp" (collecteur décrit par les variables
+	// OTEL_EXPORTER_OTLP_*) ou "stdout" (JSON sur la sortie standard, ou dans
+	// TracingFile) ; TracingSampleRatio est la part des traces conservées
+	TracingExporter    string
+	TracingFile        string
+	TracingSampleRatio float64
+	// TracingServiceName nomme l'API dans les traces
+	TracingServiceName string
+}
+
+// Development indique si l'API tourne dans l'environnement de développement
+func (c Config) Development() bool {
+	return c.Environment == "development"
+}
+
+// Load lit la configuration depuis les variables d'environnement ; les
+// outils de développement et les logs debug ne sont activés que par
+// APP_ENV=development
+func Load() Config {
+	environment := getEnv("APP_ENV", "production")
+	logLevel := "info"
+	if environment == "development" {
+		logLevel = "debug"
+	}
+
+	return Config{
+		Environment:                environment,
+		LogFormat:                  getEnv("LOG_FORMAT", "text"),
+		LogLevel:                   getEnv("LOG_LEVEL", logLevel),
+		EnforcePetSizeConsistency:  getEnvBool("ENFORCE_PET_SIZE_CONSISTENCY", false),
+		MaxPageLimit:               getEnvInt("MAX_PAGE_LIMIT", 100),
+		LegacyDeprecatedSince:      getEnvDate("LEGACY_DEPRECATED_SINCE", "2026-10-19"),
+		LegacySunset:               getEnvDate("LEGACY_SUNSET", "2027-04-30"),
+		GraphQLMaxDepth:            getEnvInt("GRAPHQL_MAX_DEPTH", 5),
+		GraphQLMaxComplexity:       getEnvInt("GRAPHQL_MAX_COMPLEXITY", 1000),
+		GRPCPort:                   getEnv("GRPC_PORT", "5001"),
+		AuthJWTSecret:              getEnv("AUTH_JWT_SECRET", ""),
+		AuthJWTIssuer:              getEnv("AUTH_JWT_ISSUER", ""),
+		AuthJWTAudience:            getEnv("AUTH_JWT_AUDIENCE", ""),
+		AuthBootstrapKey:           getEnv("AUTH_BOOTSTRAP_KEY", ""),
+		RateLimitStore:             getEnv("RATE_LIMIT_STORE", "memory"),
+		RateLimitReadPerMinute:     getEnvCount("RATE_LIMIT_READ_PER_MINUTE", 300),
+		RateLimitWritePerMinute:    getEnvCount("RATE_LIMIT_WRITE_PER_MINUTE", 60),
+		RateLimitImportPerHour:     getEnvCount("RATE_LIMIT_IMPORT_PER_HOUR", 5),
+		RateLimitIPPerMinute:       getEnvCount("RATE_LIMIT_IP_PER_MINUTE", 600),
+		CORSAllowedOrigins:         getEnvList("CORS_ALLOWED_ORIGINS", "http://localhost:5173"),
+		CORSAllowedMethods:         getEnvList("CORS_ALLOWED_METHODS", "GET,HEAD,POST,PUT,DELETE"),
+		CORSAllowedHeaders:         getEnvList("CORS_ALLOWED_HEADERS", "Accept-Language,Authorization,Content-Type,Weight-Unit,X-API-Key,X-Request-ID,traceparent,tracestate"),
+		CORSAllowCredentials:       getEnvBool("CORS_ALLOW_CREDENTIALS", false),
+		CORSMaxAge:                 time.Duration(getEnvCount("CORS_MAX_AGE", 600)) * time.Second,
+		ContentSecurityPolicy:      getEnv("CONTENT_SECURITY_POLICY", "default-src 'none'; frame-ancestors 'none'"),
+		FrameOptions:               getEnv("FRAME_OPTIONS", "DENY"),
+		FrontDir:                   getEnv("FRONT_DIR", ""),
+		FrontEmbedded:              getEnvBool("FRONT_EMBEDDED", false),
+		FrontContentSecurityPolicy: getEnv("FRONT_CONTENT_SECURITY_POLICY", defaultFrontPolicy),
+		HTTPReadHeaderTimeout:      getEnvDuration("HTTP_READ_HEADER_TIMEOUT", 5*time.Second),
+		HTTPReadTimeout:            getEnvDuration("HTTP_READ_TIMEOUT", 30*time.Second),
+		HTTPWriteTimeout:           getEnvDuration("HTTP_WRITE_TIMEOUT", 2*time.Minute),
+		HTTPIdleTimeout:            getEnvDuration("HTTP_IDLE_TIMEOUT", 2*time.Minute),
+		HTTPMaxHeaderBytes:         getEnvInt("HTTP_MAX_HEADER_BYTES", 64<<10),
+		ShutdownTimeout:            getEnvDuration("SHUTDOWN_TIMEOUT", 25*time.Second),
+		ShutdownDrainDelay:         getEnvDuration("SHUTDOWN_DRAIN_DELAY", 3*time.Second),
+		ReadinessTimeout:           getEnvDuration("READINESS_TIMEOUT", 2*time.Second),
+		DBMaxOpenConns:             getEnvInt("DB_MAX_OPEN_CONNS", 25),
+		DBMaxIdleConns:             getEnvCount("DB_MAX_IDLE_CONNS", 10),
+		DBConnMaxLifetime:          getEnvDuration("DB_CONN_MAX_LIFETIME", 5*time.Minute),
+		DBConnMaxIdleTime:          getEnvDuration("DB_CONN_MAX_IDLE_TIME", time.Minute),
+		DBStartupTimeout:           getEnvDuration("DB_STARTUP_TIMEOUT", time.Minute),
+		DBRetryInitialDelay:        getEnvDuration("DB_RETRY_INITIAL_DELAY", 500*time.Millisecond),
+		DBRetryMaxDelay:            getEnvDuration("DB_RETRY_MAX_DELAY", 10*time.Second),
+		DBReadAttempts:             getEnvInt("DB_READ_ATTEMPTS", 3),
+		DBReplicaDSNs:              getEnvList("DB_REPLICA_DSNS", ""),
+		DBReplicaMaxLag:            getEnvDuration("DB_REPLICA_MAX_LAG", 5*time.Second),
+		DBReplicaCheckInterval:     getEnvDuration("DB_REPLICA_CHECK_INTERVAL", 5*time.Second),
+		DBReplicaCheckTimeout:      getEnvDuration("DB_REPLICA_CHECK_TIMEOUT", time.Second),
+		TracingExporter:            getEnv("TRACING_EXPORTER", "none"),
+		TracingFile:                getEnv("TRACING_FILE", ""),
+		TracingSampleRatio:         getEnvRatio("TRACING_SAMPLE_RATIO", 1),
+		TracingServiceName:         getEnv("OTEL_SERVICE_NAME", "backend-test"),
+	}
+}
+
+func getEnv(key, fallback string) string {
+	if value, ok := os.LookupEnv(key); ok && strings.TrimSpace(value) != "" {
+		return strings.TrimSpace(value)
+	}
+	return fallback
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	value, err := strconv.ParseBool(getEnv(key, strconv.FormatBool(fallback)))
+	if err != nil {
+		return fallback
+	}
+	return value
+}
+
+func getEnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(getEnv(key, strconv.Itoa(fallback)))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// getEnvCount lit un entier positif ou nul, 0 ayant un sens (désactivation)
+func getEnvCount(key string, fallback int) int {
+	value, err := strconv.Atoi(getEnv(key, strconv.Itoa(fallback)))
+	if err != nil || value < 0 {
+		return fallback
+	}
+	return value
+}
+
+// getEnvList lit une liste séparée par des virgules, sans éléments vides
+func getEnvList(key, fallback string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, fallback), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getEnvDuration lit une durée strictement positive (ex: 30s, 2m)
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	value, err := time.ParseDuration(getEnv(key, fallback.String()))
+	if err != nil || value <= 0 {
+		return fallback
+	}
+	return value
+}
+
+// getEnvRatio lit une proportion entre 0 et 1
+func getEnvRatio(key string, fallback float64) float64 {
+	value, err := strconv.ParseFloat(getEnv(key, ""), 64)
+	if err != nil || value < 0 || value > 1 {
+		return fallback
+	}
+	return value
+}
+
+// getEnvDate lit une date au format AAAA-MM-JJ (UTC)
+func getEnvDate(key, fallback string) time.Time {
+	value, err := time.Parse(time.DateOnly, getEnv(key, fallback))
+	if err != nil {
+		value, _ = time.Parse(time.DateOnly, fallback)
+	}
+	return value
+}
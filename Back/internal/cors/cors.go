@@ -0,0 +1,134 @@
+// Package cors autorise les navigateurs d'autres origines, comme le front Vue
+// servi par Vite, à appeler l'API
+package cors
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Policy décrit les appels cross-origin acceptés
+type Policy struct {
+	// AllowedOrigins liste les origines acceptées (ex: http://localhost:5173) ;
+	// "*" les accepte toutes
+	AllowedOrigins []string
+	// AllowedMethods et AllowedHeaders bornent ce qu'une requête préalable
+	// (preflight) peut demander
+	AllowedMethods []string
+	AllowedHeaders []string
+	// ExposedHeaders liste les en-têtes de réponse lisibles par le navigateur
+	ExposedHeaders []string
+	// AllowCredentials autorise l'envoi des cookies et de l'en-tête
+	// Authorization géré par le navigateur
+	AllowCredentials bool
+	// MaxAge est la durée pendant laquelle le navigateur garde la réponse à
+	// une requête préalable
+	MaxAge time.Duration
+}
+
+// allowsOrigin indique si l'origine est acceptée
+func (p Policy) allowsOrigin(origin string) bool {
+	for _, allowed := range p.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsMethod indique si la méthode est acceptée
+func (p Policy) allowsMethod(method string) bool {
+	for _, allowed := range p.AllowedMethods {
+		if strings.EqualFold(allowed, method) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsHeaders indique si tous les en-têtes demandés, séparés par des
+// virgules, sont acceptés
+func (p Policy) allowsHeaders(requested string) bool {
+	for _, name := range strings.Split(requested, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		found := false
+		for _, allowed := range p.AllowedHeaders {
+			if strings.EqualFold(allowed, name) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Middleware ajoute les en-têtes CORS aux réponses des origines acceptées et
+// répond lui-même aux requêtes préalables (OPTIONS), sans les transmettre au
+// routeur. Une origine, une méthode ou un en-tête refusé n'obtient aucun
+// en-tête Access-Control-*, ce que le navigateur traite comme un refus.
+func Middleware(policy Policy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			origin := r.Header.Get("Origin")
+			if origin == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			header := w.Header()
+			header.Add("Vary", "Origin")
+			preflight := r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != ""
+
+			if preflight {
+				header.Add("Vary", "Access-Control-Request-Method")
+				header.Add("Vary", "Access-Control-Request-Headers")
+				requestedHeaders := r.Header.Get("Access-Control-Request-Headers")
+				if policy.allowsOrigin(origin) && policy.allowsMethod(r.Header.Get("Access-Control-Request-Method")) && policy.allowsHeaders(requestedHeaders) {
+					allowOrigin(header, policy, origin)
+					header.Set("Access-Control-Allow-Methods", strings.Join(policy.AllowedMethods, ", "))
+					if requestedHeaders != "" {
+						header.Set("Access-Control-Allow-Headers", strings.Join(policy.AllowedHeaders, ", "))
+					}
+					if policy.MaxAge > 0 {
+						header.Set("Access-Control-Max-Age", strconv.Itoa(int(policy.MaxAge.Seconds())))
+					}
+				}
+				w.WriteHeader(http.StatusNoContent)
+				return
+			}
+
+			if policy.allowsOrigin(origin) {
+				allowOrigin(header, policy, origin)
+				if len(policy.ExposedHeaders) > 0 {
+					header.Set("Access-Control-Expose-Headers", strings.Join(policy.ExposedHeaders, ", "))
+				}
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// allowOrigin renvoie l'origine acceptée ; "*" n'est pas permis avec les
+// identifiants, l'origine est alors reprise telle quelle
+func allowOrigin(header http.Header, policy Policy, origin string) {
+	if policy.AllowCredentials {
+		header.Set("Access-Control-Allow-Origin", origin)
+		header.Set("Access-Control-Allow-Credentials", "true")
+		return
+	}
+	for _, allowed := range policy.AllowedOrigins {
+		if allowed == "*" {
+			header.Set("Access-Control-Allow-Origin", "*")
+			return
+		}
+	}
+	header.Set("Access-Control-Allow-Origin", origin)
+}
@@ -0,0 +1,114 @@
+package cors
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+var policy = Policy{
+	AllowedOrigins: []string{"http://localhost:5173"},
+	AllowedMethods: []string{"GET", "POST", "PUT", "DELETE"},
+	AllowedHeaders: []string{"Authorization", "Content-Type", "X-API-Key"},
+	ExposedHeaders: []string{"X-Request-ID"},
+	MaxAge:         10 * time.Minute,
+}
+
+func serve(policy Policy, req *http.Request) (*httptest.ResponseRecorder, bool) {
+	reached := false
+	handler := Middleware(policy)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		reached = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w, reached
+}
+
+func preflight(origin, method, headers string) *http.Request {
+	req := httptest.NewRequest(http.MethodOptions, "/v1/breeds", nil)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", method)
+	if headers != "" {
+		req.Header.Set("Access-Control-Request-Headers", headers)
+	}
+	return req
+}
+
+func TestPreflight(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *http.Request
+		allowed bool
+	}{
+		{name: "origine acceptée", req: preflight("http://localhost:5173", "PUT", "content-type, x-api-key"), allowed: true},
+		{name: "origine inconnue", req: preflight("https://evil.example", "PUT", "content-type")},
+		{name: "méthode refusée", req: preflight("http://localhost:5173", "PATCH", "")},
+		{name: "en-tête refusé", req: preflight("http://localhost:5173", "GET", "x-custom")},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w, reached := serve(policy, tc.req)
+
+			if reached || w.Code != http.StatusNoContent {
+				t.Fatalf("la requête préalable doit être traitée par le middleware, obtenu %d (transmise: %v)", w.Code, reached)
+			}
+			got := w.Header().Get("Access-Control-Allow-Origin")
+			if tc.allowed {
+				if got != "http://localhost:5173" || w.Header().Get("Access-Control-Allow-Methods") != "GET, POST, PUT, DELETE" || w.Header().Get("Access-Control-Max-Age") != "600" {
+					t.Errorf("en-têtes CORS inattendus: %v", w.Header())
+				}
+				if w.Header().Get("Access-Control-Allow-Headers") == "" {
+					t.Error("Access-Control-Allow-Headers attendu")
+				}
+			} else if got != "" {
+				t.Errorf("aucune origine ne doit être autorisée, obtenu %q", got)
+			}
+		})
+	}
+}
+
+func TestSimpleRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/v1/breeds", nil)
+	req.Header.Set("Origin", "http://localhost:5173")
+	w, reached := serve(policy, req)
+	if !reached || w.Header().Get("Access-Control-Allow-Origin") != "http://localhost:5173" || w.Header().Get("Access-Control-Expose-Headers") != "X-Request-ID" {
+		t.Errorf("en-têtes CORS inattendus: %v", w.Header())
+	}
+	if w.Header().Get("Vary") != "Origin" {
+		t.Errorf("Vary: Origin attendu, obtenu %q", w.Header().Get("Vary"))
+	}
+
+	// Sans Origin (curl, autre serveur), rien n'est ajouté
+	w, reached = serve(policy, httptest.NewRequest(http.MethodGet, "/v1/breeds", nil))
+	if !reached || w.Header().Get("Access-Control-Allow-Origin") != "" || w.Header().Get("Vary") != "" {
+		t.Errorf("aucun en-tête CORS attendu sans Origin: %v", w.Header())
+	}
+
+	// Une simple requête OPTIONS, sans Access-Control-Request-Method, va au routeur
+	options := httptest.NewRequest(http.MethodOptions, "/v1/breeds", nil)
+	options.Header.Set("Origin", "http://localhost:5173")
+	if _, reached := serve(policy, options); !reached {
+		t.Error("une requête OPTIONS qui n'est pas préalable doit être transmise")
+	}
+}
+
+func TestWildcardOrigin(t *testing.T) {
+	open := policy
+	open.AllowedOrigins = []string{"*"}
+
+	req := httptest.NewRequest(http.MethodGet, "/v1/breeds", nil)
+	req.Header.Set("Origin", "https://app.example")
+	if w, _ := serve(open, req); w.Header().Get("Access-Control-Allow-Origin") != "*" {
+		t.Errorf("attendu *, obtenu %q", w.Header().Get("Access-Control-Allow-Origin"))
+	}
+
+	// Avec les identifiants, le navigateur refuse * : l'origine est reprise
+	open.AllowCredentials = true
+	w, _ := serve(open, req)
+	if w.Header().Get("Access-Control-Allow-Origin") != "https://app.example" || w.Header().Get("Access-Control-Allow-Credentials") != "true" {
+		t.Errorf("en-têtes CORS inattendus: %v", w.Header())
+	}
+}
@@ -0,0 +1,33 @@
+// Package deprecation signale aux clients les routes appelées à disparaître
+package deprecation
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Policy décrit le retrait programmé d'une famille de routes
+type Policy struct {
+	// Since est la date à partir de laquelle les routes sont dépréciées
+	Since time.Time
+	// Sunset est la date à partir de laquelle elles pourront être retirées
+	Sunset time.Time
+	// Successor est le préfixe qui, ajouté au chemin appelé, donne la route
+	// qui la remplace (ex: /v1)
+	Successor string
+}
+
+// Middleware ajoute à chaque réponse les en-têtes Deprecation (RFC 9745),
+// Sunset (RFC 8594) et un lien vers la route qui remplace celle appelée
+func Middleware(policy Policy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := w.Header()
+			header.Set("Deprecation", fmt.Sprintf("@%d", policy.Since.Unix()))
+			header.Set("Sunset", policy.Sunset.UTC().Format(http.TimeFormat))
+			header.Add("Link", fmt.Sprintf(`<%s%s>; rel="successor-version"`, policy.Successor, r.URL.EscapedPath()))
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+
+	"github.com/japhy-tech/backend-test/internal/logging"
+	"github.com/japhy-tech/backend-test/internal/problem"
+	"github.com/japhy-tech/backend-test/internal/requestid"
+)
+
+// Error est une erreur GraphQL portant le même code stable et le même détail
+// par champ que les réponses problem+json de l'API REST
+type Error struct {
+	details *problem.Details
+}
+
+func (e *Error) Error() string {
+	if e.details.Detail != "" {
+		return e.details.Detail
+	}
+	return e.details.Title
+}
+
+// Extensions est ajouté à l'erreur dans la réponse (errors[].extensions)
+func (e *Error) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{
+		"code":   e.details.Code,
+		"status": e.details.Status,
+	}
+	if len(e.details.Errors) > 0 {
+		extensions["errors"] = e.details.Errors
+	}
+	if e.details.RequestID != "" {
+		extensions["request_id"] = e.details.RequestID
+	}
+	return extensions
+}
+
+// newError traduit le problème dans la langue de la requête
+func newError(ctx context.Context, details *problem.Details) *Error {
+	details.Translate(stateFrom(ctx).lang)
+	details.RequestID = requestid.FromContext(ctx)
+	return &Error{details: details}
+}
+
+// toError traduit une erreur de validation ou de repository comme le ferait
+// l'API REST ; toute autre erreur est journalisée et masquée
+func (r *resolver) toError(ctx context.Context, err error, keyvals ...interface{}) error {
+	var errs problem.FieldErrors
+	if !errors.As(err, &errs) {
+		errs = problem.FromValidationError(err)
+	}
+	if errs != nil {
+		return newError(ctx, problem.Validation(errs))
+	}
+	if details := problem.FromRepositoryError(err, problem.CodeBreedNotFound); details != nil {
+		return newError(ctx, details)
+	}
+
+	keyvals = append(keyvals, "error", err)
+	logging.FromContext(ctx, r.logger).Error("Erreur lors de l'exécution d'une requête GraphQL", keyvals...)
+	return newError(ctx, problem.Internal())
+}
@@ -0,0 +1,320 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/auth"
+	"github.com/japhy-tech/backend-test/internal/repository"
+	"github.com/japhy-tech/backend-test/internal/service"
+)
+
+type mockBreedRepo struct {
+	lastFilter repository.BreedFilter
+	created    *repository.Breed
+}
+
+func (m *mockBreedRepo) GetAll(ctx context.Context, filter repository.BreedFilter) ([]repository.Breed, error) {
+	m.lastFilter = filter
+	return []repository.Breed{
+		{ID: 1, Species: "dog", PetSize: "medium", Name: "border_collie", AverageMaleAdultWeight: 20000, AverageFemaleAdultWeight: 18000},
+		{ID: 2, Species: "cat", PetSize: "small", Name: "maine_coon", AverageMaleAdultWeight: 8000, AverageFemaleAdultWeight: 5000},
+	}, nil
+}
+func (m *mockBreedRepo) GetByID(ctx context.Context, id int) (*repository.Breed, error) {
+	if id == 1 {
+		return &repository.Breed{ID: 1, Species: "dog", PetSize: "medium", Name: "border_collie", AverageMaleAdultWeight: 20000, AverageFemaleAdultWeight: 18000}, nil
+	}
+	return nil, &repository.Error{Kind: repository.ErrNotFound, Entity: "breed"}
+}
+func (m *mockBreedRepo) Resolve(ctx context.Context, name string) (*repository.Breed, error) {
+	return nil, repository.ErrNotFound
+}
+func (m *mockBreedRepo) Create(ctx context.Context, breed *repository.Breed) (*repository.Breed, error) {
+	m.created = breed
+	breed.ID = 12
+	return breed, nil
+}
+func (m *mockBreedRepo) Update(ctx context.Context, id int, breed *repository.Breed) (*repository.Breed, error) {
+	return breed, nil
+}
+func (m *mockBreedRepo) Delete(ctx context.Context, id int) error {
+	return &repository.Error{Kind: repository.ErrNotFound, Entity: "breed"}
+}
+func (m *mockBreedRepo) ImportFromCSV(ctx context.Context, breeds []repository.Breed) (repository.ImportStats, error) {
+	return repository.ImportStats{}, nil
+}
+
+type mockReferences struct{}
+
+func (m *mockReferences) Snapshot() (*service.ReferenceSet, error) {
+	return &service.ReferenceSet{Species: []string{"cat", "dog"}, PetSizes: []string{"medium", "small", "tall"}}, nil
+}
+
+type mockSizes struct{}
+
+func (m *mockSizes) Snapshot() (*service.SizeClassification, error) {
+	small, medium := 10000, 25000
+	return service.NewSizeClassification([]repository.SizeThreshold{
+		{Species: "dog", PetSize: "small", MaxWeight: &small},
+		{Species: "dog", PetSize: "medium", MaxWeight: &medium},
+		{Species: "dog", PetSize: "tall"},
+	}, false), nil
+}
+
+// mockNames traduit la race 1 en français et compte les appels
+type mockNames struct {
+	calls int
+}
+
+func (m *mockNames) DisplayNames(locale string, breeds []repository.Breed) (map[int]string, error) {
+	m.calls++
+	names := map[int]string{}
+	for _, breed := range breeds {
+		names[breed.ID] = service.HumanizeBreedName(breed.Name)
+		if breed.ID == 1 && locale == "fr" {
+			names[breed.ID] = "Colley à poil long"
+		}
+	}
+	return names, nil
+}
+
+type response struct {
+	Data   map[string]interface{} `json:"data"`
+	Errors []struct {
+		Message    string                 `json:"message"`
+		Extensions map[string]interface{} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func newTestHandler(t *testing.T, repo *mockBreedRepo, names *mockNames) *Handler {
+	t.Helper()
+
+	logger := log.NewWithOptions(nil, log.Options{})
+	handler, err := NewHandler(repo, &mockReferences{}, &mockSizes{}, names, 100, Limits{MaxDepth: 3, MaxComplexity: 200}, logger)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	return handler
+}
+
+func execute(t *testing.T, handler *Handler, query string, lang string) (int, response) {
+	t.Helper()
+	return executeAs(t, handler, auth.RoleAdmin, query, lang)
+}
+
+// executeAs exécute la requête pour un appelant du rôle donné, authentifié
+// en amont par la route
+func executeAs(t *testing.T, handler *Handler, role auth.Role, query string, lang string) (int, response) {
+	t.Helper()
+
+	body, _ := json.Marshal(Request{Query: query})
+	req := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body)))
+	req = req.WithContext(auth.WithPrincipal(req.Context(), &auth.Principal{Subject: "test", Role: role}))
+	if lang != "" {
+		req.Header.Set("Accept-Language", lang)
+	}
+	w := httptest.NewRecorder()
+
+	handler.ServeHTTP(w, req)
+
+	var resp response
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("réponse JSON invalide: %v\n%s", err, w.Body.String())
+	}
+	return w.Code, resp
+}
+
+func TestBreedsQuery(t *testing.T) {
+	repo := &mockBreedRepo{}
+	names := &mockNames{}
+	handler := newTestHandler(t, repo, names)
+
+	status, resp := execute(t, handler, `{
+		breeds(filter: {species: "dog", name: "Border", weightMin: 10, unit: KG}, sort: {field: MALE_WEIGHT, direction: DESC}, page: {limit: 10}) {
+			id
+			petSize
+			displayName
+			averageMaleAdultWeight(unit: KG)
+		}
+	}`, "fr")
+
+	if status != http.StatusOK || len(resp.Errors) > 0 {
+		t.Fatalf("attendu 200 sans erreur, obtenu %d: %+v", status, resp.Errors)
+	}
+
+	filter := repo.lastFilter
+	if filter.Species != "dog" || filter.Name != "border" || filter.Weight.Min == nil || *filter.Weight.Min != 10000 {
+		t.Errorf("filtre inattendu: %+v", filter)
+	}
+	if filter.Sort != repository.SortByMaleWeight || !filter.Desc || filter.Limit != 10 {
+		t.Errorf("tri ou pagination inattendus: %+v", filter)
+	}
+
+	breeds := resp.Data["breeds"].([]interface{})
+	first := breeds[0].(map[string]interface{})
+	if first["displayName"] != "Colley à poil long" || first["averageMaleAdultWeight"] != 20.0 || first["petSize"] != "medium" {
+		t.Errorf("race inattendue: %v", first)
+	}
+	second := breeds[1].(map[string]interface{})
+	if second["displayName"] != "Maine Coon" {
+		t.Errorf("nom d'affichage inattendu: %v", second)
+	}
+	if names.calls != 1 {
+		t.Errorf("attendu un seul chargement des noms, obtenu %d", names.calls)
+	}
+}
+
+func TestBreedQuery_NotFoundIsNull(t *testing.T) {
+	handler := newTestHandler(t, &mockBreedRepo{}, &mockNames{})
+
+	status, resp := execute(t, handler, `{ breed(id: 999) { id name } }`, "")
+
+	if status != http.StatusOK || len(resp.Errors) > 0 {
+		t.Fatalf("attendu 200 sans erreur, obtenu %d: %+v", status, resp.Errors)
+	}
+	if value, ok := resp.Data["breed"]; !ok || value != nil {
+		t.Errorf("attendu breed null, obtenu %v", value)
+	}
+}
+
+func TestCreateBreedMutation(t *testing.T) {
+	repo := &mockBreedRepo{}
+	handler := newTestHandler(t, repo, &mockNames{})
+
+	status, resp := execute(t, handler, `mutation {
+		createBreed(input: {species: "Dog", name: "border_collie", averageMaleAdultWeight: 20, averageFemaleAdultWeight: 18, unit: KG}) { id petSize }
+	}`, "")
+
+	if status != http.StatusOK || len(resp.Errors) > 0 {
+		t.Fatalf("attendu 200 sans erreur, obtenu %d: %+v", status, resp.Errors)
+	}
+	if repo.created == nil || repo.created.Species != "dog" || repo.created.PetSize != "medium" || repo.created.AverageMaleAdultWeight != 20000 {
+		t.Errorf("race créée inattendue: %+v", repo.created)
+	}
+}
+
+func TestCreateBreedMutation_ValidationFailed(t *testing.T) {
+	repo := &mockBreedRepo{}
+	handler := newTestHandler(t, repo, &mockNames{})
+
+	status, resp := execute(t, handler, `mutation {
+		createBreed(input: {species: "bird", name: "parrot", averageMaleAdultWeight: 0, averageFemaleAdultWeight: 400}) { id }
+	}`, "en")
+
+	if status != http.StatusOK || len(resp.Errors) != 1 {
+		t.Fatalf("attendu 200 avec une erreur, obtenu %d: %+v", status, resp.Errors)
+	}
+	extensions := resp.Errors[0].Extensions
+	if extensions["code"] != "validation_failed" {
+		t.Errorf("code attendu validation_failed, obtenu %v", extensions["code"])
+	}
+	fields, _ := extensions["errors"].([]interface{})
+	if len(fields) != 1 || !strings.Contains(fields[0].(map[string]interface{})["message"].(string), "must be") {
+		t.Errorf("erreurs par champ inattendues (en anglais attendu): %v", fields)
+	}
+	if repo.created != nil {
+		t.Error("la race ne doit pas être créée")
+	}
+}
+
+func TestDeleteBreedMutation_NotFound(t *testing.T) {
+	handler := newTestHandler(t, &mockBreedRepo{}, &mockNames{})
+
+	_, resp := execute(t, handler, `mutation { deleteBreed(id: 999) }`, "")
+
+	if len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != "breed_not_found" {
+		t.Errorf("attendu breed_not_found, obtenu %+v", resp.Errors)
+	}
+}
+
+func TestMutations_RequireRole(t *testing.T) {
+	repo := &mockBreedRepo{}
+	handler := newTestHandler(t, repo, &mockNames{})
+
+	_, resp := executeAs(t, handler, auth.RoleReader, `mutation {
+		createBreed(input: {species: "dog", name: "beagle", averageMaleAdultWeight: 9000, averageFemaleAdultWeight: 8000}) { id }
+	}`, "")
+	if len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != "forbidden" {
+		t.Errorf("attendu forbidden pour un reader, obtenu %+v", resp.Errors)
+	}
+	if repo.created != nil {
+		t.Error("la race ne doit pas être créée")
+	}
+
+	_, resp = executeAs(t, handler, auth.RoleEditor, `mutation { deleteBreed(id: 1) }`, "")
+	if len(resp.Errors) != 1 || resp.Errors[0].Extensions["code"] != "forbidden" {
+		t.Errorf("attendu forbidden pour un editor, obtenu %+v", resp.Errors)
+	}
+}
+
+func TestQueryLimits(t *testing.T) {
+	handler := newTestHandler(t, &mockBreedRepo{}, &mockNames{})
+
+	tests := []struct {
+		name  string
+		query string
+		code  string
+	}{
+		{
+			name:  "syntaxe invalide",
+			query: `{ breeds { id }`,
+			code:  "invalid_query",
+		},
+		{
+			name:  "champ inconnu",
+			query: `{ breeds { weight } }`,
+			code:  "invalid_query",
+		},
+		{
+			name:  "fragments",
+			query: `query { ...a } fragment a on Query { breeds { ...b } } fragment b on Breed { ... on Breed { id } }`,
+			code:  "",
+		},
+		{
+			name:  "trop complexe",
+			query: `{ breeds(page: {limit: 100}) { id name } }`,
+			code:  "query_too_complex",
+		},
+		{
+			name:  "complexité au défaut de page",
+			query: `{ breeds { id name species petSize } }`,
+			code:  "query_too_complex",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status, resp := execute(t, handler, tt.query, "")
+
+			if tt.code == "" {
+				if status != http.StatusOK {
+					t.Errorf("attendu 200, obtenu %d: %+v", status, resp.Errors)
+				}
+				return
+			}
+			if status != http.StatusBadRequest || len(resp.Errors) == 0 {
+				t.Fatalf("attendu 400, obtenu %d: %+v", status, resp.Errors)
+			}
+			if resp.Errors[0].Extensions["code"] != tt.code {
+				t.Errorf("code attendu %s, obtenu %v", tt.code, resp.Errors[0].Extensions["code"])
+			}
+		})
+	}
+}
+
+func TestMeasure_Depth(t *testing.T) {
+	handler := newTestHandler(t, &mockBreedRepo{}, &mockNames{})
+	handler.limits.MaxDepth = 1
+
+	status, resp := execute(t, handler, `{ breeds(page: {limit: 1}) { id } }`, "")
+
+	if status != http.StatusBadRequest || len(resp.Errors) == 0 || resp.Errors[0].Extensions["code"] != "query_too_deep" {
+		t.Errorf("attendu 400 query_too_deep, obtenu %d: %+v", status, resp.Errors)
+	}
+}
@@ -0,0 +1,152 @@
+package graphql
+
+import (
+	"context"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	charmLog "github.com/charmbracelet/log"
+	gql "github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/japhy-tech/backend-test/internal/handlers"
+	"github.com/japhy-tech/backend-test/internal/i18n"
+	"github.com/japhy-tech/backend-test/internal/problem"
+	"github.com/japhy-tech/backend-test/internal/repository"
+	"github.com/japhy-tech/backend-test/internal/secheaders"
+	"github.com/japhy-tech/backend-test/internal/service"
+)
+
+//go:embed graphiql.html
+var graphiqlPage []byte
+
+// maxBodySize borne la taille d'une requête GraphQL, avant toute analyse
+const maxBodySize = 1 << 20
+
+// Limits borne le coût d'une requête avant son exécution
+type Limits struct {
+	MaxDepth      int
+	MaxComplexity int
+}
+
+// Handler sert l'endpoint GraphQL
+type Handler struct {
+	schema gql.Schema
+	limits Limits
+}
+
+// Request est le corps d'une requête POST /graphql
+type Request struct {
+	Query         string                 `json:"query"`
+	Variables     map[string]interface{} `json:"variables"`
+	OperationName string                 `json:"operationName"`
+}
+
+// NewHandler construit le schéma ; maxLimit borne page.limit comme le
+// paramètre limit de GET /breeds
+func NewHandler(repo repository.BreedRepositoryInterface, references handlers.ReferenceProvider, sizes handlers.SizeClassifierProvider, names handlers.DisplayNameProvider, maxLimit int, limits Limits, logger *charmLog.Logger) (*Handler, error) {
+	res := &resolver{
+		repo:     repo,
+		writer:   service.NewBreedService(repo, references, sizes),
+		names:    names,
+		maxLimit: maxLimit,
+		logger:   logger,
+	}
+
+	schema, err := newSchema(res)
+	if err != nil {
+		return nil, fmt.Errorf("schéma GraphQL invalide: %w", err)
+	}
+
+	return &Handler{schema: schema, limits: limits}, nil
+}
+
+// ServeHTTP exécute une requête GraphQL
+// POST /graphql
+// Une requête illisible, invalide ou trop coûteuse est refusée en 400 sans
+// être exécutée ; sinon la réponse est une 200 dont errors[] porte les
+// erreurs des champs, avec le code stable de l'API REST dans extensions.code
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	lang := i18n.FromRequest(r)
+	ctx := withState(r.Context(), lang)
+
+	var req Request
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxBodySize)).Decode(&req); err != nil {
+		h.reject(w, lang, formatError(newError(ctx, problem.Localized(http.StatusBadRequest, problem.CodeInvalidBody, i18n.FromDecodeError(err)))))
+		return
+	}
+
+	document, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(req.Query), Name: "GraphQL request"})})
+	if err != nil {
+		h.reject(w, lang, invalidQuery(ctx, gqlerrors.FormatError(err))...)
+		return
+	}
+
+	if validation := gql.ValidateDocument(&h.schema, document, nil); !validation.IsValid {
+		h.reject(w, lang, invalidQuery(ctx, validation.Errors...)...)
+		return
+	}
+
+	measured := measure(document, req.OperationName, req.Variables)
+	if measured.depth > h.limits.MaxDepth {
+		h.reject(w, lang, formatError(newError(ctx, problem.Localized(http.StatusBadRequest, problem.CodeQueryTooDeep, i18n.New(i18n.QueryTooDeep, measured.depth, h.limits.MaxDepth)))))
+		return
+	}
+	if measured.complexity > h.limits.MaxComplexity {
+		h.reject(w, lang, formatError(newError(ctx, problem.Localized(http.StatusBadRequest, problem.CodeQueryTooComplex, i18n.New(i18n.QueryTooComplex, measured.complexity, h.limits.MaxComplexity)))))
+		return
+	}
+
+	result := gql.Execute(gql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           document,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	})
+	writeResult(w, lang, http.StatusOK, result)
+}
+
+// GraphiQLHandler sert l'explorateur GraphiQL, réservé au développement
+// GET /graphql
+func GraphiQLHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Security-Policy", secheaders.PagePolicy)
+		w.Write(graphiqlPage)
+	})
+}
+
+// invalidQuery rattache le code invalid_query aux erreurs de syntaxe et de
+// validation, qui gardent leur message et leur position
+func invalidQuery(ctx context.Context, errs ...gqlerrors.FormattedError) []gqlerrors.FormattedError {
+	details := newError(ctx, problem.New(http.StatusBadRequest, problem.CodeInvalidQuery, ""))
+	for i := range errs {
+		errs[i].Extensions = details.Extensions()
+	}
+	return errs
+}
+
+// reject refuse la requête sans l'exécuter
+func (h *Handler) reject(w http.ResponseWriter, lang i18n.Lang, errs ...gqlerrors.FormattedError) {
+	writeResult(w, lang, http.StatusBadRequest, &gql.Result{Errors: errs})
+}
+
+func formatError(err *Error) gqlerrors.FormattedError {
+	return gqlerrors.FormattedError{
+		Message:    err.Error(),
+		Locations:  []location.SourceLocation{},
+		Extensions: err.Extensions(),
+	}
+}
+
+func writeResult(w http.ResponseWriter, lang i18n.Lang, status int, result *gql.Result) {
+	i18n.SetContentLanguage(w, lang)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(result)
+}
@@ -0,0 +1,137 @@
+package graphql
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/japhy-tech/backend-test/internal/handlers"
+)
+
+// cost est la mesure d'une requête avant exécution : profondeur des
+// sélections et complexité, où chaque champ vaut 1 et les champs d'une liste
+// paginée comptent autant de fois que la page peut contenir d'éléments
+type cost struct {
+	depth      int
+	complexity int
+}
+
+// measure évalue l'opération exécutée ; les champs d'introspection (__schema,
+// __typename...) ne comptent pas, pour que GraphiQL reste utilisable
+func measure(doc *ast.Document, operationName string, variables map[string]interface{}) cost {
+	m := measurer{fragments: map[string]*ast.FragmentDefinition{}, variables: variables}
+
+	var operation *ast.OperationDefinition
+	for _, definition := range doc.Definitions {
+		switch def := definition.(type) {
+		case *ast.FragmentDefinition:
+			m.fragments[def.Name.Value] = def
+		case *ast.OperationDefinition:
+			if operation == nil && (operationName == "" || (def.Name != nil && def.Name.Value == operationName)) {
+				operation = def
+			}
+		}
+	}
+	if operation == nil {
+		return cost{}
+	}
+
+	return m.selectionSet(operation.SelectionSet, map[string]bool{})
+}
+
+type measurer struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+}
+
+// selectionSet retourne la profondeur maximale et la complexité cumulée des
+// sélections ; visiting protège des fragments qui s'incluent eux-mêmes
+func (m measurer) selectionSet(set *ast.SelectionSet, visiting map[string]bool) cost {
+	var total cost
+	if set == nil {
+		return total
+	}
+
+	for _, selection := range set.Selections {
+		var c cost
+		switch sel := selection.(type) {
+		case *ast.Field:
+			c = m.field(sel, visiting)
+		case *ast.InlineFragment:
+			c = m.selectionSet(sel.SelectionSet, visiting)
+		case *ast.FragmentSpread:
+			name := sel.Name.Value
+			fragment, ok := m.fragments[name]
+			if !ok || visiting[name] {
+				continue
+			}
+			visiting[name] = true
+			c = m.selectionSet(fragment.SelectionSet, visiting)
+			delete(visiting, name)
+		}
+
+		if c.depth > total.depth {
+			total.depth = c.depth
+		}
+		total.complexity += c.complexity
+	}
+
+	return total
+}
+
+func (m measurer) field(field *ast.Field, visiting map[string]bool) cost {
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return cost{}
+	}
+
+	children := m.selectionSet(field.SelectionSet, visiting)
+	multiplier := 1
+	if field.Name.Value == "breeds" {
+		multiplier = m.pageLimit(field)
+	}
+
+	return cost{
+		depth:      children.depth + 1,
+		complexity: 1 + multiplier*children.complexity,
+	}
+}
+
+// pageLimit lit page.limit, littéral ou variable, et retombe sur la taille de
+// page par défaut
+func (m measurer) pageLimit(field *ast.Field) int {
+	for _, argument := range field.Arguments {
+		if argument.Name.Value != "page" {
+			continue
+		}
+
+		switch page := argument.Value.(type) {
+		case *ast.ObjectValue:
+			for _, objectField := range page.Fields {
+				if objectField.Name.Value == "limit" {
+					if limit, ok := m.intValue(objectField.Value); ok {
+						return limit
+					}
+				}
+			}
+		case *ast.Variable:
+			if value, ok := m.variables[page.Name.Value].(map[string]interface{}); ok {
+				if limit, ok := value["limit"].(float64); ok && limit > 0 {
+					return int(limit)
+				}
+			}
+		}
+	}
+	return handlers.DefaultPageLimit
+}
+
+func (m measurer) intValue(value ast.Value) (int, bool) {
+	switch v := value.(type) {
+	case *ast.IntValue:
+		limit, err := strconv.Atoi(v.Value)
+		return limit, err == nil && limit > 0
+	case *ast.Variable:
+		limit, ok := m.variables[v.Name.Value].(float64)
+		return int(limit), ok && limit > 0
+	}
+	return 0, false
+}
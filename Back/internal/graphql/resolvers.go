@@ -0,0 +1,327 @@
+package graphql
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+
+	charmLog "github.com/charmbracelet/log"
+	gql "github.com/graphql-go/graphql"
+	"github.com/japhy-tech/backend-test/internal/auth"
+	"github.com/japhy-tech/backend-test/internal/handlers"
+	"github.com/japhy-tech/backend-test/internal/i18n"
+	"github.com/japhy-tech/backend-test/internal/problem"
+	"github.com/japhy-tech/backend-test/internal/repository"
+	"github.com/japhy-tech/backend-test/internal/service"
+	"github.com/japhy-tech/backend-test/internal/units"
+)
+
+// resolver porte les dépendances des champs du schéma : lectures sur le
+// repository, écritures via service.BreedService comme l'API REST
+type resolver struct {
+	repo     repository.BreedRepositoryInterface
+	writer   *service.BreedService
+	names    handlers.DisplayNameProvider
+	maxLimit int
+	logger   *charmLog.Logger
+}
+
+type contextKey struct{}
+
+// requestState est propre à une requête GraphQL : sa langue et les noms
+// d'affichage déjà chargés
+type requestState struct {
+	lang i18n.Lang
+
+	mu     sync.Mutex
+	loaded []repository.Breed
+	names  map[string]map[int]string
+}
+
+func withState(ctx context.Context, lang i18n.Lang) context.Context {
+	return context.WithValue(ctx, contextKey{}, &requestState{lang: lang, names: map[string]map[int]string{}})
+}
+
+func stateFrom(ctx context.Context) *requestState {
+	if state, ok := ctx.Value(contextKey{}).(*requestState); ok {
+		return state
+	}
+	return &requestState{lang: i18n.Default, names: map[string]map[int]string{}}
+}
+
+// track retient les races renvoyées, pour charger leurs noms d'affichage en
+// une seule requête par langue
+func (s *requestState) track(breeds ...repository.Breed) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.loaded = append(s.loaded, breeds...)
+}
+
+func (r *resolver) breeds(p gql.ResolveParams) (interface{}, error) {
+	filter, errs := r.breedFilter(p.Args)
+	if len(errs) > 0 {
+		return nil, r.toError(p.Context, errs)
+	}
+
+	breeds, err := r.repo.GetAll(p.Context, filter)
+	if err != nil {
+		return nil, r.toError(p.Context, err)
+	}
+	if breeds == nil {
+		breeds = []repository.Breed{}
+	}
+
+	stateFrom(p.Context).track(breeds...)
+	return breeds, nil
+}
+
+// breedFilter traduit les arguments filter, sort et page en BreedFilter, avec
+// les mêmes règles que les paramètres de GET /breeds
+func (r *resolver) breedFilter(args map[string]interface{}) (repository.BreedFilter, problem.FieldErrors) {
+	var errs problem.FieldErrors
+	filter := repository.BreedFilter{Limit: handlers.PageLimit(r.maxLimit)}
+
+	if input, ok := args["filter"].(map[string]interface{}); ok {
+		unit := unitArg(input)
+		filter.Species, _ = input["species"].(string)
+		filter.PetSize, _ = input["petSize"].(string)
+		name, _ := input["name"].(string)
+		filter.Name = service.NormalizeAlias(name)
+		filter.Sex, _ = input["sex"].(repository.WeightSex)
+		filter.Match, _ = input["weightMatch"].(repository.WeightMatch)
+		filter.Weight.Min = weightBound(input, "weightMin", unit, &errs)
+		filter.Weight.Max = weightBound(input, "weightMax", unit, &errs)
+	}
+
+	if input, ok := args["sort"].(map[string]interface{}); ok {
+		filter.Sort, _ = input["field"].(repository.SortField)
+		direction, _ := input["direction"].(string)
+		filter.Desc = direction == "desc"
+	}
+
+	if input, ok := args["page"].(map[string]interface{}); ok {
+		if limit, ok := input["limit"].(int); ok {
+			filter.Limit = limit
+		}
+		filter.Offset, _ = input["offset"].(int)
+	}
+	if filter.Limit < 1 {
+		errs.Add("page.limit", "", i18n.MinBound, "1")
+	}
+	if filter.Limit > r.maxLimit {
+		errs.Add("page.limit", "", i18n.MaxBound, strconv.Itoa(r.maxLimit))
+	}
+	if filter.Offset < 0 {
+		errs.Add("page.offset", "", i18n.MinBound, "0")
+	}
+
+	return filter, errs
+}
+
+func weightBound(input map[string]interface{}, field string, unit units.Unit, errs *problem.FieldErrors) *int {
+	value, ok := input[field].(float64)
+	if !ok {
+		return nil
+	}
+	if value < 0 {
+		errs.Add("filter."+field, "", i18n.MinBound, "0")
+		return nil
+	}
+	if tooHigh(value, unit, "filter."+field, errs) {
+		return nil
+	}
+	grams := unit.ToGrams(value)
+	return &grams
+}
+
+// tooHigh signale dans errs un poids au-delà de units.MaxPlausibleGrams, qui
+// ne doit pas être converti en grammes
+func tooHigh(value float64, unit units.Unit, field string, errs *problem.FieldErrors) bool {
+	if !unit.TooHigh(value) {
+		return false
+	}
+	errs.Add(field, "", i18n.MaxBound, strconv.FormatFloat(unit.MaxValue(), 'f', -1, 64))
+	return true
+}
+
+func unitArg(args map[string]interface{}) units.Unit {
+	if unit, ok := args["unit"].(units.Unit); ok {
+		return unit
+	}
+	return units.Grams
+}
+
+func (r *resolver) breed(p gql.ResolveParams) (interface{}, error) {
+	id, _ := p.Args["id"].(int)
+	breed, err := r.repo.GetByID(p.Context, id)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, r.toError(p.Context, err)
+	}
+
+	stateFrom(p.Context).track(*breed)
+	return breed, nil
+}
+
+// authorized exige pour une mutation le même rôle que la route REST
+// équivalente ; l'endpoint lui-même n'exige que le rôle reader
+func authorized(role auth.Role, resolve gql.FieldResolveFn) gql.FieldResolveFn {
+	return func(p gql.ResolveParams) (interface{}, error) {
+		if denied := auth.Authorize(p.Context, role); denied != nil {
+			return nil, newError(p.Context, denied)
+		}
+		return resolve(p)
+	}
+}
+
+func (r *resolver) createBreed(p gql.ResolveParams) (interface{}, error) {
+	input, _ := p.Args["input"].(map[string]interface{})
+	unit := unitArg(input)
+
+	breed := &repository.Breed{}
+	breed.Species, _ = input["species"].(string)
+	breed.PetSize, _ = input["petSize"].(string)
+	breed.Name, _ = input["name"].(string)
+	maleWeight, _ := input["averageMaleAdultWeight"].(float64)
+	femaleWeight, _ := input["averageFemaleAdultWeight"].(float64)
+
+	var errs problem.FieldErrors
+	if breed.Species == "" {
+		errs.Add("species", "", i18n.FieldRequired)
+	}
+	if breed.Name == "" {
+		errs.Add("name", "", i18n.FieldRequired)
+	}
+	if !tooHigh(maleWeight, unit, "averageMaleAdultWeight", &errs) {
+		breed.AverageMaleAdultWeight = unit.ToGrams(maleWeight)
+		if breed.AverageMaleAdultWeight <= 0 {
+			errs.Add("averageMaleAdultWeight", "", i18n.MustBePositive)
+		}
+	}
+	if !tooHigh(femaleWeight, unit, "averageFemaleAdultWeight", &errs) {
+		breed.AverageFemaleAdultWeight = unit.ToGrams(femaleWeight)
+		if breed.AverageFemaleAdultWeight <= 0 {
+			errs.Add("averageFemaleAdultWeight", "", i18n.MustBePositive)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, r.toError(p.Context, errs)
+	}
+
+	created, err := r.writer.Create(p.Context, breed)
+	if err != nil {
+		return nil, r.toError(p.Context, err, "breed", breed.Name)
+	}
+
+	stateFrom(p.Context).track(*created)
+	return created, nil
+}
+
+func (r *resolver) updateBreed(p gql.ResolveParams) (interface{}, error) {
+	id, _ := p.Args["id"].(int)
+	input, _ := p.Args["input"].(map[string]interface{})
+	unit := unitArg(input)
+
+	changes := &repository.Breed{}
+	changes.Species, _ = input["species"].(string)
+	changes.PetSize, _ = input["petSize"].(string)
+	changes.Name, _ = input["name"].(string)
+	var errs problem.FieldErrors
+	if value, ok := input["averageMaleAdultWeight"].(float64); ok && !tooHigh(value, unit, "averageMaleAdultWeight", &errs) {
+		changes.AverageMaleAdultWeight = unit.ToGrams(value)
+		if changes.AverageMaleAdultWeight <= 0 {
+			errs.Add("averageMaleAdultWeight", "", i18n.MustBePositive)
+		}
+	}
+	if value, ok := input["averageFemaleAdultWeight"].(float64); ok && !tooHigh(value, unit, "averageFemaleAdultWeight", &errs) {
+		changes.AverageFemaleAdultWeight = unit.ToGrams(value)
+		if changes.AverageFemaleAdultWeight <= 0 {
+			errs.Add("averageFemaleAdultWeight", "", i18n.MustBePositive)
+		}
+	}
+	if *changes == (repository.Breed{}) && len(errs) == 0 {
+		errs.Add("input", "", i18n.NothingToUpdate)
+	}
+	if len(errs) > 0 {
+		return nil, r.toError(p.Context, errs)
+	}
+
+	updated, err := r.writer.Update(p.Context, id, changes)
+	if err != nil {
+		return nil, r.toError(p.Context, err, "id", id)
+	}
+
+	stateFrom(p.Context).track(*updated)
+	return updated, nil
+}
+
+func (r *resolver) deleteBreed(p gql.ResolveParams) (interface{}, error) {
+	id, _ := p.Args["id"].(int)
+	if err := r.repo.Delete(p.Context, id); err != nil {
+		return nil, r.toError(p.Context, err, "id", id)
+	}
+	return true, nil
+}
+
+func (r *resolver) maleWeight(p gql.ResolveParams) (interface{}, error) {
+	breed := sourceBreed(p)
+	return unitArg(p.Args).FromGrams(breed.AverageMaleAdultWeight), nil
+}
+
+func (r *resolver) femaleWeight(p gql.ResolveParams) (interface{}, error) {
+	breed := sourceBreed(p)
+	return unitArg(p.Args).FromGrams(breed.AverageFemaleAdultWeight), nil
+}
+
+// displayName charge les noms de toutes les races déjà renvoyées au premier
+// appel pour une langue, plutôt qu'une requête par race
+func (r *resolver) displayName(p gql.ResolveParams) (interface{}, error) {
+	breed := sourceBreed(p)
+	state := stateFrom(p.Context)
+	locale, ok := p.Args["lang"].(string)
+	if !ok {
+		locale = string(state.lang)
+	}
+
+	state.mu.Lock()
+	defer state.mu.Unlock()
+
+	names, ok := state.names[locale]
+	if !ok {
+		names = map[int]string{}
+		state.names[locale] = names
+	}
+	if _, found := names[breed.ID]; !found {
+		pending := []repository.Breed{breed}
+		for _, loaded := range state.loaded {
+			if _, found := names[loaded.ID]; !found && loaded.ID != breed.ID {
+				pending = append(pending, loaded)
+			}
+		}
+		loaded, err := r.names.DisplayNames(locale, pending)
+		if err != nil {
+			return nil, r.toError(p.Context, err, "lang", locale)
+		}
+		for id, name := range loaded {
+			names[id] = name
+		}
+	}
+
+	if name, ok := names[breed.ID]; ok {
+		return name, nil
+	}
+	return service.HumanizeBreedName(breed.Name), nil
+}
+
+func sourceBreed(p gql.ResolveParams) repository.Breed {
+	switch source := p.Source.(type) {
+	case *repository.Breed:
+		return *source
+	case repository.Breed:
+		return source
+	}
+	return repository.Breed{}
+}
@@ -0,0 +1,204 @@
+// Package graphql expose le modèle des races en GraphQL (/graphql), à côté
+// de l'API REST et sur les mêmes repositories et services
+package graphql
+
+import (
+	"strings"
+
+	gql "github.com/graphql-go/graphql"
+	"github.com/japhy-tech/backend-test/internal/auth"
+	"github.com/japhy-tech/backend-test/internal/handlers"
+	"github.com/japhy-tech/backend-test/internal/repository"
+	"github.com/japhy-tech/backend-test/internal/service"
+	"github.com/japhy-tech/backend-test/internal/units"
+)
+
+// newSchema décrit les types et branche chaque champ sur son resolver
+func newSchema(res *resolver) (gql.Schema, error) {
+	weightUnit := gql.NewEnum(gql.EnumConfig{
+		Name:        "WeightUnit",
+		Description: "Unité des poids ; ils sont stockés en grammes",
+		Values: gql.EnumValueConfigMap{
+			"G":  &gql.EnumValueConfig{Value: units.Grams},
+			"KG": &gql.EnumValueConfig{Value: units.Kilograms},
+			"LB": &gql.EnumValueConfig{Value: units.Pounds},
+		},
+	})
+
+	langValues := gql.EnumValueConfigMap{}
+	for _, locale := range service.SupportedLocales() {
+		langValues[strings.ToUpper(locale)] = &gql.EnumValueConfig{Value: locale}
+	}
+	lang := gql.NewEnum(gql.EnumConfig{
+		Name:        "Lang",
+		Description: "Langue des noms d'affichage",
+		Values:      langValues,
+	})
+
+	weightSex := gql.NewEnum(gql.EnumConfig{
+		Name:        "WeightSex",
+		Description: "Poids moyens comparés à weightMin/weightMax",
+		Values: gql.EnumValueConfigMap{
+			"ANY":    &gql.EnumValueConfig{Value: repository.SexAny},
+			"BOTH":   &gql.EnumValueConfig{Value: repository.SexBoth},
+			"MALE":   &gql.EnumValueConfig{Value: repository.SexMale},
+			"FEMALE": &gql.EnumValueConfig{Value: repository.SexFemale},
+		},
+	})
+
+	weightMatch := gql.NewEnum(gql.EnumConfig{
+		Name:        "WeightMatch",
+		Description: "Comparaison des poids à l'intervalle demandé",
+		Values: gql.EnumValueConfigMap{
+			"CONTAINED": &gql.EnumValueConfig{Value: repository.MatchContained},
+			"OVERLAP":   &gql.EnumValueConfig{Value: repository.MatchOverlap},
+		},
+	})
+
+	sortField := gql.NewEnum(gql.EnumConfig{
+		Name: "BreedSortField",
+		Values: gql.EnumValueConfigMap{
+			"NAME":          &gql.EnumValueConfig{Value: repository.SortByName},
+			"ID":            &gql.EnumValueConfig{Value: repository.SortByID},
+			"MALE_WEIGHT":   &gql.EnumValueConfig{Value: repository.SortByMaleWeight},
+			"FEMALE_WEIGHT": &gql.EnumValueConfig{Value: repository.SortByFemaleWeight},
+		},
+	})
+
+	sortDirection := gql.NewEnum(gql.EnumConfig{
+		Name: "SortDirection",
+		Values: gql.EnumValueConfigMap{
+			"ASC":  &gql.EnumValueConfig{Value: "asc"},
+			"DESC": &gql.EnumValueConfig{Value: "desc"},
+		},
+	})
+
+	unitArg := &gql.ArgumentConfig{Type: weightUnit, DefaultValue: units.Grams}
+
+	breed := gql.NewObject(gql.ObjectConfig{
+		Name: "Breed",
+		Fields: gql.Fields{
+			"id":      &gql.Field{Type: gql.NewNonNull(gql.Int)},
+			"species": &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"petSize": &gql.Field{Type: gql.NewNonNull(gql.String)},
+			"name":    &gql.Field{Type: gql.NewNonNull(gql.String), Description: "Identifiant unique (snake_case)"},
+			"displayName": &gql.Field{
+				Type:        gql.NewNonNull(gql.String),
+				Description: "Nom traduit, dans la langue de Accept-Language si lang est absent",
+				Args:        gql.FieldConfigArgument{"lang": &gql.ArgumentConfig{Type: lang}},
+				Resolve:     res.displayName,
+			},
+			"averageMaleAdultWeight": &gql.Field{
+				Type:    gql.NewNonNull(gql.Float),
+				Args:    gql.FieldConfigArgument{"unit": unitArg},
+				Resolve: res.maleWeight,
+			},
+			"averageFemaleAdultWeight": &gql.Field{
+				Type:    gql.NewNonNull(gql.Float),
+				Args:    gql.FieldConfigArgument{"unit": unitArg},
+				Resolve: res.femaleWeight,
+			},
+		},
+	})
+
+	filter := gql.NewInputObject(gql.InputObjectConfig{
+		Name: "BreedFilter",
+		Fields: gql.InputObjectConfigFieldMap{
+			"species":     &gql.InputObjectFieldConfig{Type: gql.String},
+			"petSize":     &gql.InputObjectFieldConfig{Type: gql.String},
+			"name":        &gql.InputObjectFieldConfig{Type: gql.String, Description: "Texte recherché dans le nom et les alias"},
+			"weightMin":   &gql.InputObjectFieldConfig{Type: gql.Float},
+			"weightMax":   &gql.InputObjectFieldConfig{Type: gql.Float},
+			"unit":        &gql.InputObjectFieldConfig{Type: weightUnit, DefaultValue: units.Grams},
+			"sex":         &gql.InputObjectFieldConfig{Type: weightSex, DefaultValue: repository.SexAny},
+			"weightMatch": &gql.InputObjectFieldConfig{Type: weightMatch, DefaultValue: repository.MatchContained},
+		},
+	})
+
+	sort := gql.NewInputObject(gql.InputObjectConfig{
+		Name: "BreedSort",
+		Fields: gql.InputObjectConfigFieldMap{
+			"field":     &gql.InputObjectFieldConfig{Type: sortField, DefaultValue: repository.SortByName},
+			"direction": &gql.InputObjectFieldConfig{Type: sortDirection, DefaultValue: "asc"},
+		},
+	})
+
+	page := gql.NewInputObject(gql.InputObjectConfig{
+		Name: "Page",
+		Fields: gql.InputObjectConfigFieldMap{
+			"limit":  &gql.InputObjectFieldConfig{Type: gql.Int, DefaultValue: handlers.PageLimit(res.maxLimit)},
+			"offset": &gql.InputObjectFieldConfig{Type: gql.Int, DefaultValue: 0},
+		},
+	})
+
+	createInput := gql.NewInputObject(gql.InputObjectConfig{
+		Name: "CreateBreedInput",
+		Fields: gql.InputObjectConfigFieldMap{
+			"species":                  &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.String)},
+			"petSize":                  &gql.InputObjectFieldConfig{Type: gql.String, Description: "Déduit des poids si absent"},
+			"name":                     &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.String)},
+			"averageMaleAdultWeight":   &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.Float)},
+			"averageFemaleAdultWeight": &gql.InputObjectFieldConfig{Type: gql.NewNonNull(gql.Float)},
+			"unit":                     &gql.InputObjectFieldConfig{Type: weightUnit, DefaultValue: units.Grams},
+		},
+	})
+
+	updateInput := gql.NewInputObject(gql.InputObjectConfig{
+		Name: "UpdateBreedInput",
+		Fields: gql.InputObjectConfigFieldMap{
+			"species":                  &gql.InputObjectFieldConfig{Type: gql.String},
+			"petSize":                  &gql.InputObjectFieldConfig{Type: gql.String},
+			"name":                     &gql.InputObjectFieldConfig{Type: gql.String},
+			"averageMaleAdultWeight":   &gql.InputObjectFieldConfig{Type: gql.Float},
+			"averageFemaleAdultWeight": &gql.InputObjectFieldConfig{Type: gql.Float},
+			"unit":                     &gql.InputObjectFieldConfig{Type: weightUnit, DefaultValue: units.Grams},
+		},
+	})
+
+	query := gql.NewObject(gql.ObjectConfig{
+		Name: "Query",
+		Fields: gql.Fields{
+			"breeds": &gql.Field{
+				Type: gql.NewNonNull(gql.NewList(gql.NewNonNull(breed))),
+				Args: gql.FieldConfigArgument{
+					"filter": &gql.ArgumentConfig{Type: filter},
+					"sort":   &gql.ArgumentConfig{Type: sort},
+					"page":   &gql.ArgumentConfig{Type: page},
+				},
+				Resolve: res.breeds,
+			},
+			"breed": &gql.Field{
+				Type:        breed,
+				Description: "null si la race n'existe pas",
+				Args:        gql.FieldConfigArgument{"id": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.Int)}},
+				Resolve:     res.breed,
+			},
+		},
+	})
+
+	mutation := gql.NewObject(gql.ObjectConfig{
+		Name: "Mutation",
+		Fields: gql.Fields{
+			"createBreed": &gql.Field{
+				Type:    gql.NewNonNull(breed),
+				Args:    gql.FieldConfigArgument{"input": &gql.ArgumentConfig{Type: gql.NewNonNull(createInput)}},
+				Resolve: authorized(auth.RoleEditor, res.createBreed),
+			},
+			"updateBreed": &gql.Field{
+				Type: gql.NewNonNull(breed),
+				Args: gql.FieldConfigArgument{
+					"id":    &gql.ArgumentConfig{Type: gql.NewNonNull(gql.Int)},
+					"input": &gql.ArgumentConfig{Type: gql.NewNonNull(updateInput)},
+				},
+				Resolve: authorized(auth.RoleEditor, res.updateBreed),
+			},
+			"deleteBreed": &gql.Field{
+				Type:    gql.NewNonNull(gql.Boolean),
+				Args:    gql.FieldConfigArgument{"id": &gql.ArgumentConfig{Type: gql.NewNonNull(gql.Int)}},
+				Resolve: authorized(auth.RoleAdmin, res.deleteBreed),
+			},
+		},
+	})
+
+	return gql.NewSchema(gql.SchemaConfig{Query: query, Mutation: mutation})
+}
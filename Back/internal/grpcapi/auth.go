@@ -0,0 +1,62 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/auth"
+	"github.com/japhy-tech/backend-test/internal/grpcapi/breedv1"
+	"github.com/japhy-tech/backend-test/internal/problem"
+	"github.com/japhy-tech/backend-test/internal/requestid"
+	"google.golang.org/grpc/metadata"
+)
+
+// methodRoles donne le rôle requis par chaque méthode, le même que celui de
+// la route REST équivalente ; les méthodes absentes (health checking,
+// réflexion) restent ouvertes
+var methodRoles = map[string]auth.Role{
+	breedv1.BreedService_ListBreeds_FullMethodName:  auth.RoleReader,
+	breedv1.BreedService_GetBreed_FullMethodName:    auth.RoleReader,
+	breedv1.BreedService_CreateBreed_FullMethodName: auth.RoleEditor,
+	breedv1.BreedService_UpdateBreed_FullMethodName: auth.RoleEditor,
+	breedv1.BreedService_DeleteBreed_FullMethodName: auth.RoleAdmin,
+}
+
+// authorize authentifie l'appelant à partir des métadonnées authorization ou
+// x-api-key, comme les en-têtes HTTP, et le place dans le contexte
+func authorize(ctx context.Context, method string, authenticator *auth.Authenticator, logger *charmLog.Logger) (context.Context, error) {
+	role, ok := methodRoles[method]
+	if !ok {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	principal, err := authenticator.Authenticate(first(md, "authorization"), first(md, "x-api-key"))
+	if err != nil {
+		if !errors.Is(err, auth.ErrMissingCredentials) && !errors.Is(err, auth.ErrInvalidCredentials) {
+			logger.Error("Erreur lors de la vérification de la clé d'API", "error", err, "request_id", requestid.FromContext(ctx))
+			return ctx, statusFrom(ctx, problem.Internal())
+		}
+		logger.Warn("Authentification refusée", "error", err, "method", method, "request_id", requestid.FromContext(ctx))
+		return ctx, statusFrom(ctx, auth.Unauthenticated(err))
+	}
+
+	if !principal.Role.Allows(role) {
+		logger.Warn("Accès refusé", "principal", principal.Subject, "role", principal.Role, "required", role, "method", method, "request_id", requestid.FromContext(ctx))
+		return ctx, statusFrom(ctx, auth.Forbidden(principal, role))
+	}
+
+	if role != auth.RoleReader {
+		logger.Info("Accès en écriture", "principal", principal.Subject, "name", principal.Name, "role", principal.Role, "method", method, "request_id", requestid.FromContext(ctx))
+	}
+
+	return auth.WithPrincipal(ctx, principal), nil
+}
+
+func first(md metadata.MD, key string) string {
+	if values := md.Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
@@ -0,0 +1,977 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: breed/v1/breed.proto
+
+// API gRPC des races, pour les consommateurs internes (service de
+// recommandation). Les poids sont toujours exprimés en grammes.
+
+package breedv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// WeightSex indique quels poids moyens sont comparés à l'intervalle
+type WeightSex int32
+
+const (
+	// Équivaut à WEIGHT_SEX_ANY
+	WeightSex_WEIGHT_SEX_UNSPECIFIED WeightSex = 0
+	WeightSex_WEIGHT_SEX_ANY         WeightSex = 1
+	WeightSex_WEIGHT_SEX_BOTH        WeightSex = 2
+	WeightSex_WEIGHT_SEX_MALE        WeightSex = 3
+	WeightSex_WEIGHT_SEX_FEMALE      WeightSex = 4
+)
+
+// Enum value maps for WeightSex.
+var (
+	WeightSex_name = map[int32]string{
+		0: "WEIGHT_SEX_UNSPECIFIED",
+		1: "WEIGHT_SEX_ANY",
+		2: "WEIGHT_SEX_BOTH",
+		3: "WEIGHT_SEX_MALE",
+		4: "WEIGHT_SEX_FEMALE",
+	}
+	WeightSex_value = map[string]int32{
+		"WEIGHT_SEX_UNSPECIFIED": 0,
+		"WEIGHT_SEX_ANY":         1,
+		"WEIGHT_SEX_BOTH":        2,
+		"WEIGHT_SEX_MALE":        3,
+		"WEIGHT_SEX_FEMALE":      4,
+	}
+)
+
+func (x WeightSex) Enum() *WeightSex {
+	p := new(WeightSex)
+	*p = x
+	return p
+}
+
+func (x WeightSex) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WeightSex) Descriptor() protoreflect.EnumDescriptor {
+	return file_breed_v1_breed_proto_enumTypes[0].Descriptor()
+}
+
+func (WeightSex) Type() protoreflect.EnumType {
+	return &file_breed_v1_breed_proto_enumTypes[0]
+}
+
+func (x WeightSex) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WeightSex.Descriptor instead.
+func (WeightSex) EnumDescriptor() ([]byte, []int) {
+	return file_breed_v1_breed_proto_rawDescGZIP(), []int{0}
+}
+
+// WeightMatch indique comment les poids sont comparés à l'intervalle
+type WeightMatch int32
+
+const (
+	// Équivaut à WEIGHT_MATCH_CONTAINED
+	WeightMatch_WEIGHT_MATCH_UNSPECIFIED WeightMatch = 0
+	WeightMatch_WEIGHT_MATCH_CONTAINED   WeightMatch = 1
+	WeightMatch_WEIGHT_MATCH_OVERLAP     WeightMatch = 2
+)
+
+// Enum value maps for WeightMatch.
+var (
+	WeightMatch_name = map[int32]string{
+		0: "WEIGHT_MATCH_UNSPECIFIED",
+		1: "WEIGHT_MATCH_CONTAINED",
+		2: "WEIGHT_MATCH_OVERLAP",
+	}
+	WeightMatch_value = map[string]int32{
+		"WEIGHT_MATCH_UNSPECIFIED": 0,
+		"WEIGHT_MATCH_CONTAINED":   1,
+		"WEIGHT_MATCH_OVERLAP":     2,
+	}
+)
+
+func (x WeightMatch) Enum() *WeightMatch {
+	p := new(WeightMatch)
+	*p = x
+	return p
+}
+
+func (x WeightMatch) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (WeightMatch) Descriptor() protoreflect.EnumDescriptor {
+	return file_breed_v1_breed_proto_enumTypes[1].Descriptor()
+}
+
+func (WeightMatch) Type() protoreflect.EnumType {
+	return &file_breed_v1_breed_proto_enumTypes[1]
+}
+
+func (x WeightMatch) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use WeightMatch.Descriptor instead.
+func (WeightMatch) EnumDescriptor() ([]byte, []int) {
+	return file_breed_v1_breed_proto_rawDescGZIP(), []int{1}
+}
+
+type SortField int32
+
+const (
+	// Équivaut à SORT_FIELD_NAME
+	SortField_SORT_FIELD_UNSPECIFIED   SortField = 0
+	SortField_SORT_FIELD_NAME          SortField = 1
+	SortField_SORT_FIELD_ID            SortField = 2
+	SortField_SORT_FIELD_MALE_WEIGHT   SortField = 3
+	SortField_SORT_FIELD_FEMALE_WEIGHT SortField = 4
+)
+
+// Enum value maps for SortField.
+var (
+	SortField_name = map[int32]string{
+		0: "SORT_FIELD_UNSPECIFIED",
+		1: "SORT_FIELD_NAME",
+		2: "SORT_FIELD_ID",
+		3: "SORT_FIELD_MALE_WEIGHT",
+		4: "SORT_FIELD_FEMALE_WEIGHT",
+	}
+	SortField_value = map[string]int32{
+		"SORT_FIELD_UNSPECIFIED":   0,
+		"SORT_FIELD_NAME":          1,
+		"SORT_FIELD_ID":            2,
+		"SORT_FIELD_MALE_WEIGHT":   3,
+		"SORT_FIELD_FEMALE_WEIGHT": 4,
+	}
+)
+
+func (x SortField) Enum() *SortField {
+	p := new(SortField)
+	*p = x
+	return p
+}
+
+func (x SortField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortField) Descriptor() protoreflect.EnumDescriptor {
+	return file_breed_v1_breed_proto_enumTypes[2].Descriptor()
+}
+
+func (SortField) Type() protoreflect.EnumType {
+	return &file_breed_v1_breed_proto_enumTypes[2]
+}
+
+func (x SortField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortField.Descriptor instead.
+func (SortField) EnumDescriptor() ([]byte, []int) {
+	return file_breed_v1_breed_proto_rawDescGZIP(), []int{2}
+}
+
+type Breed struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Species string                 `protobuf:"bytes,2,opt,name=species,proto3" json:"species,omitempty"`
+	PetSize string                 `protobuf:"bytes,3,opt,name=pet_size,json=petSize,proto3" json:"pet_size,omitempty"`
+	// Identifiant unique (snake_case)
+	Name                     string `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	AverageMaleAdultWeight   int32  `protobuf:"varint,5,opt,name=average_male_adult_weight,json=averageMaleAdultWeight,proto3" json:"average_male_adult_weight,omitempty"`
+	AverageFemaleAdultWeight int32  `protobuf:"varint,6,opt,name=average_female_adult_weight,json=averageFemaleAdultWeight,proto3" json:"average_female_adult_weight,omitempty"`
+	unknownFields            protoimpl.UnknownFields
+	sizeCache                protoimpl.SizeCache
+}
+
+func (x *Breed) Reset() {
+	*x = Breed{}
+	mi := &file_breed_v1_breed_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Breed) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Breed) ProtoMessage() {}
+
+func (x *Breed) ProtoReflect() protoreflect.Message {
+	mi := &file_breed_v1_breed_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Breed.ProtoReflect.Descriptor instead.
+func (*Breed) Descriptor() ([]byte, []int) {
+	return file_breed_v1_breed_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Breed) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Breed) GetSpecies() string {
+	if x != nil {
+		return x.Species
+	}
+	return ""
+}
+
+func (x *Breed) GetPetSize() string {
+	if x != nil {
+		return x.PetSize
+	}
+	return ""
+}
+
+func (x *Breed) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Breed) GetAverageMaleAdultWeight() int32 {
+	if x != nil {
+		return x.AverageMaleAdultWeight
+	}
+	return 0
+}
+
+func (x *Breed) GetAverageFemaleAdultWeight() int32 {
+	if x != nil {
+		return x.AverageFemaleAdultWeight
+	}
+	return 0
+}
+
+// WeightRange est un intervalle en grammes, bornes incluses et optionnelles
+type WeightRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Min           *int32                 `protobuf:"varint,1,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	Max           *int32                 `protobuf:"varint,2,opt,name=max,proto3,oneof" json:"max,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WeightRange) Reset() {
+	*x = WeightRange{}
+	mi := &file_breed_v1_breed_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WeightRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WeightRange) ProtoMessage() {}
+
+func (x *WeightRange) ProtoReflect() protoreflect.Message {
+	mi := &file_breed_v1_breed_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WeightRange.ProtoReflect.Descriptor instead.
+func (*WeightRange) Descriptor() ([]byte, []int) {
+	return file_breed_v1_breed_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WeightRange) GetMin() int32 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *WeightRange) GetMax() int32 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+type BreedFilter struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Species string                 `protobuf:"bytes,1,opt,name=species,proto3" json:"species,omitempty"`
+	PetSize string                 `protobuf:"bytes,2,opt,name=pet_size,json=petSize,proto3" json:"pet_size,omitempty"`
+	// Texte recherché dans le nom et les alias
+	Name string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// weight est combiné selon sex et match
+	Weight *WeightRange `protobuf:"bytes,4,opt,name=weight,proto3" json:"weight,omitempty"`
+	Sex    WeightSex    `protobuf:"varint,5,opt,name=sex,proto3,enum=japhy.breed.v1.WeightSex" json:"sex,omitempty"`
+	Match  WeightMatch  `protobuf:"varint,6,opt,name=match,proto3,enum=japhy.breed.v1.WeightMatch" json:"match,omitempty"`
+	// male_weight et female_weight s'ajoutent (ET) au filtre weight
+	MaleWeight    *WeightRange `protobuf:"bytes,7,opt,name=male_weight,json=maleWeight,proto3" json:"male_weight,omitempty"`
+	FemaleWeight  *WeightRange `protobuf:"bytes,8,opt,name=female_weight,json=femaleWeight,proto3" json:"female_weight,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BreedFilter) Reset() {
+	*x = BreedFilter{}
+	mi := &file_breed_v1_breed_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BreedFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BreedFilter) ProtoMessage() {}
+
+func (x *BreedFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_breed_v1_breed_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BreedFilter.ProtoReflect.Descriptor instead.
+func (*BreedFilter) Descriptor() ([]byte, []int) {
+	return file_breed_v1_breed_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BreedFilter) GetSpecies() string {
+	if x != nil {
+		return x.Species
+	}
+	return ""
+}
+
+func (x *BreedFilter) GetPetSize() string {
+	if x != nil {
+		return x.PetSize
+	}
+	return ""
+}
+
+func (x *BreedFilter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *BreedFilter) GetWeight() *WeightRange {
+	if x != nil {
+		return x.Weight
+	}
+	return nil
+}
+
+func (x *BreedFilter) GetSex() WeightSex {
+	if x != nil {
+		return x.Sex
+	}
+	return WeightSex_WEIGHT_SEX_UNSPECIFIED
+}
+
+func (x *BreedFilter) GetMatch() WeightMatch {
+	if x != nil {
+		return x.Match
+	}
+	return WeightMatch_WEIGHT_MATCH_UNSPECIFIED
+}
+
+func (x *BreedFilter) GetMaleWeight() *WeightRange {
+	if x != nil {
+		return x.MaleWeight
+	}
+	return nil
+}
+
+func (x *BreedFilter) GetFemaleWeight() *WeightRange {
+	if x != nil {
+		return x.FemaleWeight
+	}
+	return nil
+}
+
+type Sort struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         SortField              `protobuf:"varint,1,opt,name=field,proto3,enum=japhy.breed.v1.SortField" json:"field,omitempty"`
+	Descending    bool                   `protobuf:"varint,2,opt,name=descending,proto3" json:"descending,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Sort) Reset() {
+	*x = Sort{}
+	mi := &file_breed_v1_breed_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Sort) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Sort) ProtoMessage() {}
+
+func (x *Sort) ProtoReflect() protoreflect.Message {
+	mi := &file_breed_v1_breed_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Sort.ProtoReflect.Descriptor instead.
+func (*Sort) Descriptor() ([]byte, []int) {
+	return file_breed_v1_breed_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Sort) GetField() SortField {
+	if x != nil {
+		return x.Field
+	}
+	return SortField_SORT_FIELD_UNSPECIFIED
+}
+
+func (x *Sort) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+type Pagination struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// 0 envoie toutes les races ; sinon au plus MAX_PAGE_LIMIT
+	Limit         int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32 `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Pagination) Reset() {
+	*x = Pagination{}
+	mi := &file_breed_v1_breed_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Pagination) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Pagination) ProtoMessage() {}
+
+func (x *Pagination) ProtoReflect() protoreflect.Message {
+	mi := &file_breed_v1_breed_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Pagination.ProtoReflect.Descriptor instead.
+func (*Pagination) Descriptor() ([]byte, []int) {
+	return file_breed_v1_breed_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Pagination) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *Pagination) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type ListBreedsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *BreedFilter           `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	Sort          *Sort                  `protobuf:"bytes,2,opt,name=sort,proto3" json:"sort,omitempty"`
+	Page          *Pagination            `protobuf:"bytes,3,opt,name=page,proto3" json:"page,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListBreedsRequest) Reset() {
+	*x = ListBreedsRequest{}
+	mi := &file_breed_v1_breed_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListBreedsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListBreedsRequest) ProtoMessage() {}
+
+func (x *ListBreedsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_breed_v1_breed_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListBreedsRequest.ProtoReflect.Descriptor instead.
+func (*ListBreedsRequest) Descriptor() ([]byte, []int) {
+	return file_breed_v1_breed_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListBreedsRequest) GetFilter() *BreedFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListBreedsRequest) GetSort() *Sort {
+	if x != nil {
+		return x.Sort
+	}
+	return nil
+}
+
+func (x *ListBreedsRequest) GetPage() *Pagination {
+	if x != nil {
+		return x.Page
+	}
+	return nil
+}
+
+type GetBreedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBreedRequest) Reset() {
+	*x = GetBreedRequest{}
+	mi := &file_breed_v1_breed_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBreedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBreedRequest) ProtoMessage() {}
+
+func (x *GetBreedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_breed_v1_breed_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBreedRequest.ProtoReflect.Descriptor instead.
+func (*GetBreedRequest) Descriptor() ([]byte, []int) {
+	return file_breed_v1_breed_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *GetBreedRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CreateBreedRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id est ignoré ; pet_size est déduit des poids s'il est vide
+	Breed         *Breed `protobuf:"bytes,1,opt,name=breed,proto3" json:"breed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBreedRequest) Reset() {
+	*x = CreateBreedRequest{}
+	mi := &file_breed_v1_breed_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBreedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBreedRequest) ProtoMessage() {}
+
+func (x *CreateBreedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_breed_v1_breed_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBreedRequest.ProtoReflect.Descriptor instead.
+func (*CreateBreedRequest) Descriptor() ([]byte, []int) {
+	return file_breed_v1_breed_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *CreateBreedRequest) GetBreed() *Breed {
+	if x != nil {
+		return x.Breed
+	}
+	return nil
+}
+
+type UpdateBreedRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Seuls les champs renseignés (chaînes non vides, poids non nuls) sont
+	// modifiés
+	Breed         *Breed `protobuf:"bytes,2,opt,name=breed,proto3" json:"breed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBreedRequest) Reset() {
+	*x = UpdateBreedRequest{}
+	mi := &file_breed_v1_breed_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBreedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBreedRequest) ProtoMessage() {}
+
+func (x *UpdateBreedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_breed_v1_breed_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBreedRequest.ProtoReflect.Descriptor instead.
+func (*UpdateBreedRequest) Descriptor() ([]byte, []int) {
+	return file_breed_v1_breed_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *UpdateBreedRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateBreedRequest) GetBreed() *Breed {
+	if x != nil {
+		return x.Breed
+	}
+	return nil
+}
+
+type DeleteBreedRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBreedRequest) Reset() {
+	*x = DeleteBreedRequest{}
+	mi := &file_breed_v1_breed_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBreedRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBreedRequest) ProtoMessage() {}
+
+func (x *DeleteBreedRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_breed_v1_breed_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBreedRequest.ProtoReflect.Descriptor instead.
+func (*DeleteBreedRequest) Descriptor() ([]byte, []int) {
+	return file_breed_v1_breed_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *DeleteBreedRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type DeleteBreedResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteBreedResponse) Reset() {
+	*x = DeleteBreedResponse{}
+	mi := &file_breed_v1_breed_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteBreedResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteBreedResponse) ProtoMessage() {}
+
+func (x *DeleteBreedResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_breed_v1_breed_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteBreedResponse.ProtoReflect.Descriptor instead.
+func (*DeleteBreedResponse) Descriptor() ([]byte, []int) {
+	return file_breed_v1_breed_proto_rawDescGZIP(), []int{10}
+}
+
+var File_breed_v1_breed_proto protoreflect.FileDescriptor
+
+const file_breed_v1_breed_proto_rawDesc = "" +
+	"\n" +
+	"\x14breed/v1/breed.proto\x12\x0ejaphy.breed.v1\"\xda\x01\n" +
+	"\x05Breed\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x18\n" +
+	"\aspecies\x18\x02 \x01(\tR\aspecies\x12\x19\n" +
+	"\bpet_size\x18\x03 \x01(\tR\apetSize\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x129\n" +
+	"\x19average_male_adult_weight\x18\x05 \x01(\x05R\x16averageMaleAdultWeight\x12=\n" +
+	"\x1baverage_female_adult_weight\x18\x06 \x01(\x05R\x18averageFemaleAdultWeight\"K\n" +
+	"\vWeightRange\x12\x15\n" +
+	"\x03min\x18\x01 \x01(\x05H\x00R\x03min\x88\x01\x01\x12\x15\n" +
+	"\x03max\x18\x02 \x01(\x05H\x01R\x03max\x88\x01\x01B\x06\n" +
+	"\x04_minB\x06\n" +
+	"\x04_max\"\xeb\x02\n" +
+	"\vBreedFilter\x12\x18\n" +
+	"\aspecies\x18\x01 \x01(\tR\aspecies\x12\x19\n" +
+	"\bpet_size\x18\x02 \x01(\tR\apetSize\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x123\n" +
+	"\x06weight\x18\x04 \x01(\v2\x1b.japhy.breed.v1.WeightRangeR\x06weight\x12+\n" +
+	"\x03sex\x18\x05 \x01(\x0e2\x19.japhy.breed.v1.WeightSexR\x03sex\x121\n" +
+	"\x05match\x18\x06 \x01(\x0e2\x1b.japhy.breed.v1.WeightMatchR\x05match\x12<\n" +
+	"\vmale_weight\x18\a \x01(\v2\x1b.japhy.breed.v1.WeightRangeR\n" +
+	"maleWeight\x12@\n" +
+	"\rfemale_weight\x18\b \x01(\v2\x1b.japhy.breed.v1.WeightRangeR\ffemaleWeight\"W\n" +
+	"\x04Sort\x12/\n" +
+	"\x05field\x18\x01 \x01(\x0e2\x19.japhy.breed.v1.SortFieldR\x05field\x12\x1e\n" +
+	"\n" +
+	"descending\x18\x02 \x01(\bR\n" +
+	"descending\":\n" +
+	"\n" +
+	"Pagination\x12\x14\n" +
+	"\x05limit\x18\x01 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x05R\x06offset\"\xa2\x01\n" +
+	"\x11ListBreedsRequest\x123\n" +
+	"\x06filter\x18\x01 \x01(\v2\x1b.japhy.breed.v1.BreedFilterR\x06filter\x12(\n" +
+	"\x04sort\x18\x02 \x01(\v2\x14.japhy.breed.v1.SortR\x04sort\x12.\n" +
+	"\x04page\x18\x03 \x01(\v2\x1a.japhy.breed.v1.PaginationR\x04page\"!\n" +
+	"\x0fGetBreedRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"A\n" +
+	"\x12CreateBreedRequest\x12+\n" +
+	"\x05breed\x18\x01 \x01(\v2\x15.japhy.breed.v1.BreedR\x05breed\"Q\n" +
+	"\x12UpdateBreedRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12+\n" +
+	"\x05breed\x18\x02 \x01(\v2\x15.japhy.breed.v1.BreedR\x05breed\"$\n" +
+	"\x12DeleteBreedRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\x15\n" +
+	"\x13DeleteBreedResponse*|\n" +
+	"\tWeightSex\x12\x1a\n" +
+	"\x16WEIGHT_SEX_UNSPECIFIED\x10\x00\x12\x12\n" +
+	"\x0eWEIGHT_SEX_ANY\x10\x01\x12\x13\n" +
+	"\x0fWEIGHT_SEX_BOTH\x10\x02\x12\x13\n" +
+	"\x0fWEIGHT_SEX_MALE\x10\x03\x12\x15\n" +
+	"\x11WEIGHT_SEX_FEMALE\x10\x04*a\n" +
+	"\vWeightMatch\x12\x1c\n" +
+	"\x18WEIGHT_MATCH_UNSPECIFIED\x10\x00\x12\x1a\n" +
+	"\x16WEIGHT_MATCH_CONTAINED\x10\x01\x12\x18\n" +
+	"\x14WEIGHT_MATCH_OVERLAP\x10\x02*\x89\x01\n" +
+	"\tSortField\x12\x1a\n" +
+	"\x16SORT_FIELD_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fSORT_FIELD_NAME\x10\x01\x12\x11\n" +
+	"\rSORT_FIELD_ID\x10\x02\x12\x1a\n" +
+	"\x16SORT_FIELD_MALE_WEIGHT\x10\x03\x12\x1c\n" +
+	"\x18SORT_FIELD_FEMALE_WEIGHT\x10\x042\x88\x03\n" +
+	"\fBreedService\x12H\n" +
+	"\n" +
+	"ListBreeds\x12!.japhy.breed.v1.ListBreedsRequest\x1a\x15.japhy.breed.v1.Breed0\x01\x12B\n" +
+	"\bGetBreed\x12\x1f.japhy.breed.v1.GetBreedRequest\x1a\x15.japhy.breed.v1.Breed\x12H\n" +
+	"\vCreateBreed\x12\".japhy.breed.v1.CreateBreedRequest\x1a\x15.japhy.breed.v1.Breed\x12H\n" +
+	"\vUpdateBreed\x12\".japhy.breed.v1.UpdateBreedRequest\x1a\x15.japhy.breed.v1.Breed\x12V\n" +
+	"\vDeleteBreed\x12\".japhy.breed.v1.DeleteBreedRequest\x1a#.japhy.breed.v1.DeleteBreedResponseBEZCgithub.com/japhy-tech/backend-test/internal/grpcapi/breedv1;breedv1b\x06proto3"
+
+var (
+	file_breed_v1_breed_proto_rawDescOnce sync.Once
+	file_breed_v1_breed_proto_rawDescData []byte
+)
+
+func file_breed_v1_breed_proto_rawDescGZIP() []byte {
+	file_breed_v1_breed_proto_rawDescOnce.Do(func() {
+		file_breed_v1_breed_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_breed_v1_breed_proto_rawDesc), len(file_breed_v1_breed_proto_rawDesc)))
+	})
+	return file_breed_v1_breed_proto_rawDescData
+}
+
+var file_breed_v1_breed_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_breed_v1_breed_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_breed_v1_breed_proto_goTypes = []any{
+	(WeightSex)(0),              // 0: japhy.breed.v1.WeightSex
+	(WeightMatch)(0),            // 1: japhy.breed.v1.WeightMatch
+	(SortField)(0),              // 2: japhy.breed.v1.SortField
+	(*Breed)(nil),               // 3: japhy.breed.v1.Breed
+	(*WeightRange)(nil),         // 4: japhy.breed.v1.WeightRange
+	(*BreedFilter)(nil),         // 5: japhy.breed.v1.BreedFilter
+	(*Sort)(nil),                // 6: japhy.breed.v1.Sort
+	(*Pagination)(nil),          // 7: japhy.breed.v1.Pagination
+	(*ListBreedsRequest)(nil),   // 8: japhy.breed.v1.ListBreedsRequest
+	(*GetBreedRequest)(nil),     // 9: japhy.breed.v1.GetBreedRequest
+	(*CreateBreedRequest)(nil),  // 10: japhy.breed.v1.CreateBreedRequest
+	(*UpdateBreedRequest)(nil),  // 11: japhy.breed.v1.UpdateBreedRequest
+	(*DeleteBreedRequest)(nil),  // 12: japhy.breed.v1.DeleteBreedRequest
+	(*DeleteBreedResponse)(nil), // 13: japhy.breed.v1.DeleteBreedResponse
+}
+var file_breed_v1_breed_proto_depIdxs = []int32{
+	4,  // 0: japhy.breed.v1.BreedFilter.weight:type_name -> japhy.breed.v1.WeightRange
+	0,  // 1: japhy.breed.v1.BreedFilter.sex:type_name -> japhy.breed.v1.WeightSex
+	1,  // 2: japhy.breed.v1.BreedFilter.match:type_name -> japhy.breed.v1.WeightMatch
+	4,  // 3: japhy.breed.v1.BreedFilter.male_weight:type_name -> japhy.breed.v1.WeightRange
+	4,  // 4: japhy.breed.v1.BreedFilter.female_weight:type_name -> japhy.breed.v1.WeightRange
+	2,  // 5: japhy.breed.v1.Sort.field:type_name -> japhy.breed.v1.SortField
+	5,  // 6: japhy.breed.v1.ListBreedsRequest.filter:type_name -> japhy.breed.v1.BreedFilter
+	6,  // 7: japhy.breed.v1.ListBreedsRequest.sort:type_name -> japhy.breed.v1.Sort
+	7,  // 8: japhy.breed.v1.ListBreedsRequest.page:type_name -> japhy.breed.v1.Pagination
+	3,  // 9: japhy.breed.v1.CreateBreedRequest.breed:type_name -> japhy.breed.v1.Breed
+	3,  // 10: japhy.breed.v1.UpdateBreedRequest.breed:type_name -> japhy.breed.v1.Breed
+	8,  // 11: japhy.breed.v1.BreedService.ListBreeds:input_type -> japhy.breed.v1.ListBreedsRequest
+	9,  // 12: japhy.breed.v1.BreedService.GetBreed:input_type -> japhy.breed.v1.GetBreedRequest
+	10, // 13: japhy.breed.v1.BreedService.CreateBreed:input_type -> japhy.breed.v1.CreateBreedRequest
+	11, // 14: japhy.breed.v1.BreedService.UpdateBreed:input_type -> japhy.breed.v1.UpdateBreedRequest
+	12, // 15: japhy.breed.v1.BreedService.DeleteBreed:input_type -> japhy.breed.v1.DeleteBreedRequest
+	3,  // 16: japhy.breed.v1.BreedService.ListBreeds:output_type -> japhy.breed.v1.Breed
+	3,  // 17: japhy.breed.v1.BreedService.GetBreed:output_type -> japhy.breed.v1.Breed
+	3,  // 18: japhy.breed.v1.BreedService.CreateBreed:output_type -> japhy.breed.v1.Breed
+	3,  // 19: japhy.breed.v1.BreedService.UpdateBreed:output_type -> japhy.breed.v1.Breed
+	13, // 20: japhy.breed.v1.BreedService.DeleteBreed:output_type -> japhy.breed.v1.DeleteBreedResponse
+	16, // [16:21] is the sub-list for method output_type
+	11, // [11:16] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
+}
+
+func init() { file_breed_v1_breed_proto_init() }
+func file_breed_v1_breed_proto_init() {
+	if File_breed_v1_breed_proto != nil {
+		return
+	}
+	file_breed_v1_breed_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_breed_v1_breed_proto_rawDesc), len(file_breed_v1_breed_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   11,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_breed_v1_breed_proto_goTypes,
+		DependencyIndexes: file_breed_v1_breed_proto_depIdxs,
+		EnumInfos:         file_breed_v1_breed_proto_enumTypes,
+		MessageInfos:      file_breed_v1_breed_proto_msgTypes,
+	}.Build()
+	File_breed_v1_breed_proto = out.File
+	file_breed_v1_breed_proto_goTypes = nil
+	file_breed_v1_breed_proto_depIdxs = nil
+}
@@ -0,0 +1,282 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: breed/v1/breed.proto
+
+// API gRPC des races, pour les consommateurs internes (service de
+// recommandation). Les poids sont toujours exprimés en grammes.
+
+package breedv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	BreedService_ListBreeds_FullMethodName  = "/japhy.breed.v1.BreedService/ListBreeds"
+	BreedService_GetBreed_FullMethodName    = "/japhy.breed.v1.BreedService/GetBreed"
+	BreedService_CreateBreed_FullMethodName = "/japhy.breed.v1.BreedService/CreateBreed"
+	BreedService_UpdateBreed_FullMethodName = "/japhy.breed.v1.BreedService/UpdateBreed"
+	BreedService_DeleteBreed_FullMethodName = "/japhy.breed.v1.BreedService/DeleteBreed"
+)
+
+// BreedServiceClient is the client API for BreedService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BreedServiceClient interface {
+	// ListBreeds envoie les races une à une, dans l'ordre demandé
+	ListBreeds(ctx context.Context, in *ListBreedsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Breed], error)
+	GetBreed(ctx context.Context, in *GetBreedRequest, opts ...grpc.CallOption) (*Breed, error)
+	CreateBreed(ctx context.Context, in *CreateBreedRequest, opts ...grpc.CallOption) (*Breed, error)
+	UpdateBreed(ctx context.Context, in *UpdateBreedRequest, opts ...grpc.CallOption) (*Breed, error)
+	DeleteBreed(ctx context.Context, in *DeleteBreedRequest, opts ...grpc.CallOption) (*DeleteBreedResponse, error)
+}
+
+type breedServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBreedServiceClient(cc grpc.ClientConnInterface) BreedServiceClient {
+	return &breedServiceClient{cc}
+}
+
+func (c *breedServiceClient) ListBreeds(ctx context.Context, in *ListBreedsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[Breed], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &BreedService_ServiceDesc.Streams[0], BreedService_ListBreeds_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ListBreedsRequest, Breed]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BreedService_ListBreedsClient = grpc.ServerStreamingClient[Breed]
+
+func (c *breedServiceClient) GetBreed(ctx context.Context, in *GetBreedRequest, opts ...grpc.CallOption) (*Breed, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Breed)
+	err := c.cc.Invoke(ctx, BreedService_GetBreed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *breedServiceClient) CreateBreed(ctx context.Context, in *CreateBreedRequest, opts ...grpc.CallOption) (*Breed, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Breed)
+	err := c.cc.Invoke(ctx, BreedService_CreateBreed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *breedServiceClient) UpdateBreed(ctx context.Context, in *UpdateBreedRequest, opts ...grpc.CallOption) (*Breed, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Breed)
+	err := c.cc.Invoke(ctx, BreedService_UpdateBreed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *breedServiceClient) DeleteBreed(ctx context.Context, in *DeleteBreedRequest, opts ...grpc.CallOption) (*DeleteBreedResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteBreedResponse)
+	err := c.cc.Invoke(ctx, BreedService_DeleteBreed_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BreedServiceServer is the server API for BreedService service.
+// All implementations must embed UnimplementedBreedServiceServer
+// for forward compatibility.
+type BreedServiceServer interface {
+	// ListBreeds envoie les races une à une, dans l'ordre demandé
+	ListBreeds(*ListBreedsRequest, grpc.ServerStreamingServer[Breed]) error
+	GetBreed(context.Context, *GetBreedRequest) (*Breed, error)
+	CreateBreed(context.Context, *CreateBreedRequest) (*Breed, error)
+	UpdateBreed(context.Context, *UpdateBreedRequest) (*Breed, error)
+	DeleteBreed(context.Context, *DeleteBreedRequest) (*DeleteBreedResponse, error)
+	mustEmbedUnimplementedBreedServiceServer()
+}
+
+// UnimplementedBreedServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedBreedServiceServer struct{}
+
+func (UnimplementedBreedServiceServer) ListBreeds(*ListBreedsRequest, grpc.ServerStreamingServer[Breed]) error {
+	return status.Errorf(codes.Unimplemented, "method ListBreeds not implemented")
+}
+func (UnimplementedBreedServiceServer) GetBreed(context.Context, *GetBreedRequest) (*Breed, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBreed not implemented")
+}
+func (UnimplementedBreedServiceServer) CreateBreed(context.Context, *CreateBreedRequest) (*Breed, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateBreed not implemented")
+}
+func (UnimplementedBreedServiceServer) UpdateBreed(context.Context, *UpdateBreedRequest) (*Breed, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateBreed not implemented")
+}
+func (UnimplementedBreedServiceServer) DeleteBreed(context.Context, *DeleteBreedRequest) (*DeleteBreedResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteBreed not implemented")
+}
+func (UnimplementedBreedServiceServer) mustEmbedUnimplementedBreedServiceServer() {}
+func (UnimplementedBreedServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeBreedServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BreedServiceServer will
+// result in compilation errors.
+type UnsafeBreedServiceServer interface {
+	mustEmbedUnimplementedBreedServiceServer()
+}
+
+func RegisterBreedServiceServer(s grpc.ServiceRegistrar, srv BreedServiceServer) {
+	// If the following call pancis, it indicates UnimplementedBreedServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&BreedService_ServiceDesc, srv)
+}
+
+func _BreedService_ListBreeds_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ListBreedsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(BreedServiceServer).ListBreeds(m, &grpc.GenericServerStream[ListBreedsRequest, Breed]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type BreedService_ListBreedsServer = grpc.ServerStreamingServer[Breed]
+
+func _BreedService_GetBreed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBreedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BreedServiceServer).GetBreed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BreedService_GetBreed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BreedServiceServer).GetBreed(ctx, req.(*GetBreedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BreedService_CreateBreed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBreedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BreedServiceServer).CreateBreed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BreedService_CreateBreed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BreedServiceServer).CreateBreed(ctx, req.(*CreateBreedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BreedService_UpdateBreed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateBreedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BreedServiceServer).UpdateBreed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BreedService_UpdateBreed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BreedServiceServer).UpdateBreed(ctx, req.(*UpdateBreedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _BreedService_DeleteBreed_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteBreedRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BreedServiceServer).DeleteBreed(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: BreedService_DeleteBreed_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BreedServiceServer).DeleteBreed(ctx, req.(*DeleteBreedRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BreedService_ServiceDesc is the grpc.ServiceDesc for BreedService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BreedService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "japhy.breed.v1.BreedService",
+	HandlerType: (*BreedServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetBreed",
+			Handler:    _BreedService_GetBreed_Handler,
+		},
+		{
+			MethodName: "CreateBreed",
+			Handler:    _BreedService_CreateBreed_Handler,
+		},
+		{
+			MethodName: "UpdateBreed",
+			Handler:    _BreedService_UpdateBreed_Handler,
+		},
+		{
+			MethodName: "DeleteBreed",
+			Handler:    _BreedService_DeleteBreed_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ListBreeds",
+			Handler:       _BreedService_ListBreeds_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "breed/v1/breed.proto",
+}
@@ -0,0 +1,90 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+
+	"github.com/japhy-tech/backend-test/internal/i18n"
+	"github.com/japhy-tech/backend-test/internal/problem"
+	"github.com/japhy-tech/backend-test/internal/requestid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// errorDomain accompagne le code stable de l'API dans google.rpc.ErrorInfo
+const errorDomain = "breeds.japhy"
+
+// statusCodes associe les codes stables de l'API aux codes gRPC
+var statusCodes = map[problem.Code]codes.Code{
+	problem.CodeValidationFailed: codes.InvalidArgument,
+	problem.CodeBreedNotFound:    codes.NotFound,
+	problem.CodeDuplicateName:    codes.AlreadyExists,
+	problem.CodeConflict:         codes.FailedPrecondition,
+	problem.CodeUnauthenticated:  codes.Unauthenticated,
+	problem.CodeForbidden:        codes.PermissionDenied,
+	problem.CodeInternal:         codes.Internal,
+}
+
+// toStatus traduit une erreur de validation ou de repository comme le ferait
+// l'API REST : le message est traduit selon la métadonnée accept-language,
+// le code stable est porté par un ErrorInfo et le détail par champ par un
+// BadRequest ; toute autre erreur est journalisée et masquée
+func (s *Server) toStatus(ctx context.Context, err error, keyvals ...interface{}) error {
+	var details *problem.Details
+	var errs problem.FieldErrors
+	if !errors.As(err, &errs) {
+		errs = problem.FromValidationError(err)
+	}
+	if errs != nil {
+		details = problem.Validation(errs)
+	} else {
+		details = problem.FromRepositoryError(err, problem.CodeBreedNotFound)
+	}
+	if details == nil {
+		keyvals = append(keyvals, "error", err, "request_id", requestid.FromContext(ctx))
+		s.logger.Error("Erreur lors d'un appel gRPC", keyvals...)
+		details = problem.Internal()
+	}
+
+	return statusFrom(ctx, details)
+}
+
+// statusFrom construit le statut gRPC équivalent à un problème de l'API REST
+func statusFrom(ctx context.Context, details *problem.Details) error {
+	details.Translate(languageFrom(ctx))
+	message := details.Detail
+	if message == "" {
+		message = details.Title
+	}
+
+	st := status.New(statusCodes[details.Code], message)
+	info := &errdetails.ErrorInfo{Reason: string(details.Code), Domain: errorDomain}
+	if id := requestid.FromContext(ctx); id != "" {
+		info.Metadata = map[string]string{"request_id": id}
+	}
+	badRequest := &errdetails.BadRequest{}
+	for _, fieldError := range details.Errors {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       fieldError.Field,
+			Description: fieldError.Message,
+		})
+	}
+
+	withDetails, detailsErr := st.WithDetails(info, badRequest)
+	if detailsErr != nil {
+		return st.Err()
+	}
+	return withDetails.Err()
+}
+
+// languageFrom négocie la langue des messages comme l'en-tête Accept-Language
+func languageFrom(ctx context.Context) i18n.Lang {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("accept-language")
+	if len(values) == 0 {
+		return i18n.Default
+	}
+	return i18n.Negotiate(values[0])
+}
@@ -0,0 +1,56 @@
+package grpcapi
+
+import (
+	"context"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/auth"
+	"github.com/japhy-tech/backend-test/internal/requestid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// requestIDKey est la métadonnée équivalente à l'en-tête X-Request-ID
+const requestIDKey = "x-request-id"
+
+// ServerOptions retourne les options du serveur gRPC : chaque appel reçoit
+// un identifiant de requête, repris de la métadonnée x-request-id ou généré
+// et renvoyé dans les en-têtes de la réponse, puis l'appelant est
+// authentifié et son rôle contrôlé pour les méthodes du service des races
+func ServerOptions(authenticator *auth.Authenticator, logger *charmLog.Logger) []grpc.ServerOption {
+	return []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+			ctx, err := authorize(withRequestID(ctx), info.FullMethod, authenticator, logger)
+			if err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}),
+		grpc.ChainStreamInterceptor(func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+			ctx, err := authorize(withRequestID(stream.Context()), info.FullMethod, authenticator, logger)
+			if err != nil {
+				return err
+			}
+			return handler(srv, &requestIDStream{ServerStream: stream, ctx: ctx})
+		}),
+	}
+}
+
+func withRequestID(ctx context.Context) context.Context {
+	md, _ := metadata.FromIncomingContext(ctx)
+	id := requestid.Sanitize(first(md, requestIDKey))
+
+	grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, id))
+	return requestid.WithID(ctx, id)
+}
+
+// requestIDStream remplace le contexte d'un flux par celui portant
+// l'identifiant de requête et l'appelant
+type requestIDStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *requestIDStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,253 @@
+// Package grpcapi expose le modèle des races en gRPC, sur un port séparé du
+// routeur HTTP et sur les mêmes repositories et services
+package grpcapi
+
+//go:generate protoc -I ../../proto --go_out=../.. --go_opt=module=github.com/japhy-tech/backend-test --go-grpc_out=../.. --go-grpc_opt=module=github.com/japhy-tech/backend-test breed/v1/breed.proto
+
+import (
+	"context"
+	"strconv"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/grpcapi/breedv1"
+	"github.com/japhy-tech/backend-test/internal/handlers"
+	"github.com/japhy-tech/backend-test/internal/i18n"
+	"github.com/japhy-tech/backend-test/internal/problem"
+	"github.com/japhy-tech/backend-test/internal/repository"
+	"github.com/japhy-tech/backend-test/internal/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+)
+
+// Server implémente breedv1.BreedService : lectures sur le repository,
+// écritures via service.BreedService comme l'API REST
+type Server struct {
+	breedv1.UnimplementedBreedServiceServer
+
+	repo     repository.BreedRepositoryInterface
+	writer   *service.BreedService
+	maxLimit int
+	logger   *charmLog.Logger
+	health   *health.Server
+}
+
+// NewServer crée le service ; maxLimit borne page.limit comme le paramètre
+// limit de GET /breeds
+func NewServer(repo repository.BreedRepositoryInterface, references handlers.ReferenceProvider, sizes handlers.SizeClassifierProvider, maxLimit int, logger *charmLog.Logger) *Server {
+	return &Server{
+		repo:     repo,
+		writer:   service.NewBreedService(repo, references, sizes),
+		maxLimit: maxLimit,
+		logger:   logger,
+		health:   health.NewServer(),
+	}
+}
+
+// Register enregistre le service des races, le service de santé standard
+// (grpc.health.v1) et la réflexion, pour grpcurl et les clients génériques
+func (s *Server) Register(gs *grpc.Server) {
+	breedv1.RegisterBreedServiceServer(gs, s)
+
+	s.health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	s.health.SetServingStatus(breedv1.BreedService_ServiceDesc.ServiceName, healthpb.HealthCheckResponse_SERVING)
+	healthpb.RegisterHealthServer(gs, s.health)
+
+	reflection.Register(gs)
+}
+
+// Shutdown annonce NOT_SERVING aux clients du service de santé, pour qu'ils
+// se détournent de l'instance pendant son arrêt
+func (s *Server) Shutdown() {
+	s.health.Shutdown()
+}
+
+// ListBreeds envoie les races filtrées une à une
+func (s *Server) ListBreeds(req *breedv1.ListBreedsRequest, stream grpc.ServerStreamingServer[breedv1.Breed]) error {
+	ctx := stream.Context()
+
+	filter, errs := s.breedFilter(req)
+	if len(errs) > 0 {
+		return s.toStatus(ctx, errs)
+	}
+
+	breeds, err := s.repo.GetAll(ctx, filter)
+	if err != nil {
+		return s.toStatus(ctx, err)
+	}
+
+	for i := range breeds {
+		if err := stream.Send(toProto(&breeds[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// breedFilter traduit la requête en BreedFilter, avec les mêmes bornes que
+// les paramètres de GET /breeds
+func (s *Server) breedFilter(req *breedv1.ListBreedsRequest) (repository.BreedFilter, problem.FieldErrors) {
+	var errs problem.FieldErrors
+
+	f := req.GetFilter()
+	filter := repository.BreedFilter{
+		Species:      f.GetSpecies(),
+		PetSize:      f.GetPetSize(),
+		Name:         service.NormalizeAlias(f.GetName()),
+		Weight:       weightRange(f.GetWeight(), "filter.weight", &errs),
+		Sex:          weightSexes[f.GetSex()],
+		Match:        weightMatches[f.GetMatch()],
+		MaleWeight:   weightRange(f.GetMaleWeight(), "filter.male_weight", &errs),
+		FemaleWeight: weightRange(f.GetFemaleWeight(), "filter.female_weight", &errs),
+		Sort:         sortFields[req.GetSort().GetField()],
+		Desc:         req.GetSort().GetDescending(),
+		Limit:        int(req.GetPage().GetLimit()),
+		Offset:       int(req.GetPage().GetOffset()),
+	}
+
+	if filter.Limit < 0 {
+		errs.Add("page.limit", strconv.Itoa(filter.Limit), i18n.MinBound, "0")
+	}
+	if filter.Limit > s.maxLimit {
+		errs.Add("page.limit", strconv.Itoa(filter.Limit), i18n.MaxBound, strconv.Itoa(s.maxLimit))
+	}
+	if filter.Offset < 0 {
+		errs.Add("page.offset", strconv.Itoa(filter.Offset), i18n.MinBound, "0")
+	}
+
+	return filter, errs
+}
+
+var weightSexes = map[breedv1.WeightSex]repository.WeightSex{
+	breedv1.WeightSex_WEIGHT_SEX_UNSPECIFIED: repository.SexAny,
+	breedv1.WeightSex_WEIGHT_SEX_ANY:         repository.SexAny,
+	breedv1.WeightSex_WEIGHT_SEX_BOTH:        repository.SexBoth,
+	breedv1.WeightSex_WEIGHT_SEX_MALE:        repository.SexMale,
+	breedv1.WeightSex_WEIGHT_SEX_FEMALE:      repository.SexFemale,
+}
+
+var weightMatches = map[breedv1.WeightMatch]repository.WeightMatch{
+	breedv1.WeightMatch_WEIGHT_MATCH_UNSPECIFIED: repository.MatchContained,
+	breedv1.WeightMatch_WEIGHT_MATCH_CONTAINED:   repository.MatchContained,
+	breedv1.WeightMatch_WEIGHT_MATCH_OVERLAP:     repository.MatchOverlap,
+}
+
+var sortFields = map[breedv1.SortField]repository.SortField{
+	breedv1.SortField_SORT_FIELD_UNSPECIFIED:   repository.SortByName,
+	breedv1.SortField_SORT_FIELD_NAME:          repository.SortByName,
+	breedv1.SortField_SORT_FIELD_ID:            repository.SortByID,
+	breedv1.SortField_SORT_FIELD_MALE_WEIGHT:   repository.SortByMaleWeight,
+	breedv1.SortField_SORT_FIELD_FEMALE_WEIGHT: repository.SortByFemaleWeight,
+}
+
+func weightRange(wr *breedv1.WeightRange, field string, errs *problem.FieldErrors) repository.WeightRange {
+	var r repository.WeightRange
+	if wr == nil {
+		return r
+	}
+	if wr.Min != nil {
+		if *wr.Min < 0 {
+			errs.Add(field+".min", strconv.Itoa(int(*wr.Min)), i18n.MinBound, "0")
+		}
+		lo := int(*wr.Min)
+		r.Min = &lo
+	}
+	if wr.Max != nil {
+		if *wr.Max < 0 {
+			errs.Add(field+".max", strconv.Itoa(int(*wr.Max)), i18n.MinBound, "0")
+		}
+		hi := int(*wr.Max)
+		r.Max = &hi
+	}
+	return r
+}
+
+func (s *Server) GetBreed(ctx context.Context, req *breedv1.GetBreedRequest) (*breedv1.Breed, error) {
+	breed, err := s.repo.GetByID(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "id", req.GetId())
+	}
+	return toProto(breed), nil
+}
+
+func (s *Server) CreateBreed(ctx context.Context, req *breedv1.CreateBreedRequest) (*breedv1.Breed, error) {
+	breed := fromProto(req.GetBreed())
+
+	// Mêmes règles que POST /breeds ; pet_size peut être omis
+	var errs problem.FieldErrors
+	if breed.Species == "" {
+		errs.Add("breed.species", "", i18n.FieldRequired)
+	}
+	if breed.Name == "" {
+		errs.Add("breed.name", "", i18n.FieldRequired)
+	}
+	if breed.AverageMaleAdultWeight <= 0 {
+		errs.Add("breed.average_male_adult_weight", "", i18n.MustBePositive)
+	}
+	if breed.AverageFemaleAdultWeight <= 0 {
+		errs.Add("breed.average_female_adult_weight", "", i18n.MustBePositive)
+	}
+	if len(errs) > 0 {
+		return nil, s.toStatus(ctx, errs)
+	}
+
+	created, err := s.writer.Create(ctx, breed)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "breed", breed.Name)
+	}
+	return toProto(created), nil
+}
+
+func (s *Server) UpdateBreed(ctx context.Context, req *breedv1.UpdateBreedRequest) (*breedv1.Breed, error) {
+	changes := fromProto(req.GetBreed())
+
+	var errs problem.FieldErrors
+	if changes.AverageMaleAdultWeight < 0 {
+		errs.Add("breed.average_male_adult_weight", "", i18n.MustBePositive)
+	}
+	if changes.AverageFemaleAdultWeight < 0 {
+		errs.Add("breed.average_female_adult_weight", "", i18n.MustBePositive)
+	}
+	if *changes == (repository.Breed{}) {
+		errs.Add("breed", "", i18n.NothingToUpdate)
+	}
+	if len(errs) > 0 {
+		return nil, s.toStatus(ctx, errs)
+	}
+
+	updated, err := s.writer.Update(ctx, int(req.GetId()), changes)
+	if err != nil {
+		return nil, s.toStatus(ctx, err, "id", req.GetId())
+	}
+	return toProto(updated), nil
+}
+
+func (s *Server) DeleteBreed(ctx context.Context, req *breedv1.DeleteBreedRequest) (*breedv1.DeleteBreedResponse, error) {
+	if err := s.repo.Delete(ctx, int(req.GetId())); err != nil {
+		return nil, s.toStatus(ctx, err, "id", req.GetId())
+	}
+	return &breedv1.DeleteBreedResponse{}, nil
+}
+
+func toProto(breed *repository.Breed) *breedv1.Breed {
+	return &breedv1.Breed{
+		Id:                       int64(breed.ID),
+		Species:                  breed.Species,
+		PetSize:                  breed.PetSize,
+		Name:                     breed.Name,
+		AverageMaleAdultWeight:   int32(breed.AverageMaleAdultWeight),
+		AverageFemaleAdultWeight: int32(breed.AverageFemaleAdultWeight),
+	}
+}
+
+// fromProto ignore l'id, porté par la requête pour une mise à jour
+func fromProto(breed *breedv1.Breed) *repository.Breed {
+	return &repository.Breed{
+		Species:                  breed.GetSpecies(),
+		PetSize:                  breed.GetPetSize(),
+		Name:                     breed.GetName(),
+		AverageMaleAdultWeight:   int(breed.GetAverageMaleAdultWeight()),
+		AverageFemaleAdultWeight: int(breed.GetAverageFemaleAdultWeight()),
+	}
+}
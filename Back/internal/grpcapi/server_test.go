@@ -0,0 +1,298 @@
+package grpcapi
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	"github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/auth"
+	"github.com/japhy-tech/backend-test/internal/grpcapi/breedv1"
+	"github.com/japhy-tech/backend-test/internal/repository"
+	"github.com/japhy-tech/backend-test/internal/service"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type mockBreedRepo struct {
+	lastFilter repository.BreedFilter
+	created    *repository.Breed
+}
+
+func (m *mockBreedRepo) GetAll(ctx context.Context, filter repository.BreedFilter) ([]repository.Breed, error) {
+	m.lastFilter = filter
+	return []repository.Breed{
+		{ID: 1, Species: "dog", PetSize: "medium", Name: "border_collie", AverageMaleAdultWeight: 20000, AverageFemaleAdultWeight: 18000},
+		{ID: 2, Species: "dog", PetSize: "small", Name: "beagle", AverageMaleAdultWeight: 9000, AverageFemaleAdultWeight: 8000},
+	}, nil
+}
+func (m *mockBreedRepo) GetByID(ctx context.Context, id int) (*repository.Breed, error) {
+	if id == 1 {
+		return &repository.Breed{ID: 1, Species: "dog", PetSize: "medium", Name: "border_collie", AverageMaleAdultWeight: 20000, AverageFemaleAdultWeight: 18000}, nil
+	}
+	return nil, &repository.Error{Kind: repository.ErrNotFound, Entity: "breed"}
+}
+func (m *mockBreedRepo) Resolve(ctx context.Context, name string) (*repository.Breed, error) {
+	return nil, repository.ErrNotFound
+}
+func (m *mockBreedRepo) Create(ctx context.Context, breed *repository.Breed) (*repository.Breed, error) {
+	if breed.Name == "beagle" {
+		return nil, &repository.Error{Kind: repository.ErrDuplicateName, Entity: "breed"}
+	}
+	m.created = breed
+	breed.ID = 12
+	return breed, nil
+}
+func (m *mockBreedRepo) Update(ctx context.Context, id int, breed *repository.Breed) (*repository.Breed, error) {
+	return breed, nil
+}
+func (m *mockBreedRepo) Delete(ctx context.Context, id int) error {
+	return errors.New("connexion perdue")
+}
+func (m *mockBreedRepo) ImportFromCSV(ctx context.Context, breeds []repository.Breed) (repository.ImportStats, error) {
+	return repository.ImportStats{}, nil
+}
+
+// mockKeys connaît une clé par rôle, la clé valant "key-" suivi du rôle
+type mockKeys struct{}
+
+func (m *mockKeys) FindActiveByHash(hash string) (*repository.APIKey, error) {
+	for i, role := range auth.Roles {
+		if hash == auth.HashKey("key-"+string(role)) {
+			return &repository.APIKey{ID: i + 1, Name: string(role), Role: string(role)}, nil
+		}
+	}
+	return nil, repository.ErrNotFound
+}
+
+// as retourne un contexte d'appel authentifié par la clé d'un rôle
+func as(role auth.Role) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", "key-"+string(role))
+}
+
+type mockReferences struct{}
+
+func (m *mockReferences) Snapshot() (*service.ReferenceSet, error) {
+	return &service.ReferenceSet{Species: []string{"cat", "dog"}, PetSizes: []string{"medium", "small", "tall"}}, nil
+}
+
+type mockSizes struct{}
+
+func (m *mockSizes) Snapshot() (*service.SizeClassification, error) {
+	small, medium := 10000, 25000
+	return service.NewSizeClassification([]repository.SizeThreshold{
+		{Species: "dog", PetSize: "small", MaxWeight: &small},
+		{Species: "dog", PetSize: "medium", MaxWeight: &medium},
+		{Species: "dog", PetSize: "tall"},
+	}, false), nil
+}
+
+// newTestClient sert le service sur un listener en mémoire (bufconn)
+func newTestClient(t *testing.T, repo *mockBreedRepo) *grpc.ClientConn {
+	t.Helper()
+
+	listener := bufconn.Listen(1 << 20)
+	logger := log.NewWithOptions(io.Discard, log.Options{})
+	server := grpc.NewServer(ServerOptions(auth.NewAuthenticator(&mockKeys{}, auth.JWTConfig{}), logger)...)
+	NewServer(repo, &mockReferences{}, &mockSizes{}, 100, logger).Register(server)
+	go server.Serve(listener)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("Erreur lors de la connexion: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestListBreeds_Streams(t *testing.T) {
+	repo := &mockBreedRepo{}
+	client := breedv1.NewBreedServiceClient(newTestClient(t, repo))
+
+	min := int32(5000)
+	stream, err := client.ListBreeds(as(auth.RoleReader), &breedv1.ListBreedsRequest{
+		Filter: &breedv1.BreedFilter{Species: "dog", Name: "Border Collie", Weight: &breedv1.WeightRange{Min: &min}, Sex: breedv1.WeightSex_WEIGHT_SEX_BOTH},
+		Sort:   &breedv1.Sort{Field: breedv1.SortField_SORT_FIELD_MALE_WEIGHT, Descending: true},
+		Page:   &breedv1.Pagination{Limit: 10, Offset: 5},
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	var names []string
+	for {
+		breed, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+		names = append(names, breed.GetName())
+	}
+
+	if len(names) != 2 || names[0] != "border_collie" {
+		t.Errorf("races inattendues: %v", names)
+	}
+	filter := repo.lastFilter
+	if filter.Name != "border_collie" || filter.Weight.Min == nil || *filter.Weight.Min != 5000 || filter.Sex != repository.SexBoth {
+		t.Errorf("filtre inattendu: %+v", filter)
+	}
+	if filter.Sort != repository.SortByMaleWeight || !filter.Desc || filter.Limit != 10 || filter.Offset != 5 {
+		t.Errorf("tri ou pagination inattendus: %+v", filter)
+	}
+}
+
+func TestListBreeds_InvalidPage(t *testing.T) {
+	client := breedv1.NewBreedServiceClient(newTestClient(t, &mockBreedRepo{}))
+
+	stream, err := client.ListBreeds(as(auth.RoleReader), &breedv1.ListBreedsRequest{Page: &breedv1.Pagination{Limit: 1000}})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+
+	st := status.Convert(err)
+	if st.Code() != codes.InvalidArgument {
+		t.Fatalf("attendu InvalidArgument, obtenu %v", st)
+	}
+	for _, detail := range st.Details() {
+		if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+			if len(badRequest.FieldViolations) != 1 || badRequest.FieldViolations[0].Field != "page.limit" {
+				t.Errorf("violations inattendues: %v", badRequest.FieldViolations)
+			}
+			return
+		}
+	}
+	t.Error("détail BadRequest absent")
+}
+
+func TestGetBreed(t *testing.T) {
+	client := breedv1.NewBreedServiceClient(newTestClient(t, &mockBreedRepo{}))
+
+	breed, err := client.GetBreed(as(auth.RoleReader), &breedv1.GetBreedRequest{Id: 1})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if breed.GetName() != "border_collie" || breed.GetAverageMaleAdultWeight() != 20000 {
+		t.Errorf("race inattendue: %v", breed)
+	}
+
+	_, err = client.GetBreed(as(auth.RoleReader), &breedv1.GetBreedRequest{Id: 999})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("attendu NotFound, obtenu %v", err)
+	}
+}
+
+func TestCreateBreed(t *testing.T) {
+	repo := &mockBreedRepo{}
+	client := breedv1.NewBreedServiceClient(newTestClient(t, repo))
+
+	breed, err := client.CreateBreed(as(auth.RoleEditor), &breedv1.CreateBreedRequest{Breed: &breedv1.Breed{
+		Species: "Dog", Name: "border_collie", AverageMaleAdultWeight: 20000, AverageFemaleAdultWeight: 18000,
+	}})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if breed.GetId() != 12 || breed.GetSpecies() != "dog" || breed.GetPetSize() != "medium" {
+		t.Errorf("race créée inattendue: %v", breed)
+	}
+}
+
+func TestCreateBreed_Errors(t *testing.T) {
+	client := breedv1.NewBreedServiceClient(newTestClient(t, &mockBreedRepo{}))
+	ctx := metadata.AppendToOutgoingContext(as(auth.RoleEditor), "accept-language", "en", "x-request-id", "req-42")
+
+	_, err := client.CreateBreed(ctx, &breedv1.CreateBreedRequest{Breed: &breedv1.Breed{Species: "dog"}})
+	if st := status.Convert(err); st.Code() != codes.InvalidArgument || st.Message() == "" {
+		t.Errorf("attendu InvalidArgument, obtenu %v", st)
+	}
+
+	_, err = client.CreateBreed(ctx, &breedv1.CreateBreedRequest{Breed: &breedv1.Breed{
+		Species: "dog", Name: "beagle", AverageMaleAdultWeight: 9000, AverageFemaleAdultWeight: 8000,
+	}})
+	st := status.Convert(err)
+	if st.Code() != codes.AlreadyExists {
+		t.Fatalf("attendu AlreadyExists, obtenu %v", st)
+	}
+	info, ok := st.Details()[0].(*errdetails.ErrorInfo)
+	if !ok || info.Reason != "duplicate_name" || info.Metadata["request_id"] != "req-42" {
+		t.Errorf("ErrorInfo inattendu: %v", st.Details())
+	}
+}
+
+func TestDeleteBreed_InternalErrorIsHidden(t *testing.T) {
+	client := breedv1.NewBreedServiceClient(newTestClient(t, &mockBreedRepo{}))
+
+	_, err := client.DeleteBreed(as(auth.RoleAdmin), &breedv1.DeleteBreedRequest{Id: 1})
+	st := status.Convert(err)
+	if st.Code() != codes.Internal {
+		t.Fatalf("attendu Internal, obtenu %v", st)
+	}
+	if st.Message() == "connexion perdue" {
+		t.Error("l'erreur interne ne doit pas être exposée")
+	}
+}
+
+func TestHealth(t *testing.T) {
+	conn := newTestClient(t, &mockBreedRepo{})
+
+	resp, err := healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{Service: breedv1.BreedService_ServiceDesc.ServiceName})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_SERVING {
+		t.Errorf("attendu SERVING, obtenu %v", resp.GetStatus())
+	}
+}
+
+func TestHealth_Shutdown(t *testing.T) {
+	server := NewServer(&mockBreedRepo{}, &mockReferences{}, &mockSizes{}, 100, log.NewWithOptions(io.Discard, log.Options{}))
+	server.Register(grpc.NewServer())
+	server.Shutdown()
+
+	resp, err := server.health.Check(context.Background(), &healthpb.HealthCheckRequest{Service: breedv1.BreedService_ServiceDesc.ServiceName})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if resp.GetStatus() != healthpb.HealthCheckResponse_NOT_SERVING {
+		t.Errorf("attendu NOT_SERVING pendant l'arrêt, obtenu %v", resp.GetStatus())
+	}
+}
+
+func TestAuthorization(t *testing.T) {
+	client := breedv1.NewBreedServiceClient(newTestClient(t, &mockBreedRepo{}))
+
+	_, err := client.GetBreed(context.Background(), &breedv1.GetBreedRequest{Id: 1})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("attendu Unauthenticated sans clé, obtenu %v", err)
+	}
+
+	unknown := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer key-unknown")
+	_, err = client.GetBreed(unknown, &breedv1.GetBreedRequest{Id: 1})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Errorf("attendu Unauthenticated avec une clé inconnue, obtenu %v", err)
+	}
+
+	_, err = client.DeleteBreed(as(auth.RoleEditor), &breedv1.DeleteBreedRequest{Id: 1})
+	st := status.Convert(err)
+	if st.Code() != codes.PermissionDenied {
+		t.Fatalf("attendu PermissionDenied pour un editor, obtenu %v", st)
+	}
+	if info, ok := st.Details()[0].(*errdetails.ErrorInfo); !ok || info.Reason != "forbidden" {
+		t.Errorf("ErrorInfo inattendu: %v", st.Details())
+	}
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/auth"
+	"github.com/japhy-tech/backend-test/internal/i18n"
+	"github.com/japhy-tech/backend-test/internal/logging"
+	"github.com/japhy-tech/backend-test/internal/problem"
+	"github.com/japhy-tech/backend-test/internal/repository"
+)
+
+// maxKeyNameLength correspond à la colonne api_keys.name
+const maxKeyNameLength = 100
+
+// APIKeyHandler gère les clés d'accès à l'API
+type APIKeyHandler struct {
+	repo   repository.APIKeyRepositoryInterface
+	logger *charmLog.Logger
+}
+
+// NewAPIKeyHandler crée un nouveau handler
+func NewAPIKeyHandler(repo repository.APIKeyRepositoryInterface, logger *charmLog.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// APIKeyRequest représente une clé à créer
+type APIKeyRequest struct {
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+// CreatedAPIKey est la clé renvoyée à sa création, seul moment où elle est
+// affichée en clair
+type CreatedAPIKey struct {
+	repository.APIKey
+	Key string `json:"key"`
+}
+
+// List récupère toutes les clés, sans leur valeur
+// GET /api-keys
+func (h *APIKeyHandler) List(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.repo.List()
+	if err != nil {
+		sendInternalError(w, r, h.logger, "Erreur lors de la récupération des clés", err)
+		return
+	}
+
+	sendSuccessResponse(w, r, http.StatusOK, keys, "")
+}
+
+// Create génère une clé pour un rôle donné
+// POST /api-keys
+func (h *APIKeyHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req APIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendProblem(w, r, http.StatusBadRequest, problem.CodeInvalidBody, i18n.FromDecodeError(err))
+		return
+	}
+
+	var errs problem.FieldErrors
+	name := strings.TrimSpace(req.Name)
+	switch {
+	case name == "":
+		errs.Add("name", req.Name, i18n.FieldRequired)
+	case len(name) > maxKeyNameLength:
+		errs.Add("name", req.Name, i18n.MaxLength, strconv.Itoa(maxKeyNameLength))
+	}
+	role, ok := auth.ParseRole(req.Role)
+	if !ok {
+		allowed := make([]string, len(auth.Roles))
+		for i, role := range auth.Roles {
+			allowed[i] = string(role)
+		}
+		errs.Add("role", req.Role, i18n.AllowedValues, strings.Join(allowed, ", "))
+	}
+	if len(errs) > 0 {
+		sendValidationProblem(w, r, errs)
+		return
+	}
+
+	key, prefix, err := auth.GenerateKey()
+	if err != nil {
+		sendInternalError(w, r, h.logger, "Erreur lors de la génération de la clé", err)
+		return
+	}
+
+	created := CreatedAPIKey{
+		APIKey: repository.APIKey{Name: name, Prefix: prefix, Hash: auth.HashKey(key), Role: string(role)},
+		Key:    key,
+	}
+	if err := h.repo.Create(&created.APIKey); err != nil {
+		sendRepositoryError(w, r, h.logger, problem.CodeAPIKeyNotFound, "Erreur lors de la création de la clé", err, "name", name)
+		return
+	}
+
+	logging.FromContext(r.Context(), h.logger).Info("Clé d'API créée", "id", created.ID, "name", name, "role", role)
+	sendSuccessResponse(w, r, http.StatusCreated, created, i18n.APIKeyCreated)
+}
+
+// Revoke révoque une clé ; elle reste listée avec sa date de révocation
+// DELETE /api-keys/{id}
+func (h *APIKeyHandler) Revoke(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		sendProblem(w, r, http.StatusBadRequest, problem.CodeInvalidID, i18n.New(i18n.InvalidID))
+		return
+	}
+
+	if err := h.repo.Revoke(id); err != nil {
+		sendRepositoryError(w, r, h.logger, problem.CodeAPIKeyNotFound, "Erreur lors de la révocation de la clé", err, "id", id)
+		return
+	}
+
+	logging.FromContext(r.Context(), h.logger).Info("Clé d'API révoquée", "id", id)
+	sendSuccessResponse(w, r, http.StatusOK, nil, i18n.APIKeyRevoked)
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/i18n"
+	"github.com/japhy-tech/backend-test/internal/problem"
+	"github.com/japhy-tech/backend-test/internal/repository"
+	"github.com/japhy-tech/backend-test/internal/service"
+)
+
+// BreedAliasHandler gère les autres noms sous lesquels une race est connue
+type BreedAliasHandler struct {
+	breeds repository.BreedRepositoryInterface
+	repo   repository.BreedAliasRepositoryInterface
+	logger *charmLog.Logger
+}
+
+// NewBreedAliasHandler crée un nouveau handler
+func NewBreedAliasHandler(breeds repository.BreedRepositoryInterface, repo repository.BreedAliasRepositoryInterface, logger *charmLog.Logger) *BreedAliasHandler {
+	return &BreedAliasHandler{
+		breeds: breeds,
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// BreedAliasRequest représente un alias à ajouter à une race
+type BreedAliasRequest struct {
+	Alias string `json:"alias"`
+}
+
+// List récupère les alias d'une race
+// GET /breeds/{id}/aliases
+func (h *BreedAliasHandler) List(w http.ResponseWriter, r *http.Request) {
+	breed, ok := existingBreed(w, r, h.breeds, h.logger)
+	if !ok {
+		return
+	}
+
+	aliases, err := h.repo.ListForBreed(breed.ID)
+	if err != nil {
+		sendInternalError(w, r, h.logger, "Erreur lors de la récupération des alias", err, "id", breed.ID)
+		return
+	}
+
+	sendSuccessResponse(w, r, http.StatusOK, aliases, "")
+}
+
+// Create ajoute un alias à une race ; un alias qui désigne déjà une race,
+// par son nom ou par un autre alias, est rejeté (409)
+// POST /breeds/{id}/aliases
+func (h *BreedAliasHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req BreedAliasRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendProblem(w, r, http.StatusBadRequest, problem.CodeInvalidBody, i18n.FromDecodeError(err))
+		return
+	}
+
+	alias := service.NormalizeAlias(req.Alias)
+	if alias == "" {
+		var errs problem.FieldErrors
+		errs.Add("alias", req.Alias, i18n.FieldRequired)
+		sendValidationProblem(w, r, errs)
+		return
+	}
+
+	breed, ok := existingBreed(w, r, h.breeds, h.logger)
+	if !ok {
+		return
+	}
+
+	owner, err := h.breeds.Resolve(r.Context(), alias)
+	switch {
+	case err == nil:
+		sendProblem(w, r, http.StatusConflict, problem.CodeAliasConflict, &service.AliasConflictError{Alias: alias, Breed: owner.Name})
+		return
+	case !errors.Is(err, repository.ErrNotFound):
+		sendInternalError(w, r, h.logger, "Erreur lors de la vérification de l'alias", err, "alias", alias)
+		return
+	}
+
+	created := &repository.BreedAlias{Alias: alias, BreedID: breed.ID, BreedName: breed.Name}
+	if err := h.repo.Create(created); err != nil {
+		sendRepositoryError(w, r, h.logger, problem.CodeBreedNotFound, "Erreur lors de la création de l'alias", err, "id", breed.ID, "alias", alias)
+		return
+	}
+
+	sendSuccessResponse(w, r, http.StatusCreated, created, i18n.AliasCreated)
+}
+
+// Delete supprime un alias d'une race
+// DELETE /breeds/{id}/aliases/{alias}
+func (h *BreedAliasHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	breed, ok := existingBreed(w, r, h.breeds, h.logger)
+	if !ok {
+		return
+	}
+
+	alias := service.NormalizeAlias(mux.Vars(r)["alias"])
+	if err := h.repo.Delete(breed.ID, alias); err != nil {
+		sendRepositoryError(w, r, h.logger, problem.CodeAliasNotFound, "Erreur lors de la suppression de l'alias", err, "id", breed.ID, "alias", alias)
+		return
+	}
+
+	sendSuccessResponse(w, r, http.StatusOK, nil, i18n.AliasDeleted)
+}
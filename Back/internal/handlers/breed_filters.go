@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"github.com/japhy-tech/backend-test/internal/i18n"
+	"github.com/japhy-tech/backend-test/internal/problem"
+	"github.com/japhy-tech/backend-test/internal/repository"
+	"github.com/japhy-tech/backend-test/internal/units"
+)
+
+// BreedListMeta décrit, dans la réponse de GET /breeds, les filtres appliqués
+// et leur sémantique exacte
+type BreedListMeta struct {
+	Count   int              `json:"count"`
+	Limit   int              `json:"limit"`
+	Offset  int              `json:"offset"`
+	Filters BreedFiltersMeta `json:"filters"`
+}
+
+type BreedFiltersMeta struct {
+	Species      string                 `json:"species,omitempty"`
+	PetSize      string                 `json:"pet_size,omitempty"`
+	Name         string                 `json:"name,omitempty"`
+	WeightUnit   units.Unit             `json:"weight_unit"`
+	Sex          repository.WeightSex   `json:"sex"`
+	WeightMatch  repository.WeightMatch `json:"weight_match"`
+	Weight       *WeightRangeMeta       `json:"weight,omitempty"`
+	MaleWeight   *WeightRangeMeta       `json:"male_weight,omitempty"`
+	FemaleWeight *WeightRangeMeta       `json:"female_weight,omitempty"`
+	Semantics    string                 `json:"semantics,omitempty"`
+}
+
+// WeightRangeMeta est un intervalle de poids exprimé dans l'unité de la réponse
+type WeightRangeMeta struct {
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+}
+
+func newBreedListMeta(filter repository.BreedFilter, unit units.Unit, count int, lang i18n.Lang) BreedListMeta {
+	return BreedListMeta{
+		Count:  count,
+		Limit:  filter.Limit,
+		Offset: filter.Offset,
+		Filters: BreedFiltersMeta{
+			Species:      filter.Species,
+			PetSize:      filter.PetSize,
+			Name:         filter.Name,
+			WeightUnit:   unit,
+			Sex:          filter.Sex,
+			WeightMatch:  filter.Match,
+			Weight:       newWeightRangeMeta(filter.Weight, unit),
+			MaleWeight:   newWeightRangeMeta(filter.MaleWeight, unit),
+			FemaleWeight: newWeightRangeMeta(filter.FemaleWeight, unit),
+			Semantics:    weightSemantics(filter, lang),
+		},
+	}
+}
+
+func newWeightRangeMeta(wr repository.WeightRange, unit units.Unit) *WeightRangeMeta {
+	if !wr.IsSet() {
+		return nil
+	}
+
+	meta := &WeightRangeMeta{}
+	if wr.Min != nil {
+		value := unit.FromGrams(*wr.Min)
+		meta.Min = &value
+	}
+	if wr.Max != nil {
+		value := unit.FromGrams(*wr.Max)
+		meta.Max = &value
+	}
+	return meta
+}
+
+// weightSemantics explique en une phrase, dans la langue de la requête,
+// comment weight_min/weight_max ont été appliqués
+func weightSemantics(filter repository.BreedFilter, lang i18n.Lang) string {
+	if !filter.Weight.IsSet() {
+		return ""
+	}
+
+	switch filter.Sex {
+	case repository.SexMale:
+		return i18n.T(lang, i18n.SemanticsMale)
+	case repository.SexFemale:
+		return i18n.T(lang, i18n.SemanticsFemale)
+	}
+
+	if filter.Match == repository.MatchOverlap {
+		if filter.Sex == repository.SexBoth {
+			return i18n.T(lang, i18n.SemanticsOverlapBoth)
+		}
+		return i18n.T(lang, i18n.SemanticsOverlap)
+	}
+	if filter.Sex == repository.SexBoth {
+		return i18n.T(lang, i18n.SemanticsBoth)
+	}
+	return i18n.T(lang, i18n.SemanticsAny)
+}
+
+// weightRangeParam lit les paramètres <prefix>_min et <prefix>_max, déjà
+// validés et exprimés dans l'unité de la requête, et les convertit en grammes ;
+// une borne au-delà de units.MaxPlausibleGrams ou un intervalle vide
+// (min > max) est signalé dans errs
+func weightRangeParam(query Query, prefix string, unit units.Unit, errs *problem.FieldErrors) repository.WeightRange {
+	var wr repository.WeightRange
+	min, hasMin := weightParam(query, prefix+"_min", unit, errs)
+	if hasMin {
+		grams := unit.ToGrams(min)
+		wr.Min = &grams
+	}
+	max, hasMax := weightParam(query, prefix+"_max", unit, errs)
+	if hasMax {
+		grams := unit.ToGrams(max)
+		wr.Max = &grams
+	}
+	if hasMin && hasMax && min > max {
+		errs.Add(prefix+"_max", query.String(prefix+"_max"), i18n.MinBound, prefix+"_min")
+	}
+	return wr
+}
+
+// weightParam lit un poids exprimé dans l'unité de la requête et le rejette
+// s'il dépasse units.MaxPlausibleGrams
+func weightParam(query Query, name string, unit units.Unit, errs *problem.FieldErrors) (float64, bool) {
+	value, ok := query.Float(name)
+	if ok && unit.TooHigh(value) {
+		errs.Add(name, query.String(name), i18n.MaxBound, formatBound(unit.MaxValue()))
+		return 0, false
+	}
+	return value, ok
+}
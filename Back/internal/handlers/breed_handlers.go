@@ -8,138 +8,264 @@ import (
 
 	charmLog "github.com/charmbracelet/log"
 	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/i18n"
+	"github.com/japhy-tech/backend-test/internal/problem"
 	"github.com/japhy-tech/backend-test/internal/repository"
+	"github.com/japhy-tech/backend-test/internal/service"
+	"github.com/japhy-tech/backend-test/internal/units"
 )
 
 // BreedHandler gère les requêtes HTTP pour les races
 type BreedHandler struct {
-	repo   repository.BreedRepositoryInterface
-	logger *charmLog.Logger
+	repo       repository.BreedRepositoryInterface
+	breeds     *service.BreedService
+	sizes      SizeClassifierProvider
+	names      DisplayNameProvider
+	logger     *charmLog.Logger
+	
+	listParams    QuerySpec
+	detailParams  QuerySpec
+	resolveParams QuerySpec
+}
+
+// DefaultPageLimit est le nombre de races renvoyées quand limit est absent
+const DefaultPageLimit = 50
+
+// PageLimit retourne le limit appliqué par défaut, sans dépasser maxLimit
+func PageLimit(maxLimit int) int {
+	return min(DefaultPageLimit, maxLimit)
+}
+
+// ReferenceProvider fournit les espèces et tailles autorisées
+type ReferenceProvider interface {
+	Snapshot() (*service.ReferenceSet, error)
 }
 
-// NewBreedHandler crée un nouveau handler
-func NewBreedHandler(repo repository.BreedRepositoryInterface, logger *charmLog.Logger) *BreedHandler {
+// SizeClassifierProvider fournit les seuils de taille par espèce
+type SizeClassifierProvider interface {
+	Snapshot() (*service.SizeClassification, error)
+}
+
+// DisplayNameProvider fournit les noms d'affichage traduits des races
+type DisplayNameProvider interface {
+	DisplayNames(locale string, breeds []repository.Breed) (map[int]string, error)
+}
+
+// NewBreedHandler crée un nouveau handler ; maxLimit borne le paramètre limit
+// de GET /breeds
+func NewBreedHandler(repo repository.BreedRepositoryInterface, references ReferenceProvider, sizes SizeClassifierProvider, names DisplayNameProvider, maxLimit int, logger *charmLog.Logger) *BreedHandler {
+	unitParam := QueryParam{Name: "unit", Type: ParamEnum, Enum: units.Names()}
+	langParam := QueryParam{Name: "lang", Type: ParamEnum, Enum: service.SupportedLocales()}
+	
 	return &BreedHandler{
-		repo:   repo,
-		logger: logger,
+		repo:       repo,
+		breeds:     service.NewBreedService(repo, references, sizes),
+		sizes:      sizes,
+		names:      names,
+		logger:     logger,
+		listParams: NewQuerySpec(
+			QueryParam{Name: "species", Type: ParamString},
+			QueryParam{Name: "pet_size", Type: ParamString},
+			QueryParam{Name: "name", Type: ParamString},
+			unitParam,
+			langParam,
+			QueryParam{Name: "sex", Type: ParamEnum, Enum: []string{"any", "both", "male", "female"}},
+			QueryParam{Name: "weight_match", Type: ParamEnum, Enum: []string{"contained", "overlap"}},
+			QueryParam{Name: "weight_min", Type: ParamFloat, Min: Bound(0)},
+			QueryParam{Name: "weight_max", Type: ParamFloat, Min: Bound(0)},
+			QueryParam{Name: "male_weight_min", Type: ParamFloat, Min: Bound(0)},
+			QueryParam{Name: "male_weight_max", Type: ParamFloat, Min: Bound(0)},
+			QueryParam{Name: "female_weight_min", Type: ParamFloat, Min: Bound(0)},
+			QueryParam{Name: "female_weight_max", Type: ParamFloat, Min: Bound(0)},
+			QueryParam{Name: "limit", Type: ParamInt, Min: Bound(1), Max: Bound(float64(maxLimit)), Default: strconv.Itoa(PageLimit(maxLimit))},
+			QueryParam{Name: "offset", Type: ParamInt, Min: Bound(0), Default: "0"},
+		),
+		detailParams: NewQuerySpec(unitParam, langParam),
+		resolveParams: NewQuerySpec(
+			QueryParam{Name: "name", Type: ParamString, Required: true},
+			unitParam,
+			langParam,
+		),
 	}
 }
 
-// CreateBreedRequest représente la requête pour créer une race
+// CreateBreedRequest représente la requête pour créer une race ; les poids
+// sont des nombres dans l'unité de l'en-tête Weight-Unit (grammes par défaut)
+// ou des chaînes portant leur unité ("20kg")
 type CreateBreedRequest struct {
-	Species                   string `json:"species"`
-	PetSize                   string `json:"pet_size"`
-	Name                      string `json:"name"`
-	AverageMaleAdultWeight    int    `json:"average_male_adult_weight"`
-	AverageFemaleAdultWeight  int    `json:"average_female_adult_weight"`
+	Species                   string       `json:"species"`
+	PetSize                   string       `json:"pet_size"`
+	Name                      string       `json:"name"`
+	AverageMaleAdultWeight    units.Weight `json:"average_male_adult_weight"`
+	AverageFemaleAdultWeight  units.Weight `json:"average_female_adult_weight"`
 }
 
 // UpdateBreedRequest représente la requête pour modifier une race
 type UpdateBreedRequest struct {
-	Species                   string `json:"species,omitempty"`
-	PetSize                   string `json:"pet_size,omitempty"`
-	Name                      string `json:"name,omitempty"`
-	AverageMaleAdultWeight    int    `json:"average_male_adult_weight,omitempty"`
-	AverageFemaleAdultWeight  int    `json:"average_female_adult_weight,omitempty"`
-}
-
-type ErrorResponse struct {
-	Error   string `json:"error"`
-	Message string `json:"message,omitempty"`
-}
-
-type SuccessResponse struct {
-	Data    interface{} `json:"data,omitempty"`
-	Message string      `json:"message,omitempty"`
+	Species                   string       `json:"species,omitempty"`
+	PetSize                   string       `json:"pet_size,omitempty"`
+	Name                      string       `json:"name,omitempty"`
+	AverageMaleAdultWeight    units.Weight `json:"average_male_adult_weight"`
+	AverageFemaleAdultWeight  units.Weight `json:"average_female_adult_weight"`
 }
 
 // GetAllBreeds récupère toutes les races avec filtres optionnels
-// GET /breeds?species=dog&weight_min=5000&weight_max=10000&pet_size=small&limit=10&offset=0
+// GET /breeds?species=dog&weight_min=5&weight_max=10&unit=kg&sex=any&weight_match=contained&pet_size=small&limit=10&offset=0&lang=fr
+// Les poids (filtres et réponse) sont exprimés dans l'unité ?unit, sinon celle de
+// l'en-tête Weight-Unit, en grammes par défaut.
+// ?lang ajoute à chaque race son display_name dans cette langue.
+// ?name recherche le texte dans le nom canonique et dans les alias.
+// male_weight_min/max et female_weight_min/max filtrent chaque sexe séparément.
 func (h *BreedHandler) GetAllBreeds(w http.ResponseWriter, r *http.Request) {
-	// Récupérer les paramètres de requête
-	species := r.URL.Query().Get("species")
-	petSize := r.URL.Query().Get("pet_size")
-	
-	var weightMin, weightMax *int
-	if weightMinStr := r.URL.Query().Get("weight_min"); weightMinStr != "" {
-		if val, err := strconv.Atoi(weightMinStr); err == nil {
-			weightMin = &val
-		}
-	}
-	if weightMaxStr := r.URL.Query().Get("weight_max"); weightMaxStr != "" {
-		if val, err := strconv.Atoi(weightMaxStr); err == nil {
-			weightMax = &val
-		}
+	// Récupérer et valider les paramètres de requête
+	query, errs := h.listParams.Bind(r.URL.Query())
+	if len(errs) > 0 {
+		sendValidationProblem(w, r, errs)
+		return
 	}
 	
-	limit := 50 // valeur par défaut
-	if limitStr := r.URL.Query().Get("limit"); limitStr != "" {
-		if val, err := strconv.Atoi(limitStr); err == nil && val > 0 {
-			limit = val
-		}
+	unit, err := responseUnit(r, query)
+	if err != nil {
+		sendValidationProblem(w, r, problem.FieldErrors{problem.NewFieldError(WeightUnitHeader, r.Header.Get(WeightUnitHeader), err)})
+		return
 	}
+	sex, _ := repository.ParseWeightSex(query.String("sex"))
+	match, _ := repository.ParseWeightMatch(query.String("weight_match"))
+	limit, _ := query.Int("limit")
+	offset, _ := query.Int("offset")
 	
-	offset := 0
-	if offsetStr := r.URL.Query().Get("offset"); offsetStr != "" {
-		if val, err := strconv.Atoi(offsetStr); err == nil && val >= 0 {
-			offset = val
-		}
+	filter := repository.BreedFilter{
+		Species:      query.String("species"),
+		PetSize:      query.String("pet_size"),
+		Name:         service.NormalizeAlias(query.String("name")),
+		Sex:          sex,
+		Match:        match,
+		Weight:       weightRangeParam(query, "weight", unit, &errs),
+		MaleWeight:   weightRangeParam(query, "male_weight", unit, &errs),
+		FemaleWeight: weightRangeParam(query, "female_weight", unit, &errs),
+		Limit:        limit,
+		Offset:       offset,
+	}
+	if len(errs) > 0 {
+		sendValidationProblem(w, r, errs)
+		return
 	}
 	
-	breeds, err := h.repo.GetAll(species, weightMin, weightMax, petSize, limit, offset)
+	breeds, err := h.repo.GetAll(r.Context(), filter)
 	if err != nil {
-		h.logger.Error("Erreur lors de la récupération des races", "error", err)
-		h.sendErrorResponse(w, http.StatusInternalServerError, "Erreur serveur", err.Error())
+		sendInternalError(w, r, h.logger, "Erreur lors de la récupération des races", err)
 		return
 	}
 	
-	h.sendSuccessResponse(w, http.StatusOK, breeds, "")
+	responses := newBreedResponses(breeds, unit)
+	if !h.addDisplayNames(w, r, query.String("lang"), breeds, responses) {
+		return
+	}
+	
+	sendListResponse(w, r, http.StatusOK, responses, newBreedListMeta(filter, unit, len(breeds), i18n.FromRequest(r)))
 }
 
 // GetBreedByID récupère une race par son ID
-// GET /breeds/{id}
+// GET /breeds/{id}?unit=kg&lang=fr
 func (h *BreedHandler) GetBreedByID(w http.ResponseWriter, r *http.Request) {
 	vars := mux.Vars(r)
 	idStr := vars["id"]
 	
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		h.sendErrorResponse(w, http.StatusBadRequest, "ID invalide", "L'ID doit être un nombre entier")
+		sendProblem(w, r, http.StatusBadRequest, problem.CodeInvalidID, i18n.New(i18n.InvalidID))
 		return
 	}
 	
-	breed, err := h.repo.GetByID(id)
+	query, errs := h.detailParams.Bind(r.URL.Query())
+	if len(errs) > 0 {
+		sendValidationProblem(w, r, errs)
+		return
+	}
+	unit, err := responseUnit(r, query)
 	if err != nil {
-		h.logger.Error("Erreur lors de la récupération de la race", "id", id, "error", err)
-		h.sendErrorResponse(w, http.StatusInternalServerError, "Erreur serveur", err.Error())
+		sendValidationProblem(w, r, problem.FieldErrors{problem.NewFieldError(WeightUnitHeader, r.Header.Get(WeightUnitHeader), err)})
 		return
 	}
 	
-	if breed == nil {
-		h.sendErrorResponse(w, http.StatusNotFound, "Race non trouvée", "")
+	breed, err := h.repo.GetByID(r.Context(), id)
+	if err != nil {
+		sendRepositoryError(w, r, h.logger, problem.CodeBreedNotFound, "Erreur lors de la récupération de la race", err, "id", id)
 		return
 	}
 	
-	h.sendSuccessResponse(w, http.StatusOK, breed, "")
+	responses := []BreedResponse{newBreedResponse(breed, unit)}
+	if !h.addDisplayNames(w, r, query.String("lang"), []repository.Breed{*breed}, responses) {
+		return
+	}
+	
+	sendSuccessResponse(w, r, http.StatusOK, responses[0], "")
+}
+
+// ResolveBreed retrouve la race canonique désignée par un nom ou un alias
+// GET /breeds/resolve?name=Yorkie&unit=kg&lang=fr
+func (h *BreedHandler) ResolveBreed(w http.ResponseWriter, r *http.Request) {
+	query, errs := h.resolveParams.Bind(r.URL.Query())
+	if len(errs) > 0 {
+		sendValidationProblem(w, r, errs)
+		return
+	}
+	unit, err := responseUnit(r, query)
+	if err != nil {
+		sendValidationProblem(w, r, problem.FieldErrors{problem.NewFieldError(WeightUnitHeader, r.Header.Get(WeightUnitHeader), err)})
+		return
+	}
+	name := service.NormalizeAlias(query.String("name"))
+	
+	breed, err := h.repo.Resolve(r.Context(), name)
+	if err != nil {
+		sendRepositoryError(w, r, h.logger, problem.CodeBreedNotFound, "Erreur lors de la résolution de la race", err, "name", name)
+		return
+	}
+	
+	responses := []BreedResponse{newBreedResponse(breed, unit)}
+	if !h.addDisplayNames(w, r, query.String("lang"), []repository.Breed{*breed}, responses) {
+		return
+	}
+	
+	sendSuccessResponse(w, r, http.StatusOK, responses[0], "")
 }
 
 // CreateBreed crée une nouvelle race
 // POST /breeds
 func (h *BreedHandler) CreateBreed(w http.ResponseWriter, r *http.Request) {
-	var req CreateBreedRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.sendErrorResponse(w, http.StatusBadRequest, "Corps de requête invalide", err.Error())
+	unit, err := requestUnit(r)
+	if err != nil {
+		sendValidationProblem(w, r, problem.FieldErrors{problem.NewFieldError(WeightUnitHeader, r.Header.Get(WeightUnitHeader), err)})
 		return
 	}
 	
-	// Validation basique
-	if req.Species == "" || req.Name == "" || req.PetSize == "" {
-		h.sendErrorResponse(w, http.StatusBadRequest, "Champs obligatoires manquants", "species, name et pet_size sont requis")
+	var req CreateBreedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendProblem(w, r, http.StatusBadRequest, problem.CodeInvalidBody, i18n.FromDecodeError(err))
 		return
 	}
 	
-	if req.AverageMaleAdultWeight <= 0 || req.AverageFemaleAdultWeight <= 0 {
-		h.sendErrorResponse(w, http.StatusBadRequest, "Poids invalides", "Les poids doivent être supérieurs à 0")
+	maleWeight := req.AverageMaleAdultWeight.Grams(unit)
+	femaleWeight := req.AverageFemaleAdultWeight.Grams(unit)
+	
+	// Validation basique ; pet_size peut être omis et sera déduit des poids
+	var errs problem.FieldErrors
+	if req.Species == "" {
+		errs.Add("species", "", i18n.FieldRequired)
+	}
+	if req.Name == "" {
+		errs.Add("name", "", i18n.FieldRequired)
+	}
+	if maleWeight <= 0 {
+		errs.Add("average_male_adult_weight", "", i18n.MustBePositive)
+	}
+	if femaleWeight <= 0 {
+		errs.Add("average_female_adult_weight", "", i18n.MustBePositive)
+	}
+	checkWeightBounds(&errs, maleWeight, femaleWeight)
+	if len(errs) > 0 {
+		sendValidationProblem(w, r, errs)
 		return
 	}
 	
@@ -147,18 +273,17 @@ func (h *BreedHandler) CreateBreed(w http.ResponseWriter, r *http.Request) {
 		Species:                   req.Species,
 		PetSize:                   req.PetSize,
 		Name:                      req.Name,
-		AverageMaleAdultWeight:    req.AverageMaleAdultWeight,
-		AverageFemaleAdultWeight:  req.AverageFemaleAdultWeight,
+		AverageMaleAdultWeight:    maleWeight,
+		AverageFemaleAdultWeight:  femaleWeight,
 	}
 	
-	createdBreed, err := h.repo.Create(breed)
+	createdBreed, err := h.breeds.Create(r.Context(), breed)
 	if err != nil {
-		h.logger.Error("Erreur lors de la création de la race", "breed", req, "error", err)
-		h.sendErrorResponse(w, http.StatusInternalServerError, "Erreur lors de la création", err.Error())
+		h.sendWriteError(w, r, "Erreur lors de la création de la race", err, "breed", req)
 		return
 	}
 	
-	h.sendSuccessResponse(w, http.StatusCreated, createdBreed, "Race créée avec succès")
+	sendSuccessResponse(w, r, http.StatusCreated, newBreedResponse(createdBreed, unit), i18n.BreedCreated)
 }
 
 // UpdateBreed met à jour une race existante
@@ -169,26 +294,37 @@ func (h *BreedHandler) UpdateBreed(w http.ResponseWriter, r *http.Request) {
 	
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		h.sendErrorResponse(w, http.StatusBadRequest, "ID invalide", "L'ID doit être un nombre entier")
+		sendProblem(w, r, http.StatusBadRequest, problem.CodeInvalidID, i18n.New(i18n.InvalidID))
 		return
 	}
 	
-	var req UpdateBreedRequest
-	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		h.sendErrorResponse(w, http.StatusBadRequest, "Corps de requête invalide", err.Error())
+	unit, err := requestUnit(r)
+	if err != nil {
+		sendValidationProblem(w, r, problem.FieldErrors{problem.NewFieldError(WeightUnitHeader, r.Header.Get(WeightUnitHeader), err)})
 		return
 	}
 	
-	// Vérifier que la race existe
-	existingBreed, err := h.repo.GetByID(id)
-	if err != nil {
-		h.logger.Error("Erreur lors de la vérification de la race", "id", id, "error", err)
-		h.sendErrorResponse(w, http.StatusInternalServerError, "Erreur serveur", err.Error())
+	var req UpdateBreedRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendProblem(w, r, http.StatusBadRequest, problem.CodeInvalidBody, i18n.FromDecodeError(err))
 		return
 	}
 	
-	if existingBreed == nil {
-		h.sendErrorResponse(w, http.StatusNotFound, "Race non trouvée", "")
+	maleWeight := req.AverageMaleAdultWeight.Grams(unit)
+	femaleWeight := req.AverageFemaleAdultWeight.Grams(unit)
+	var errs problem.FieldErrors
+	if maleWeight < 0 {
+		errs.Add("average_male_adult_weight", "", i18n.MustBePositive)
+	}
+	if femaleWeight < 0 {
+		errs.Add("average_female_adult_weight", "", i18n.MustBePositive)
+	}
+	checkWeightBounds(&errs, maleWeight, femaleWeight)
+	if req.Species == "" && req.PetSize == "" && req.Name == "" && maleWeight == 0 && femaleWeight == 0 {
+		errs.Add("body", "", i18n.NothingToUpdate)
+	}
+	if len(errs) > 0 {
+		sendValidationProblem(w, r, errs)
 		return
 	}
 	
@@ -197,18 +333,18 @@ func (h *BreedHandler) UpdateBreed(w http.ResponseWriter, r *http.Request) {
 		Species:                   req.Species,
 		PetSize:                   req.PetSize,
 		Name:                      req.Name,
-		AverageMaleAdultWeight:    req.AverageMaleAdultWeight,
-		AverageFemaleAdultWeight:  req.AverageFemaleAdultWeight,
+		AverageMaleAdultWeight:    maleWeight,
+		AverageFemaleAdultWeight:  femaleWeight,
 	}
 	
-	updatedBreed, err := h.repo.Update(id, breed)
+	// La race doit exister et rester cohérente une fois les changements appliqués
+	updatedBreed, err := h.breeds.Update(r.Context(), id, breed)
 	if err != nil {
-		h.logger.Error("Erreur lors de la mise à jour de la race", "id", id, "error", err)
-		h.sendErrorResponse(w, http.StatusInternalServerError, "Erreur lors de la mise à jour", err.Error())
+		h.sendWriteError(w, r, "Erreur lors de la mise à jour de la race", err, "id", id)
 		return
 	}
 	
-	h.sendSuccessResponse(w, http.StatusOK, updatedBreed, "Race mise à jour avec succès")
+	sendSuccessResponse(w, r, http.StatusOK, newBreedResponse(updatedBreed, unit), i18n.BreedUpdated)
 }
 
 // DeleteBreed supprime une race
@@ -219,48 +355,71 @@ func (h *BreedHandler) DeleteBreed(w http.ResponseWriter, r *http.Request) {
 	
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		h.sendErrorResponse(w, http.StatusBadRequest, "ID invalide", "L'ID doit être un nombre entier")
+		sendProblem(w, r, http.StatusBadRequest, problem.CodeInvalidID, i18n.New(i18n.InvalidID))
 		return
 	}
 	
-	// Vérifier que la race existe
-	existingBreed, err := h.repo.GetByID(id)
+	// Delete renvoie ErrNotFound si la race n'existe pas
+	err = h.repo.Delete(r.Context(), id)
 	if err != nil {
-		h.logger.Error("Erreur lors de la vérification de la race", "id", id, "error", err)
-		h.sendErrorResponse(w, http.StatusInternalServerError, "Erreur serveur", err.Error())
+		sendRepositoryError(w, r, h.logger, problem.CodeBreedNotFound, "Erreur lors de la suppression de la race", err, "id", id)
 		return
 	}
 	
-	if existingBreed == nil {
-		h.sendErrorResponse(w, http.StatusNotFound, "Race non trouvée", "")
+	sendSuccessResponse(w, r, http.StatusOK, nil, i18n.BreedDeleted)
+}
+
+// GetSizeInconsistencies liste les races dont la taille déclarée contredit
+// les poids selon la classification configurée
+// GET /breeds/size-consistency
+func (h *BreedHandler) GetSizeInconsistencies(w http.ResponseWriter, r *http.Request) {
+	classification, err := h.sizes.Snapshot()
+	if err != nil {
+		sendInternalError(w, r, h.logger, "Erreur lors du chargement des seuils de taille", err)
 		return
 	}
 	
-	err = h.repo.Delete(id)
+	breeds, err := h.repo.GetAll(r.Context(), repository.BreedFilter{})
 	if err != nil {
-		h.logger.Error("Erreur lors de la suppression de la race", "id", id, "error", err)
-		h.sendErrorResponse(w, http.StatusInternalServerError, "Erreur lors de la suppression", err.Error())
+		sendInternalError(w, r, h.logger, "Erreur lors de la récupération des races", err)
 		return
 	}
 	
-	h.sendSuccessResponse(w, http.StatusOK, nil, "Race supprimée avec succès")
+	inconsistencies := []service.SizeInconsistency{}
+	for _, breed := range breeds {
+		if inconsistency := classification.Check(breed); inconsistency != nil {
+			inconsistencies = append(inconsistencies, *inconsistency)
+		}
+	}
+	
+	sendSuccessResponse(w, r, http.StatusOK, inconsistencies, "")
 }
 
-// Fonctions utilitaires pour les réponses HTTP
-func (h *BreedHandler) sendErrorResponse(w http.ResponseWriter, statusCode int, error, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(ErrorResponse{
-		Error:   error,
-		Message: message,
-	})
+// addDisplayNames renseigne le display_name des réponses si une langue est
+// demandée ; responses suit l'ordre de breeds
+func (h *BreedHandler) addDisplayNames(w http.ResponseWriter, r *http.Request, locale string, breeds []repository.Breed, responses []BreedResponse) bool {
+	if locale == "" {
+		return true
+	}
+	
+	names, err := h.names.DisplayNames(locale, breeds)
+	if err != nil {
+		sendInternalError(w, r, h.logger, "Erreur lors de la récupération des noms traduits", err, "lang", locale)
+		return false
+	}
+	
+	for i := range responses {
+		responses[i].DisplayName = names[responses[i].ID]
+	}
+	return true
 }
 
-func (h *BreedHandler) sendSuccessResponse(w http.ResponseWriter, statusCode int, data interface{}, message string) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(statusCode)
-	json.NewEncoder(w).Encode(SuccessResponse{
-		Data:    data,
-		Message: message,
-	})
-}
\ No newline at end of file
+// sendWriteError renvoie une 400 pour une règle d'écriture non respectée,
+// sinon l'erreur du repository
+func (h *BreedHandler) sendWriteError(w http.ResponseWriter, r *http.Request, message string, err error, keyvals ...interface{}) {
+	if errs := problem.FromValidationError(err); errs != nil {
+		sendValidationProblem(w, r, errs)
+		return
+	}
+	sendRepositoryError(w, r, h.logger, problem.CodeBreedNotFound, message, err, keyvals...)
+}
@@ -1,31 +1,98 @@
 package handlers
 
 import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
+	"github.com/japhy-tech/backend-test/internal/problem"
 	"github.com/japhy-tech/backend-test/internal/repository"
+	"github.com/japhy-tech/backend-test/internal/requestid"
+	"github.com/japhy-tech/backend-test/internal/service"
+	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/charmbracelet/log"
+	"github.com/go-sql-driver/mysql"
+	"github.com/gorilla/mux"
 )
 
-type MockBreedRepo struct{}
+type MockBreedRepo struct {
+	lastFilter repository.BreedFilter
+	err        error
+}
 
-func (m *MockBreedRepo) GetAll(species string, weightMin, weightMax *int, petSize string, limit, offset int) ([]repository.Breed, error) {
+func (m *MockBreedRepo) GetAll(ctx context.Context, filter repository.BreedFilter) ([]repository.Breed, error) {
+	m.lastFilter = filter
+	if m.err != nil {
+		return nil, m.err
+	}
 	return []repository.Breed{
-		{ID: 1, Species: "dog", PetSize: "medium", Name: "Border Collie", AverageMaleAdultWeight: 20, AverageFemaleAdultWeight: 18},
+		{ID: 1, Species: "dog", PetSize: "medium", Name: "Border Collie", AverageMaleAdultWeight: 20000, AverageFemaleAdultWeight: 18000},
 	}, nil
 }
 
-func (m *MockBreedRepo) Create(breed *repository.Breed) (*repository.Breed, error) { return nil, nil }
-func (m *MockBreedRepo) GetByID(id int) (*repository.Breed, error) { return nil, nil }
-func (m *MockBreedRepo) Update(id int, breed *repository.Breed) (*repository.Breed, error) { return nil, nil }
-func (m *MockBreedRepo) Delete(id int) error { return nil }
-func (m *MockBreedRepo) ImportFromCSV(breeds []repository.Breed) error { return nil }
+func (m *MockBreedRepo) Create(ctx context.Context, breed *repository.Breed) (*repository.Breed, error) {
+	if breed.Name == "affenpinscher" {
+		return nil, &repository.Error{Kind: repository.ErrDuplicateName, Entity: "breed", Err: &mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'affenpinscher' for key 'name'"}}
+	}
+	return breed, nil
+}
+func (m *MockBreedRepo) GetByID(ctx context.Context, id int) (*repository.Breed, error) { return nil, repository.ErrNotFound }
+func (m *MockBreedRepo) Resolve(ctx context.Context, name string) (*repository.Breed, error) {
+	if name == "yorkie" || name == "yorkshire_terrier" {
+		return &repository.Breed{ID: 7, Species: "dog", PetSize: "small", Name: "yorkshire_terrier", AverageMaleAdultWeight: 3000, AverageFemaleAdultWeight: 3000}, nil
+	}
+	return nil, repository.ErrNotFound
+}
+func (m *MockBreedRepo) Update(ctx context.Context, id int, breed *repository.Breed) (*repository.Breed, error) { return nil, nil }
+func (m *MockBreedRepo) Delete(ctx context.Context, id int) error {
+	return fmt.Errorf("erreur lors de la suppression de la race: %w", &repository.Error{Kind: repository.ErrNotFound, Entity: "breed"})
+}
+func (m *MockBreedRepo) ImportFromCSV(ctx context.Context, breeds []repository.Breed) (repository.ImportStats, error) {
+	return repository.ImportStats{}, nil
+}
+
+type MockReferences struct{}
+
+func (m *MockReferences) Snapshot() (*service.ReferenceSet, error) {
+	return &service.ReferenceSet{Species: []string{"cat", "dog"}, PetSizes: []string{"medium", "small", "tall"}}, nil
+}
+
+type MockSizes struct {
+	enforce bool
+}
+
+func (m *MockSizes) Snapshot() (*service.SizeClassification, error) {
+	small, medium := 10000, 25000
+	return service.NewSizeClassification([]repository.SizeThreshold{
+		{Species: "dog", PetSize: "small", MaxWeight: &small},
+		{Species: "dog", PetSize: "medium", MaxWeight: &medium},
+		{Species: "dog", PetSize: "tall"},
+	}, m.enforce), nil
+}
+
+// MockNames traduit en français la race 1 uniquement
+type MockNames struct{}
+
+func (m *MockNames) DisplayNames(locale string, breeds []repository.Breed) (map[int]string, error) {
+	names := map[int]string{}
+	for _, breed := range breeds {
+		names[breed.ID] = service.HumanizeBreedName(breed.Name)
+		if breed.ID == 1 && locale == "fr" {
+			names[breed.ID] = "Colley à poil long"
+		}
+	}
+	return names, nil
+}
 
 func TestGetAllBreeds(t *testing.T) {
 	mockRepo := &MockBreedRepo{}
 	logger := log.NewWithOptions(nil, log.Options{})
-	handler := NewBreedHandler(mockRepo, logger)
+	handler := NewBreedHandler(mockRepo, &MockReferences{}, &MockSizes{}, &MockNames{}, 100, logger)
 
 	req := httptest.NewRequest("GET", "/breeds", nil)
 	w := httptest.NewRecorder()
@@ -37,3 +104,603 @@ func TestGetAllBreeds(t *testing.T) {
 		t.Errorf("attendu 200, obtenu %d", resp.StatusCode)
 	}
 }
+
+func TestCreateBreed_UnknownSpecies(t *testing.T) {
+	mockRepo := &MockBreedRepo{}
+	logger := log.NewWithOptions(nil, log.Options{})
+	handler := NewBreedHandler(mockRepo, &MockReferences{}, &MockSizes{}, &MockNames{}, 100, logger)
+
+	body := `{"species": "chien", "pet_size": "medium", "name": "border_collie", "average_male_adult_weight": 20000, "average_female_adult_weight": 18000}`
+	req := httptest.NewRequest("POST", "/breeds", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.CreateBreed(w, req)
+
+	resp := w.Result()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("attendu 400, obtenu %d", resp.StatusCode)
+	}
+	if !strings.Contains(w.Body.String(), "cat, dog") {
+		t.Errorf("les valeurs autorisées devraient être listées, obtenu %s", w.Body.String())
+	}
+}
+
+func TestCreateBreed_DerivesPetSize(t *testing.T) {
+	mockRepo := &MockBreedRepo{}
+	logger := log.NewWithOptions(nil, log.Options{})
+	handler := NewBreedHandler(mockRepo, &MockReferences{}, &MockSizes{}, &MockNames{}, 100, logger)
+
+	body := `{"species": "dog", "name": "border_collie", "average_male_adult_weight": 20000, "average_female_adult_weight": 18000}`
+	req := httptest.NewRequest("POST", "/breeds", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.CreateBreed(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("attendu 201, obtenu %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), `"pet_size":"medium"`) {
+		t.Errorf("pet_size devrait être déduit à medium, obtenu %s", w.Body.String())
+	}
+}
+
+func TestCreateBreed_EnforcedPetSizeConsistency(t *testing.T) {
+	mockRepo := &MockBreedRepo{}
+	logger := log.NewWithOptions(nil, log.Options{})
+	handler := NewBreedHandler(mockRepo, &MockReferences{}, &MockSizes{enforce: true}, &MockNames{}, 100, logger)
+
+	body := `{"species": "dog", "pet_size": "small", "name": "border_collie", "average_male_adult_weight": 20000, "average_female_adult_weight": 18000}`
+	req := httptest.NewRequest("POST", "/breeds", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.CreateBreed(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("attendu 400, obtenu %d", w.Code)
+	}
+}
+
+func TestGetAllBreeds_Unit(t *testing.T) {
+	mockRepo := &MockBreedRepo{}
+	logger := log.NewWithOptions(nil, log.Options{})
+	handler := NewBreedHandler(mockRepo, &MockReferences{}, &MockSizes{}, &MockNames{}, 100, logger)
+
+	req := httptest.NewRequest("GET", "/breeds?unit=kg", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetAllBreeds(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("attendu 200, obtenu %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), `"average_male_adult_weight":20,`) || !strings.Contains(w.Body.String(), `"weight_unit":"kg"`) {
+		t.Errorf("poids attendus en kilogrammes, obtenu %s", w.Body.String())
+	}
+
+	req = httptest.NewRequest("GET", "/breeds?unit=stone", nil)
+	w = httptest.NewRecorder()
+
+	handler.GetAllBreeds(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("attendu 400 pour une unité inconnue, obtenu %d", w.Code)
+	}
+}
+
+func TestGetAllBreeds_UnitAliasAndHeader(t *testing.T) {
+	mockRepo := &MockBreedRepo{}
+	logger := log.NewWithOptions(nil, log.Options{})
+	handler := NewBreedHandler(mockRepo, &MockReferences{}, &MockSizes{}, &MockNames{}, 100, logger)
+
+	req := httptest.NewRequest("GET", "/breeds?unit=pounds", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetAllBreeds(w, req)
+
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"weight_unit":"lb"`) {
+		t.Fatalf("attendu 200 en livres, obtenu %d %s", w.Code, w.Body.String())
+	}
+
+	req = httptest.NewRequest("GET", "/breeds", nil)
+	req.Header.Set(WeightUnitHeader, "kg")
+	w = httptest.NewRecorder()
+
+	handler.GetAllBreeds(w, req)
+
+	if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"average_male_adult_weight":20,`) {
+		t.Fatalf("attendu 200 en kilogrammes, obtenu %d %s", w.Code, w.Body.String())
+	}
+
+	// ?unit l'emporte sur l'en-tête
+	req = httptest.NewRequest("GET", "/breeds?unit=g", nil)
+	req.Header.Set(WeightUnitHeader, "kg")
+	w = httptest.NewRecorder()
+
+	handler.GetAllBreeds(w, req)
+
+	if !strings.Contains(w.Body.String(), `"weight_unit":"g"`) {
+		t.Errorf("attendu des grammes, obtenu %s", w.Body.String())
+	}
+
+	req = httptest.NewRequest("GET", "/breeds", nil)
+	req.Header.Set(WeightUnitHeader, "stone")
+	w = httptest.NewRecorder()
+
+	handler.GetAllBreeds(w, req)
+
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), WeightUnitHeader) {
+		t.Errorf("attendu 400 sur l'en-tête, obtenu %d %s", w.Code, w.Body.String())
+	}
+}
+
+func TestCreateBreed_UnitTaggedWeights(t *testing.T) {
+	mockRepo := &MockBreedRepo{}
+	logger := log.NewWithOptions(nil, log.Options{})
+	handler := NewBreedHandler(mockRepo, &MockReferences{}, &MockSizes{}, &MockNames{}, 100, logger)
+
+	body := `{"species": "dog", "pet_size": "medium", "name": "border_collie", "average_male_adult_weight": "20kg", "average_female_adult_weight": 18}`
+	req := httptest.NewRequest("POST", "/breeds", strings.NewReader(body))
+	req.Header.Set(WeightUnitHeader, "kg")
+	w := httptest.NewRecorder()
+
+	handler.CreateBreed(w, req)
+
+	if w.Code != http.StatusCreated {
+		t.Fatalf("attendu 201, obtenu %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), `"average_female_adult_weight":18,`) {
+		t.Errorf("poids attendus en kilogrammes, obtenu %s", w.Body.String())
+	}
+}
+
+func TestCreateBreed_InvalidBodyLocalized(t *testing.T) {
+	mockRepo := &MockBreedRepo{}
+	logger := log.NewWithOptions(nil, log.Options{})
+	handler := NewBreedHandler(mockRepo, &MockReferences{}, &MockSizes{}, &MockNames{}, 100, logger)
+
+	for lang, detail := range map[string]string{
+		"fr": `"detail":"type invalide pour name (number reçu)"`,
+		"en": `"detail":"invalid type for name (got number)"`,
+	} {
+		req := httptest.NewRequest("POST", "/breeds", strings.NewReader(`{"species": "dog", "name": 5}`))
+		req.Header.Set("Accept-Language", lang)
+		w := httptest.NewRecorder()
+
+		handler.CreateBreed(w, req)
+
+		if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), detail) || strings.Contains(w.Body.String(), "json:") {
+			t.Errorf("%s: attendu 400 avec %s, obtenu %d: %s", lang, detail, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestCreateBreed_WeightTooHigh(t *testing.T) {
+	mockRepo := &MockBreedRepo{}
+	logger := log.NewWithOptions(nil, log.Options{})
+	handler := NewBreedHandler(mockRepo, &MockReferences{}, &MockSizes{}, &MockNames{}, 100, logger)
+
+	// 200 est accepté comme nombre nu mais dépasse la limite en kilogrammes
+	body := `{"species": "dog", "pet_size": "medium", "name": "border_collie", "average_male_adult_weight": 200, "average_female_adult_weight": 18}`
+	req := httptest.NewRequest("POST", "/breeds", strings.NewReader(body))
+	req.Header.Set(WeightUnitHeader, "kg")
+	w := httptest.NewRecorder()
+
+	handler.CreateBreed(w, req)
+
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), `"field":"average_male_adult_weight"`) {
+		t.Errorf("attendu 400 sur average_male_adult_weight, obtenu %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestGetAllBreeds_WeightTooHigh(t *testing.T) {
+	mockRepo := &MockBreedRepo{}
+	logger := log.NewWithOptions(nil, log.Options{})
+	handler := NewBreedHandler(mockRepo, &MockReferences{}, &MockSizes{}, &MockNames{}, 100, logger)
+
+	req := httptest.NewRequest("GET", "/breeds?weight_max=1e300&unit=kg&male_weight_min=151", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetAllBreeds(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("attendu 400, obtenu %d: %s", w.Code, w.Body.String())
+	}
+	for _, param := range []string{"weight_max", "male_weight_min"} {
+		if !strings.Contains(w.Body.String(), `"field":"`+param+`"`) {
+			t.Errorf("le paramètre %s devrait être signalé, obtenu %s", param, w.Body.String())
+		}
+	}
+}
+
+func TestGetAllBreeds_WeightFilterModes(t *testing.T) {
+	mockRepo := &MockBreedRepo{}
+	logger := log.NewWithOptions(nil, log.Options{})
+	handler := NewBreedHandler(mockRepo, &MockReferences{}, &MockSizes{}, &MockNames{}, 100, logger)
+
+	req := httptest.NewRequest("GET", "/breeds?weight_min=10&weight_max=20&unit=kg&sex=both&male_weight_min=15", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetAllBreeds(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("attendu 200, obtenu %d", w.Code)
+	}
+	filter := mockRepo.lastFilter
+	if filter.Sex != repository.SexBoth || filter.Match != repository.MatchContained {
+		t.Errorf("sex=both et weight_match=contained attendus, obtenu %s/%s", filter.Sex, filter.Match)
+	}
+	if *filter.Weight.Min != 10000 || *filter.Weight.Max != 20000 || *filter.MaleWeight.Min != 15000 {
+		t.Errorf("bornes attendues en grammes, obtenu %+v", filter)
+	}
+	if !strings.Contains(w.Body.String(), `"semantics":"les poids moyens mâle et femelle sont tous deux compris`) {
+		t.Errorf("la sémantique du filtre devrait figurer dans meta, obtenu %s", w.Body.String())
+	}
+
+	req = httptest.NewRequest("GET", "/breeds?sex=unknown", nil)
+	w = httptest.NewRecorder()
+
+	handler.GetAllBreeds(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("attendu 400 pour sex inconnu, obtenu %d", w.Code)
+	}
+}
+
+func TestGetAllBreeds_InvalidQueryParameters(t *testing.T) {
+	mockRepo := &MockBreedRepo{}
+	logger := log.NewWithOptions(nil, log.Options{})
+	handler := NewBreedHandler(mockRepo, &MockReferences{}, &MockSizes{}, &MockNames{}, 100, logger)
+
+	req := httptest.NewRequest("GET", "/breeds?weight_min=abc&limit=500&offset=-1&weigth_max=10", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetAllBreeds(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("attendu 400, obtenu %d", w.Code)
+	}
+	for _, param := range []string{"weight_min", "limit", "offset", "weigth_max"} {
+		if !strings.Contains(w.Body.String(), `"field":"`+param+`"`) {
+			t.Errorf("le paramètre %s devrait être signalé, obtenu %s", param, w.Body.String())
+		}
+	}
+}
+
+func TestGetAllBreeds_DefaultLimitBelowMax(t *testing.T) {
+	mockRepo := &MockBreedRepo{}
+	logger := log.NewWithOptions(nil, log.Options{})
+	handler := NewBreedHandler(mockRepo, &MockReferences{}, &MockSizes{}, &MockNames{}, 20, logger)
+
+	req := httptest.NewRequest("GET", "/breeds", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetAllBreeds(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("attendu 200 sans limit, obtenu %d %s", w.Code, w.Body.String())
+	}
+	if mockRepo.lastFilter.Limit != 20 {
+		t.Errorf("limit par défaut attendu à 20, obtenu %d", mockRepo.lastFilter.Limit)
+	}
+}
+
+func TestGetAllBreeds_NonFiniteWeights(t *testing.T) {
+	mockRepo := &MockBreedRepo{}
+	logger := log.NewWithOptions(nil, log.Options{})
+	handler := NewBreedHandler(mockRepo, &MockReferences{}, &MockSizes{}, &MockNames{}, 100, logger)
+
+	for _, value := range []string{"NaN", "Inf", "+Inf", "-Inf", "1e309"} {
+		req := httptest.NewRequest("GET", "/breeds?weight_max="+url.QueryEscape(value), nil)
+		w := httptest.NewRecorder()
+
+		handler.GetAllBreeds(w, req)
+
+		if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), `"field":"weight_max"`) {
+			t.Errorf("weight_max=%s : attendu 400 sur weight_max, obtenu %d %s", value, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestGetAllBreeds_InternalErrorIsHidden(t *testing.T) {
+	mockRepo := &MockBreedRepo{err: errors.New("Error 1045: Access denied for user 'myuser'")}
+	logger := log.NewWithOptions(nil, log.Options{})
+	handler := NewBreedHandler(mockRepo, &MockReferences{}, &MockSizes{}, &MockNames{}, 100, logger)
+
+	req := httptest.NewRequest("GET", "/breeds", nil)
+	w := httptest.NewRecorder()
+
+	requestid.Middleware(http.HandlerFunc(handler.GetAllBreeds)).ServeHTTP(w, req)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("attendu 500, obtenu %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != problem.ContentType {
+		t.Errorf("Content-Type attendu %s, obtenu %s", problem.ContentType, ct)
+	}
+	body := w.Body.String()
+	if strings.Contains(body, "Access denied") {
+		t.Errorf("l'erreur SQL ne doit pas être exposée, obtenu %s", body)
+	}
+	if !strings.Contains(body, `"code":"internal_error"`) || !strings.Contains(body, `"request_id":"`+w.Header().Get(requestid.Header)+`"`) {
+		t.Errorf("code stable et identifiant de requête attendus, obtenu %s", body)
+	}
+}
+
+func TestCreateBreed_DuplicateName(t *testing.T) {
+	mockRepo := &MockBreedRepo{}
+	logger := log.NewWithOptions(nil, log.Options{})
+	handler := NewBreedHandler(mockRepo, &MockReferences{}, &MockSizes{}, &MockNames{}, 100, logger)
+
+	body := `{"species": "dog", "pet_size": "small", "name": "affenpinscher", "average_male_adult_weight": 6000, "average_female_adult_weight": 5000}`
+	req := httptest.NewRequest("POST", "/breeds", strings.NewReader(body))
+	w := httptest.NewRecorder()
+
+	handler.CreateBreed(w, req)
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("attendu 409, obtenu %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), `"code":"duplicate_name"`) || strings.Contains(w.Body.String(), "Duplicate entry") {
+		t.Errorf("code duplicate_name attendu sans message MySQL, obtenu %s", w.Body.String())
+	}
+}
+
+func TestDeleteBreed_NotFound(t *testing.T) {
+	mockRepo := &MockBreedRepo{}
+	logger := log.NewWithOptions(nil, log.Options{})
+	handler := NewBreedHandler(mockRepo, &MockReferences{}, &MockSizes{}, &MockNames{}, 100, logger)
+
+	req := mux.SetURLVars(httptest.NewRequest("DELETE", "/breeds/42", nil), map[string]string{"id": "42"})
+	w := httptest.NewRecorder()
+
+	handler.DeleteBreed(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("attendu 404, obtenu %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), `"code":"breed_not_found"`) {
+		t.Errorf("code breed_not_found attendu, obtenu %s", w.Body.String())
+	}
+}
+
+func TestCreateBreed_AcceptLanguage(t *testing.T) {
+	mockRepo := &MockBreedRepo{}
+	logger := log.NewWithOptions(nil, log.Options{})
+	handler := NewBreedHandler(mockRepo, &MockReferences{}, &MockSizes{}, &MockNames{}, 100, logger)
+
+	body := `{"species": "chien", "name": "border_collie", "average_male_adult_weight": 20000}`
+	req := httptest.NewRequest("POST", "/breeds", strings.NewReader(body))
+	req.Header.Set("Accept-Language", "en-US,en;q=0.9,fr;q=0.5")
+	w := httptest.NewRecorder()
+
+	handler.CreateBreed(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("attendu 400, obtenu %d", w.Code)
+	}
+	if lang := w.Header().Get("Content-Language"); lang != "en" {
+		t.Errorf("Content-Language attendu en, obtenu %s", lang)
+	}
+	if !strings.Contains(w.Body.String(), `"title":"Invalid parameters"`) || !strings.Contains(w.Body.String(), `"message":"must be greater than 0"`) {
+		t.Errorf("problème attendu en anglais, obtenu %s", w.Body.String())
+	}
+
+	body = `{"species": "chien", "pet_size": "medium", "name": "border_collie", "average_male_adult_weight": 20000, "average_female_adult_weight": 18000}`
+	req = httptest.NewRequest("POST", "/breeds", strings.NewReader(body))
+	req.Header.Set("Accept-Language", "en")
+	w = httptest.NewRecorder()
+
+	handler.CreateBreed(w, req)
+
+	if !strings.Contains(w.Body.String(), "unknown value 'chien' for species (allowed values: cat, dog)") {
+		t.Errorf("erreur de référence attendue en anglais, obtenu %s", w.Body.String())
+	}
+}
+
+func TestGetAllBreeds_DisplayName(t *testing.T) {
+	mockRepo := &MockBreedRepo{}
+	logger := log.NewWithOptions(nil, log.Options{})
+	handler := NewBreedHandler(mockRepo, &MockReferences{}, &MockSizes{}, &MockNames{}, 100, logger)
+
+	req := httptest.NewRequest("GET", "/breeds?lang=fr", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetAllBreeds(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("attendu 200, obtenu %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), `"display_name":"Colley à poil long"`) {
+		t.Errorf("display_name traduit attendu, obtenu %s", w.Body.String())
+	}
+
+	req = httptest.NewRequest("GET", "/breeds", nil)
+	w = httptest.NewRecorder()
+
+	handler.GetAllBreeds(w, req)
+
+	if strings.Contains(w.Body.String(), "display_name") {
+		t.Errorf("display_name ne doit figurer qu'avec ?lang, obtenu %s", w.Body.String())
+	}
+
+	req = httptest.NewRequest("GET", "/breeds?lang=de", nil)
+	w = httptest.NewRecorder()
+
+	handler.GetAllBreeds(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("attendu 400 pour une langue non prise en charge, obtenu %d", w.Code)
+	}
+}
+
+func TestResolveBreed(t *testing.T) {
+	mockRepo := &MockBreedRepo{}
+	logger := log.NewWithOptions(nil, log.Options{})
+	handler := NewBreedHandler(mockRepo, &MockReferences{}, &MockSizes{}, &MockNames{}, 100, logger)
+
+	req := httptest.NewRequest("GET", "/breeds/resolve?name=Yorkie", nil)
+	w := httptest.NewRecorder()
+
+	handler.ResolveBreed(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("attendu 200, obtenu %d: %s", w.Code, w.Body.String())
+	}
+	if !strings.Contains(w.Body.String(), `"name":"yorkshire_terrier"`) {
+		t.Errorf("race canonique attendue, obtenu %s", w.Body.String())
+	}
+
+	req = httptest.NewRequest("GET", "/breeds/resolve?name=Labradoodle", nil)
+	w = httptest.NewRecorder()
+
+	handler.ResolveBreed(w, req)
+
+	if w.Code != http.StatusNotFound {
+		t.Errorf("attendu 404 pour un nom inconnu, obtenu %d", w.Code)
+	}
+
+	req = httptest.NewRequest("GET", "/breeds/resolve", nil)
+	w = httptest.NewRecorder()
+
+	handler.ResolveBreed(w, req)
+
+	if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), `"field":"name"`) {
+		t.Errorf("attendu 400 sur le paramètre name, obtenu %d: %s", w.Code, w.Body.String())
+	}
+}
+
+func TestGetAllBreeds_NameSearch(t *testing.T) {
+	mockRepo := &MockBreedRepo{}
+	logger := log.NewWithOptions(nil, log.Options{})
+	handler := NewBreedHandler(mockRepo, &MockReferences{}, &MockSizes{}, &MockNames{}, 100, logger)
+
+	req := httptest.NewRequest("GET", "/breeds?name=Yorkshire%20Terrier", nil)
+	w := httptest.NewRecorder()
+
+	handler.GetAllBreeds(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("attendu 200, obtenu %d", w.Code)
+	}
+	if mockRepo.lastFilter.Name != "yorkshire_terrier" {
+		t.Errorf("nom normalisé attendu, obtenu %q", mockRepo.lastFilter.Name)
+	}
+}
+
+type replicaReads struct {
+	replica *sql.DB
+}
+
+func (r replicaReads) Reader(ctx context.Context) *sql.DB { return r.replica }
+
+func (r replicaReads) Failed(db *sql.DB, err error) {}
+
+type MockTranslations struct {
+	repository.BreedTranslationRepositoryInterface
+	saved *repository.BreedTranslation
+}
+
+func (m *MockTranslations) Upsert(translation *repository.BreedTranslation) error {
+	m.saved = translation
+	return nil
+}
+
+// Une race qui vient d'être créée peut manquer sur une réplique en retard :
+// la vérification qui précède une écriture rattachée lit la base principale
+func TestPutTranslation_ChecksBreedOnPrimary(t *testing.T) {
+	primary, primaryMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Erreur lors de la création du mock: %v", err)
+	}
+	defer primary.Close()
+	replica, replicaMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Erreur lors de la création du mock: %v", err)
+	}
+	defer replica.Close()
+
+	primaryMock.ExpectQuery("FROM breeds WHERE id = ?").WithArgs(7).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "species", "pet_size", "name", "average_male_adult_weight", "average_female_adult_weight"}).
+			AddRow(7, "dog", "small", "affenpinscher", 6000, 5000))
+
+	breeds := repository.NewReplicatedBreedRepository(primary, replicaReads{replica: replica})
+	translations := &MockTranslations{}
+	handler := NewBreedTranslationHandler(breeds, translations, log.NewWithOptions(nil, log.Options{}))
+
+	req := httptest.NewRequest("PUT", "/breeds/7/translations/fr", strings.NewReader(`{"display_name": "Affenpinscher"}`))
+	req = mux.SetURLVars(req, map[string]string{"id": "7", "locale": "fr"})
+	w := httptest.NewRecorder()
+
+	handler.Put(w, req)
+
+	if w.Code != http.StatusOK || translations.saved == nil {
+		t.Fatalf("attendu 200 et la traduction enregistrée, obtenu %d %s", w.Code, w.Body.String())
+	}
+	if err := primaryMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("la race doit être lue sur la base principale: %v", err)
+	}
+	if err := replicaMock.ExpectationsWereMet(); err != nil {
+		t.Errorf("aucune requête attendue sur la réplique: %v", err)
+	}
+}
+
+type MockThresholds struct {
+	repository.SizeThresholdRepositoryInterface
+	replaced []repository.SizeThreshold
+}
+
+func (m *MockThresholds) ReplaceForSpecies(species string, thresholds []repository.SizeThreshold) error {
+	m.replaced = thresholds
+	return nil
+}
+
+func TestReplaceSizeClassification_Validation(t *testing.T) {
+	cases := []struct {
+		name  string
+		body  string
+		field string
+	}{
+		{"taille en double", `[{"pet_size": "small", "max_weight": 10000}, {"pet_size": " Small ", "max_weight": 20000}, {"pet_size": "tall"}]`, `"field":"[1].pet_size"`},
+		{"seuils non croissants", `[{"pet_size": "small", "max_weight": 20000}, {"pet_size": "medium", "max_weight": 10000}, {"pet_size": "tall"}]`, `"field":"[1].max_weight"`},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			repo := &MockThresholds{}
+			handler := NewSizeClassificationHandler(repo, &MockReferences{}, log.NewWithOptions(nil, log.Options{}))
+
+			req := httptest.NewRequest("PUT", "/size-classification/dog", strings.NewReader(c.body))
+			req = mux.SetURLVars(req, map[string]string{"species": "dog"})
+			w := httptest.NewRecorder()
+
+			handler.Replace(w, req)
+
+			if w.Code != http.StatusBadRequest || !strings.Contains(w.Body.String(), c.field) {
+				t.Errorf("attendu 400 sur %s, obtenu %d %s", c.field, w.Code, w.Body.String())
+			}
+			if repo.replaced != nil {
+				t.Error("les seuils ne doivent pas être enregistrés")
+			}
+		})
+	}
+}
+
+func TestReplaceSizeClassification_Units(t *testing.T) {
+	repo := &MockThresholds{}
+	handler := NewSizeClassificationHandler(repo, &MockReferences{}, log.NewWithOptions(nil, log.Options{}))
+
+	body := `[{"pet_size": "small", "max_weight": "6kg"}, {"pet_size": "medium", "max_weight": 20}, {"pet_size": "tall", "max_weight": null}]`
+	req := httptest.NewRequest("PUT", "/size-classification/dog", strings.NewReader(body))
+	req.Header.Set(WeightUnitHeader, "kg")
+	req = mux.SetURLVars(req, map[string]string{"species": "dog"})
+	w := httptest.NewRecorder()
+
+	handler.Replace(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("attendu 200, obtenu %d %s", w.Code, w.Body.String())
+	}
+	if len(repo.replaced) != 3 || *repo.replaced[0].MaxWeight != 6000 || *repo.replaced[1].MaxWeight != 20000 || repo.replaced[2].MaxWeight != nil {
+		t.Errorf("seuils attendus en grammes (6000, 20000, sans limite), obtenu %+v", repo.replaced)
+	}
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/i18n"
+	"github.com/japhy-tech/backend-test/internal/problem"
+	"github.com/japhy-tech/backend-test/internal/repository"
+	"github.com/japhy-tech/backend-test/internal/service"
+)
+
+// BreedTranslationHandler gère les noms d'affichage traduits des races
+type BreedTranslationHandler struct {
+	breeds repository.BreedRepositoryInterface
+	repo   repository.BreedTranslationRepositoryInterface
+	logger *charmLog.Logger
+}
+
+// NewBreedTranslationHandler crée un nouveau handler
+func NewBreedTranslationHandler(breeds repository.BreedRepositoryInterface, repo repository.BreedTranslationRepositoryInterface, logger *charmLog.Logger) *BreedTranslationHandler {
+	return &BreedTranslationHandler{
+		breeds: breeds,
+		repo:   repo,
+		logger: logger,
+	}
+}
+
+// BreedTranslationRequest représente le nom d'affichage d'une race dans une langue
+type BreedTranslationRequest struct {
+	DisplayName string `json:"display_name"`
+}
+
+// List récupère les traductions d'une race
+// GET /breeds/{id}/translations
+func (h *BreedTranslationHandler) List(w http.ResponseWriter, r *http.Request) {
+	breed, ok := existingBreed(w, r, h.breeds, h.logger)
+	if !ok {
+		return
+	}
+	id := breed.ID
+
+	translations, err := h.repo.ListForBreed(id)
+	if err != nil {
+		sendInternalError(w, r, h.logger, "Erreur lors de la récupération des traductions", err, "id", id)
+		return
+	}
+
+	sendSuccessResponse(w, r, http.StatusOK, translations, "")
+}
+
+// Put crée ou remplace la traduction d'une race dans une langue
+// PUT /breeds/{id}/translations/{locale}
+func (h *BreedTranslationHandler) Put(w http.ResponseWriter, r *http.Request) {
+	var req BreedTranslationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendProblem(w, r, http.StatusBadRequest, problem.CodeInvalidBody, i18n.FromDecodeError(err))
+		return
+	}
+
+	var errs problem.FieldErrors
+	locale, err := service.NormalizeLocale(mux.Vars(r)["locale"])
+	if err != nil {
+		errs = append(errs, problem.NewFieldError("locale", mux.Vars(r)["locale"], err))
+	}
+	displayName := strings.TrimSpace(req.DisplayName)
+	if displayName == "" {
+		errs.Add("display_name", "", i18n.FieldRequired)
+	}
+	if len(errs) > 0 {
+		sendValidationProblem(w, r, errs)
+		return
+	}
+
+	breed, ok := existingBreed(w, r, h.breeds, h.logger)
+	if !ok {
+		return
+	}
+	id := breed.ID
+
+	translation := &repository.BreedTranslation{BreedID: id, Locale: locale, DisplayName: displayName}
+	if err := h.repo.Upsert(translation); err != nil {
+		sendRepositoryError(w, r, h.logger, problem.CodeBreedNotFound, "Erreur lors de l'enregistrement de la traduction", err, "id", id, "locale", locale)
+		return
+	}
+
+	sendSuccessResponse(w, r, http.StatusOK, translation, i18n.TranslationSaved)
+}
+
+// Delete supprime la traduction d'une race dans une langue ; le nom
+// d'affichage redevient l'identifiant humanisé
+// DELETE /breeds/{id}/translations/{locale}
+func (h *BreedTranslationHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	locale, err := service.NormalizeLocale(mux.Vars(r)["locale"])
+	if err != nil {
+		sendValidationProblem(w, r, problem.FieldErrors{problem.NewFieldError("locale", mux.Vars(r)["locale"], err)})
+		return
+	}
+
+	breed, ok := existingBreed(w, r, h.breeds, h.logger)
+	if !ok {
+		return
+	}
+	id := breed.ID
+
+	if err := h.repo.Delete(id, locale); err != nil {
+		sendRepositoryError(w, r, h.logger, problem.CodeTranslationNotFound, "Erreur lors de la suppression de la traduction", err, "id", id, "locale", locale)
+		return
+	}
+
+	sendSuccessResponse(w, r, http.StatusOK, nil, i18n.TranslationDeleted)
+}
+
+// existingBreed lit l'ID de la route et charge la race, pour les ressources
+// qui lui sont rattachées (traductions, alias) ; elle est lue sur la base
+// principale, comme ces ressources, pour qu'une race qui vient d'être créée
+// ne soit pas introuvable sur une réplique en retard
+func existingBreed(w http.ResponseWriter, r *http.Request, breeds repository.BreedRepositoryInterface, logger *charmLog.Logger) (*repository.Breed, bool) {
+	id, err := strconv.Atoi(mux.Vars(r)["id"])
+	if err != nil {
+		sendProblem(w, r, http.StatusBadRequest, problem.CodeInvalidID, i18n.New(i18n.InvalidID))
+		return nil, false
+	}
+
+	breed, err := breeds.GetByID(repository.ReadPrimary(r.Context()), id)
+	if err != nil {
+		sendRepositoryError(w, r, logger, problem.CodeBreedNotFound, "Erreur lors de la vérification de la race", err, "id", id)
+		return nil, false
+	}
+
+	return breed, true
+}
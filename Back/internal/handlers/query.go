@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/japhy-tech/backend-test/internal/i18n"
+	"github.com/japhy-tech/backend-test/internal/problem"
+)
+
+// ParamType est le type attendu d'un paramètre de requête
+type ParamType int
+
+const (
+	ParamString ParamType = iota
+	ParamInt
+	ParamFloat
+	ParamEnum
+)
+
+// QueryParam déclare un paramètre de requête accepté par un handler
+type QueryParam struct {
+	Name string
+	Type ParamType
+	// Enum liste les valeurs autorisées d'un ParamEnum
+	Enum []string
+	// Min et Max bornent (inclusivement) un ParamInt ou un ParamFloat
+	Min *float64
+	Max *float64
+	// Default est utilisé quand le paramètre est absent ou vide
+	Default string
+	// Required rejette la requête si le paramètre est absent ou vide
+	Required bool
+}
+
+// QuerySpec est la liste des paramètres acceptés par un handler ; tout autre
+// paramètre est rejeté
+type QuerySpec struct {
+	params []QueryParam
+}
+
+func NewQuerySpec(params ...QueryParam) QuerySpec {
+	return QuerySpec{params: params}
+}
+
+// Query contient les paramètres validés, valeurs par défaut appliquées
+type Query struct {
+	values map[string]string
+}
+
+// Bind valide tous les paramètres et retourne l'ensemble des erreurs plutôt
+// que de s'arrêter à la première
+func (s QuerySpec) Bind(values url.Values) (Query, problem.FieldErrors) {
+	query := Query{values: map[string]string{}}
+	var errs problem.FieldErrors
+
+	known := map[string]bool{}
+	for _, param := range s.params {
+		known[param.Name] = true
+
+		raw := values[param.Name]
+		if len(raw) > 1 {
+			errs.Add(param.Name, strings.Join(raw, ","), i18n.ParamRepeated)
+			continue
+		}
+
+		value := ""
+		if len(raw) == 1 {
+			value = strings.TrimSpace(raw[0])
+		}
+		if value == "" {
+			value = param.Default
+		}
+		if value == "" {
+			if param.Required {
+				errs.Add(param.Name, "", i18n.ParamRequired)
+			}
+			continue
+		}
+
+		if message := param.check(value); message != nil {
+			errs = append(errs, problem.NewFieldError(param.Name, value, message))
+			continue
+		}
+		query.values[param.Name] = value
+	}
+
+	unknown := []string{}
+	for name := range values {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		errs.Add(name, values.Get(name), i18n.ParamUnknown)
+	}
+
+	return query, errs
+}
+
+func (p QueryParam) check(value string) *i18n.Message {
+	switch p.Type {
+	case ParamInt:
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			return i18n.New(i18n.IntegerExpected)
+		}
+		return p.checkRange(float64(number))
+	case ParamFloat:
+		// ParseFloat accepte NaN et Inf, que les bornes ne rejettent pas
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+			return i18n.New(i18n.NumberExpected)
+		}
+		return p.checkRange(number)
+	case ParamEnum:
+		for _, allowed := range p.Enum {
+			if value == allowed {
+				return nil
+			}
+		}
+		return i18n.New(i18n.AllowedValues, strings.Join(p.Enum, ", "))
+	}
+	return nil
+}
+
+func (p QueryParam) checkRange(number float64) *i18n.Message {
+	if p.Min != nil && number < *p.Min {
+		return i18n.New(i18n.MinBound, formatBound(*p.Min))
+	}
+	if p.Max != nil && number > *p.Max {
+		return i18n.New(i18n.MaxBound, formatBound(*p.Max))
+	}
+	return nil
+}
+
+func formatBound(bound float64) string {
+	return strconv.FormatFloat(bound, 'f', -1, 64)
+}
+
+// Bound est un raccourci pour renseigner QueryParam.Min et QueryParam.Max
+func Bound(value float64) *float64 {
+	return &value
+}
+
+// String retourne la valeur validée, vide si absente
+func (q Query) String(name string) string {
+	return q.values[name]
+}
+
+// Int retourne la valeur validée et si elle était présente
+func (q Query) Int(name string) (int, bool) {
+	value, ok := q.values[name]
+	if !ok {
+		return 0, false
+	}
+	number, _ := strconv.Atoi(value)
+	return number, true
+}
+
+// Float retourne la valeur validée et si elle était présente
+func (q Query) Float(name string) (float64, bool) {
+	value, ok := q.values[name]
+	if !ok {
+		return 0, false
+	}
+	number, _ := strconv.ParseFloat(value, 64)
+	return number, true
+}
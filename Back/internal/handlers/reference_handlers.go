@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/i18n"
+	"github.com/japhy-tech/backend-test/internal/problem"
+	"github.com/japhy-tech/backend-test/internal/repository"
+	"github.com/japhy-tech/backend-test/internal/service"
+)
+
+// ReferenceHandler gère les requêtes HTTP pour une table de référence
+// (espèces ou tailles)
+type ReferenceHandler struct {
+	repo     repository.ReferenceRepositoryInterface
+	notFound problem.Code
+	logger   *charmLog.Logger
+}
+
+// NewReferenceHandler crée un handler de données de référence ; notFound est
+// le code d'erreur renvoyé quand le code demandé n'existe pas
+func NewReferenceHandler(repo repository.ReferenceRepositoryInterface, notFound problem.Code, logger *charmLog.Logger) *ReferenceHandler {
+	return &ReferenceHandler{
+		repo:     repo,
+		notFound: notFound,
+		logger:   logger,
+	}
+}
+
+// ReferenceRequest représente la requête pour créer ou modifier une valeur
+type ReferenceRequest struct {
+	Code  string `json:"code"`
+	Label string `json:"label"`
+}
+
+// List récupère toutes les valeurs
+// GET /species
+func (h *ReferenceHandler) List(w http.ResponseWriter, r *http.Request) {
+	values, err := h.repo.List()
+	if err != nil {
+		sendInternalError(w, r, h.logger, "Erreur lors de la récupération des données de référence", err)
+		return
+	}
+
+	sendSuccessResponse(w, r, http.StatusOK, values, "")
+}
+
+// Get récupère une valeur par son code
+// GET /species/{code}
+func (h *ReferenceHandler) Get(w http.ResponseWriter, r *http.Request) {
+	code := codeParam(r)
+
+	value, err := h.repo.GetByCode(code)
+	if err != nil {
+		sendRepositoryError(w, r, h.logger, h.notFound, "Erreur lors de la récupération de la donnée de référence", err, "code", code)
+		return
+	}
+
+	sendSuccessResponse(w, r, http.StatusOK, value, "")
+}
+
+// Create crée une nouvelle valeur
+// POST /species
+func (h *ReferenceHandler) Create(w http.ResponseWriter, r *http.Request) {
+	var req ReferenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendProblem(w, r, http.StatusBadRequest, problem.CodeInvalidBody, i18n.FromDecodeError(err))
+		return
+	}
+
+	code := service.NormalizeReference(req.Code)
+	var errs problem.FieldErrors
+	if code == "" {
+		errs.Add("code", "", i18n.FieldRequired)
+	}
+	if req.Label == "" {
+		errs.Add("label", "", i18n.FieldRequired)
+	}
+	if len(errs) > 0 {
+		sendValidationProblem(w, r, errs)
+		return
+	}
+
+	value, err := h.repo.Create(&repository.ReferenceValue{Code: code, Label: req.Label})
+	if err != nil {
+		sendRepositoryError(w, r, h.logger, h.notFound, "Erreur lors de la création de la donnée de référence", err, "code", code)
+		return
+	}
+
+	sendSuccessResponse(w, r, http.StatusCreated, value, i18n.ValueCreated)
+}
+
+// Update modifie une valeur existante
+// PUT /species/{code}
+func (h *ReferenceHandler) Update(w http.ResponseWriter, r *http.Request) {
+	code := codeParam(r)
+
+	var req ReferenceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendProblem(w, r, http.StatusBadRequest, problem.CodeInvalidBody, i18n.FromDecodeError(err))
+		return
+	}
+
+	if _, err := h.repo.GetByCode(code); err != nil {
+		sendRepositoryError(w, r, h.logger, h.notFound, "Erreur lors de la vérification de la donnée de référence", err, "code", code)
+		return
+	}
+
+	value, err := h.repo.Update(code, &repository.ReferenceValue{
+		Code:  service.NormalizeReference(req.Code),
+		Label: req.Label,
+	})
+	if err != nil {
+		sendRepositoryError(w, r, h.logger, h.notFound, "Erreur lors de la mise à jour de la donnée de référence", err, "code", code)
+		return
+	}
+
+	sendSuccessResponse(w, r, http.StatusOK, value, i18n.ValueUpdated)
+}
+
+// Delete supprime une valeur
+// DELETE /species/{code}
+func (h *ReferenceHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	code := codeParam(r)
+
+	// La clé étrangère fait échouer la suppression (409) si des races l'utilisent
+	if err := h.repo.Delete(code); err != nil {
+		sendRepositoryError(w, r, h.logger, h.notFound, "Erreur lors de la suppression de la donnée de référence", err, "code", code)
+		return
+	}
+
+	sendSuccessResponse(w, r, http.StatusOK, nil, i18n.ValueDeleted)
+}
+
+// codeParam lit le code du chemin, normalisé comme à la création : /species/Cat
+// désigne l'espèce "cat"
+func codeParam(r *http.Request) string {
+	return service.NormalizeReference(mux.Vars(r)["code"])
+}
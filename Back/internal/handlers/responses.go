@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/i18n"
+	"github.com/japhy-tech/backend-test/internal/logging"
+	"github.com/japhy-tech/backend-test/internal/problem"
+)
+
+type SuccessResponse struct {
+	Data    interface{} `json:"data,omitempty"`
+	Message string      `json:"message,omitempty"`
+	Meta    interface{} `json:"meta,omitempty"`
+}
+
+// Fonctions utilitaires pour les réponses HTTP ; le message est traduit dans
+// la langue négociée avec Accept-Language (aucun message si la clé est vide)
+func sendSuccessResponse(w http.ResponseWriter, r *http.Request, statusCode int, data interface{}, message i18n.Key) {
+	lang := i18n.FromRequest(r)
+	response := SuccessResponse{Data: data}
+	if message != "" {
+		response.Message = i18n.T(lang, message)
+	}
+
+	i18n.SetContentLanguage(w, lang)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(response)
+}
+
+func sendListResponse(w http.ResponseWriter, r *http.Request, statusCode int, data interface{}, meta interface{}) {
+	i18n.SetContentLanguage(w, i18n.FromRequest(r))
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(SuccessResponse{
+		Data: data,
+		Meta: meta,
+	})
+}
+
+// sendProblem renvoie une erreur au format application/problem+json
+func sendProblem(w http.ResponseWriter, r *http.Request, statusCode int, code problem.Code, detail i18n.Localizer) {
+	problem.Write(w, r, problem.Localized(statusCode, code, detail))
+}
+
+// sendValidationProblem renvoie une 400 listant chaque champ ou paramètre invalide
+func sendValidationProblem(w http.ResponseWriter, r *http.Request, errs problem.FieldErrors) {
+	problem.Write(w, r, problem.Validation(errs))
+}
+
+// sendInternalError logge l'erreur avec l'identifiant de requête et renvoie
+// une 500 dont le détail ne révèle rien de l'erreur d'origine
+func sendInternalError(w http.ResponseWriter, r *http.Request, logger *charmLog.Logger, message string, err error, keyvals ...interface{}) {
+	keyvals = append(keyvals, "error", err)
+	logging.FromContext(r.Context(), logger).Error(message, keyvals...)
+	problem.Write(w, r, problem.Internal())
+}
+
+// sendRepositoryError renvoie le statut correspondant à une erreur typée des
+// repositories (404, 409, 400) et traite toute autre erreur comme interne
+func sendRepositoryError(w http.ResponseWriter, r *http.Request, logger *charmLog.Logger, notFound problem.Code, message string, err error, keyvals ...interface{}) {
+	if details := problem.FromRepositoryError(err, notFound); details != nil {
+		problem.Write(w, r, details)
+		return
+	}
+	sendInternalError(w, r, logger, message, err, keyvals...)
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/i18n"
+	"github.com/japhy-tech/backend-test/internal/problem"
+	"github.com/japhy-tech/backend-test/internal/repository"
+	"github.com/japhy-tech/backend-test/internal/units"
+)
+
+// SizeClassificationHandler gère les seuils de poids utilisés pour déduire
+// la taille d'une race
+type SizeClassificationHandler struct {
+	repo       repository.SizeThresholdRepositoryInterface
+	references ReferenceProvider
+	logger     *charmLog.Logger
+}
+
+// NewSizeClassificationHandler crée un nouveau handler
+func NewSizeClassificationHandler(repo repository.SizeThresholdRepositoryInterface, references ReferenceProvider, logger *charmLog.Logger) *SizeClassificationHandler {
+	return &SizeClassificationHandler{
+		repo:       repo,
+		references: references,
+		logger:     logger,
+	}
+}
+
+// SizeThresholdRequest représente un seuil : la taille s'applique jusqu'à
+// max_weight inclus, ou sans limite si max_weight est absent. Comme les poids
+// des races, max_weight est un nombre dans l'unité de l'en-tête Weight-Unit
+// (grammes par défaut) ou une chaîne portant son unité ("6kg")
+type SizeThresholdRequest struct {
+	PetSize   string        `json:"pet_size"`
+	MaxWeight *units.Weight `json:"max_weight"`
+}
+
+// List récupère les seuils de toutes les espèces
+// GET /size-classification
+func (h *SizeClassificationHandler) List(w http.ResponseWriter, r *http.Request) {
+	thresholds, err := h.repo.List()
+	if err != nil {
+		sendInternalError(w, r, h.logger, "Erreur lors de la récupération des seuils de taille", err)
+		return
+	}
+
+	sendSuccessResponse(w, r, http.StatusOK, thresholds, "")
+}
+
+// Replace remplace les seuils d'une espèce, du plus léger au plus lourd ; les
+// seuils enregistrés sont renvoyés en grammes, comme par List
+// PUT /size-classification/{species}
+func (h *SizeClassificationHandler) Replace(w http.ResponseWriter, r *http.Request) {
+	unit, err := requestUnit(r)
+	if err != nil {
+		sendValidationProblem(w, r, problem.FieldErrors{problem.NewFieldError(WeightUnitHeader, r.Header.Get(WeightUnitHeader), err)})
+		return
+	}
+
+	var req []SizeThresholdRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		sendProblem(w, r, http.StatusBadRequest, problem.CodeInvalidBody, i18n.FromDecodeError(err))
+		return
+	}
+
+	refs, err := h.references.Snapshot()
+	if err != nil {
+		sendInternalError(w, r, h.logger, "Erreur lors du chargement des données de référence", err)
+		return
+	}
+
+	species, err := refs.NormalizeSpecies(mux.Vars(r)["species"])
+	if err != nil {
+		sendValidationProblem(w, r, problem.FieldErrors{problem.NewFieldError("species", mux.Vars(r)["species"], err)})
+		return
+	}
+
+	thresholds := make([]repository.SizeThreshold, 0, len(req))
+	previous := 0
+	// Une taille ne peut avoir qu'un seuil par espèce
+	seen := map[string]int{}
+	for i, item := range req {
+		petSize, err := refs.NormalizePetSize(item.PetSize)
+		if err != nil {
+			sendValidationProblem(w, r, problem.FieldErrors{problem.NewFieldError(fmt.Sprintf("[%d].pet_size", i), item.PetSize, err)})
+			return
+		}
+		if first, ok := seen[petSize]; ok {
+			var errs problem.FieldErrors
+			errs.Add(fmt.Sprintf("[%d].pet_size", i), item.PetSize, i18n.ThresholdDuplicate, first)
+			sendValidationProblem(w, r, errs)
+			return
+		}
+		seen[petSize] = i
+
+		if item.MaxWeight == nil && i != len(req)-1 {
+			var errs problem.FieldErrors
+			errs.Add(fmt.Sprintf("[%d].max_weight", i), "", i18n.ThresholdUnbounded)
+			sendValidationProblem(w, r, errs)
+			return
+		}
+		var maxWeight *int
+		if item.MaxWeight != nil {
+			grams := item.MaxWeight.Grams(unit)
+			if grams <= previous {
+				var errs problem.FieldErrors
+				errs.Add(fmt.Sprintf("[%d].max_weight", i), strconv.Itoa(grams), i18n.ThresholdIncreasing, petSize)
+				sendValidationProblem(w, r, errs)
+				return
+			}
+			previous = grams
+			maxWeight = &grams
+		}
+
+		thresholds = append(thresholds, repository.SizeThreshold{
+			Species:   species,
+			PetSize:   petSize,
+			MaxWeight: maxWeight,
+		})
+	}
+
+	if err := h.repo.ReplaceForSpecies(species, thresholds); err != nil {
+		sendRepositoryError(w, r, h.logger, problem.CodeSpeciesNotFound, "Erreur lors de la mise à jour des seuils de taille", err, "species", species)
+		return
+	}
+
+	sendSuccessResponse(w, r, http.StatusOK, thresholds, i18n.ThresholdsUpdated)
+}
@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/japhy-tech/backend-test/internal/i18n"
+	"github.com/japhy-tech/backend-test/internal/problem"
+	"github.com/japhy-tech/backend-test/internal/repository"
+	"github.com/japhy-tech/backend-test/internal/units"
+)
+
+// WeightUnitHeader indique l'unité des poids nus d'une requête d'écriture,
+// et celle des poids renvoyés en l'absence de ?unit
+const WeightUnitHeader = "Weight-Unit"
+
+// BreedResponse représente une race avec ses poids exprimés dans l'unité
+// demandée par le client
+type BreedResponse struct {
+	ID                       int        `json:"id"`
+	Species                  string     `json:"species"`
+	PetSize                  string     `json:"pet_size"`
+	Name                     string     `json:"name"`
+	DisplayName              string     `json:"display_name,omitempty"`
+	AverageMaleAdultWeight   float64    `json:"average_male_adult_weight"`
+	AverageFemaleAdultWeight float64    `json:"average_female_adult_weight"`
+	WeightUnit               units.Unit `json:"weight_unit"`
+}
+
+func newBreedResponse(breed *repository.Breed, unit units.Unit) BreedResponse {
+	return BreedResponse{
+		ID:                       breed.ID,
+		Species:                  breed.Species,
+		PetSize:                  breed.PetSize,
+		Name:                     breed.Name,
+		AverageMaleAdultWeight:   unit.FromGrams(breed.AverageMaleAdultWeight),
+		AverageFemaleAdultWeight: unit.FromGrams(breed.AverageFemaleAdultWeight),
+		WeightUnit:               unit,
+	}
+}
+
+func newBreedResponses(breeds []repository.Breed, unit units.Unit) []BreedResponse {
+	responses := make([]BreedResponse, 0, len(breeds))
+	for i := range breeds {
+		responses = append(responses, newBreedResponse(&breeds[i], unit))
+	}
+	return responses
+}
+
+// responseUnit lit l'unité des poids renvoyés : paramètre ?unit, déjà
+// validé, sinon l'en-tête Weight-Unit, sinon les grammes
+func responseUnit(r *http.Request, query Query) (units.Unit, error) {
+	if value := query.String("unit"); value != "" {
+		return units.ParseUnit(value)
+	}
+	return requestUnit(r)
+}
+
+// requestUnit lit l'unité appliquée aux poids nus du corps de la requête
+func requestUnit(r *http.Request) (units.Unit, error) {
+	return units.ParseUnit(r.Header.Get(WeightUnitHeader))
+}
+
+// checkWeightBounds rejette les poids au-delà de units.MaxPlausibleGrams :
+// un nombre nu est borné à la lecture du JSON, mais peut encore dépasser la
+// limite une fois converti depuis l'unité de l'en-tête Weight-Unit
+func checkWeightBounds(errs *problem.FieldErrors, maleWeight, femaleWeight int) {
+	maximum := strconv.Itoa(units.MaxPlausibleGrams) + " g"
+	if maleWeight > units.MaxPlausibleGrams {
+		errs.Add("average_male_adult_weight", "", i18n.MaxBound, maximum)
+	}
+	if femaleWeight > units.MaxPlausibleGrams {
+		errs.Add("average_female_adult_weight", "", i18n.MaxBound, maximum)
+	}
+}
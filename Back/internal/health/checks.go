@@ -0,0 +1,31 @@
+package health
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/japhy-tech/backend-test/internal/repository"
+)
+
+// Database vérifie que la base répond
+func Database(repo repository.SchemaRepositoryInterface) Check {
+	return Check{Name: "database", Run: repo.Ping}
+}
+
+// Migrations vérifie que le schéma est à la version des migrations
+// embarquées dans le binaire, sans migration interrompue
+func Migrations(repo repository.SchemaRepositoryInterface, expected uint) Check {
+	return Check{Name: "migrations", Run: func(ctx context.Context) error {
+		version, dirty, err := repo.MigrationVersion(ctx)
+		if err != nil {
+			return err
+		}
+		if dirty {
+			return fmt.Errorf("la migration %d s'est interrompue (dirty)", version)
+		}
+		if version != expected {
+			return fmt.Errorf("schéma en version %d, %d attendue", version, expected)
+		}
+		return nil
+	}}
+}
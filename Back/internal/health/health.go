@@ -0,0 +1,141 @@
+// Package health expose les sondes de vie (/livez) et de disponibilité
+// (/readyz) de l'API
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"time"
+)
+
+// ErrShuttingDown signale une instance en cours d'arrêt, qui ne doit plus
+// recevoir de trafic
+var ErrShuttingDown = errors.New("arrêt en cours")
+
+// Check est une dépendance vérifiée par la sonde de disponibilité
+type Check struct {
+	Name string
+	Run  func(ctx context.Context) error
+}
+
+// Result est l'issue d'une vérification
+type Result struct {
+	Name    string  `json:"name"`
+	Status  string  `json:"status"`
+	Latency float64 `json:"latency_ms"`
+	Error   string  `json:"error,omitempty"`
+}
+
+// Report est la réponse des sondes
+type Report struct {
+	Status string   `json:"status"`
+	Checks []Result `json:"checks,omitempty"`
+}
+
+const (
+	statusOK   = "ok"
+	statusFail = "fail"
+)
+
+// Checker exécute les vérifications de disponibilité
+type Checker struct {
+	checks       []Check
+	timeout      time.Duration
+	shuttingDown atomic.Bool
+}
+
+// NewChecker crée les sondes ; chaque vérification est bornée par timeout,
+// s'il n'est pas nul
+func NewChecker(timeout time.Duration, checks ...Check) *Checker {
+	return &Checker{
+		checks:  checks,
+		timeout: timeout,
+	}
+}
+
+// Shutdown fait échouer la sonde de disponibilité, pour que l'instance soit
+// retirée du trafic pendant l'arrêt
+func (c *Checker) Shutdown() {
+	c.shuttingDown.Store(true)
+}
+
+// Ready exécute toutes les vérifications, en parallèle
+func (c *Checker) Ready(ctx context.Context) Report {
+	report := Report{Status: statusOK, Checks: make([]Result, len(c.checks))}
+
+	done := make(chan struct{})
+	for i, check := range c.checks {
+		go func(i int, check Check) {
+			report.Checks[i] = c.run(ctx, check)
+			done <- struct{}{}
+		}(i, check)
+	}
+	for range c.checks {
+		<-done
+	}
+
+	for _, result := range report.Checks {
+		if result.Status != statusOK {
+			report.Status = statusFail
+		}
+	}
+	if c.shuttingDown.Load() {
+		report.Status = statusFail
+		report.Checks = append(report.Checks, Result{Name: "shutdown", Status: statusFail, Error: ErrShuttingDown.Error()})
+	}
+	return report
+}
+
+func (c *Checker) run(ctx context.Context, check Check) Result {
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+	err := check.Run(ctx)
+	result := Result{
+		Name:    check.Name,
+		Status:  statusOK,
+		Latency: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		result.Status = statusFail
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// LiveHandler répond tant que le processus sert des requêtes, sans vérifier
+// ses dépendances : une base indisponible ne justifie pas un redémarrage
+// GET /livez
+func (c *Checker) LiveHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeReport(w, http.StatusOK, Report{Status: statusOK})
+	})
+}
+
+// ReadyHandler répond 200 si toutes les dépendances sont disponibles, 503
+// sinon ou pendant l'arrêt
+// GET /readyz
+func (c *Checker) ReadyHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		report := c.Ready(r.Context())
+		status := http.StatusOK
+		if report.Status != statusOK {
+			status = http.StatusServiceUnavailable
+		}
+		writeReport(w, status, report)
+	})
+}
+
+func writeReport(w http.ResponseWriter, status int, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(report)
+}
@@ -0,0 +1,96 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type mockSchema struct {
+	pingErr error
+	version uint
+	dirty   bool
+}
+
+func (m *mockSchema) Ping(ctx context.Context) error {
+	return m.pingErr
+}
+
+func (m *mockSchema) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	return m.version, m.dirty, nil
+}
+
+func ready(t *testing.T, checker *Checker) (int, Report) {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	checker.ReadyHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	var report Report
+	if err := json.NewDecoder(w.Body).Decode(&report); err != nil {
+		t.Fatalf("%v", err)
+	}
+	return w.Code, report
+}
+
+func TestReady(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *mockSchema
+		status int
+		failed string
+	}{
+		{name: "disponible", schema: &mockSchema{version: 8}, status: http.StatusOK},
+		{name: "base indisponible", schema: &mockSchema{pingErr: errors.New("connexion refusée"), version: 8}, status: http.StatusServiceUnavailable, failed: "database"},
+		{name: "schéma en retard", schema: &mockSchema{version: 7}, status: http.StatusServiceUnavailable, failed: "migrations"},
+		{name: "migration interrompue", schema: &mockSchema{version: 8, dirty: true}, status: http.StatusServiceUnavailable, failed: "migrations"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := NewChecker(time.Second, Database(tc.schema), Migrations(tc.schema, 8))
+
+			status, report := ready(t, checker)
+
+			if status != tc.status || len(report.Checks) != 2 {
+				t.Fatalf("attendu %d avec 2 vérifications, obtenu %d %+v", tc.status, status, report)
+			}
+			for _, result := range report.Checks {
+				if (result.Name == tc.failed) != (result.Status == statusFail) || (result.Status == statusFail) != (result.Error != "") {
+					t.Errorf("vérification inattendue %+v", result)
+				}
+			}
+		})
+	}
+}
+
+func TestReady_Timeout(t *testing.T) {
+	checker := NewChecker(10*time.Millisecond, Check{Name: "lente", Run: func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}})
+
+	if status, report := ready(t, checker); status != http.StatusServiceUnavailable || report.Checks[0].Error != context.DeadlineExceeded.Error() {
+		t.Errorf("attendu un échec par dépassement du délai, obtenu %d %+v", status, report)
+	}
+}
+
+func TestShutdown(t *testing.T) {
+	checker := NewChecker(time.Second, Database(&mockSchema{}))
+	checker.Shutdown()
+
+	status, report := ready(t, checker)
+	if status != http.StatusServiceUnavailable || report.Checks[len(report.Checks)-1].Name != "shutdown" {
+		t.Errorf("attendu un échec pendant l'arrêt, obtenu %d %+v", status, report)
+	}
+
+	// L'instance reste en vie jusqu'à la fin de l'arrêt
+	w := httptest.NewRecorder()
+	checker.LiveHandler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/livez", nil))
+	if w.Code != http.StatusOK {
+		t.Errorf("attendu 200 pour /livez, obtenu %d", w.Code)
+	}
+}
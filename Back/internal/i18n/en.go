@@ -0,0 +1,106 @@
+package i18n
+
+var en = map[Key]string{
+	TitleValidationFailed:    "Invalid parameters",
+	TitleInvalidBody:         "Invalid request body",
+	TitleInvalidID:           "Invalid ID",
+	TitleInvalidCSV:          "Invalid CSV file",
+	TitleBreedNotFound:       "Breed not found",
+	TitleSpeciesNotFound:     "Species not found",
+	TitlePetSizeNotFound:     "Pet size not found",
+	TitleTranslationNotFound: "Translation not found",
+	TitleAliasNotFound:       "Alias not found",
+	TitleAliasConflict:       "Alias already in use",
+	TitleInvalidQuery:        "Invalid GraphQL query",
+	TitleQueryTooDeep:        "Query too deep",
+	TitleQueryTooComplex:     "Query too complex",
+	TitleRouteNotFound:       "Resource not found",
+	TitleMethodNotAllowed:    "Method not allowed",
+	TitleDuplicateName:       "Name already in use",
+	TitleConflict:            "Conflict with the resource state",
+	TitleUnauthenticated:     "Authentication required",
+	TitleForbidden:           "Access denied",
+	TitleAPIKeyNotFound:      "API key not found",
+	TitleRateLimited:         "Too many requests",
+	TitleInternal:            "Server error",
+
+	InternalDetail:     "An internal error occurred, try again later or contact support with the request ID",
+	InvalidID:          "The ID must be an integer",
+	DuplicateName:      "a resource with this name already exists",
+	StillReferenced:    "the resource is still in use",
+	InvalidData:        "invalid data",
+	QueryTooDeep:       "query depth %d exceeds the allowed maximum (%d)",
+	QueryTooComplex:    "query complexity %d exceeds the allowed maximum (%d)",
+	CredentialsMissing: "an API key (X-API-Key header) or a token (Authorization: Bearer) is required",
+	CredentialsInvalid: "invalid, expired or revoked API key or token",
+	RoleRequired:       "the '%s' role is required (current role: %s)",
+	RateLimited:        "limit of %d requests per %d s reached, retry in %d s",
+
+	FieldRequired:       "required field",
+	MustBePositive:      "must be greater than 0",
+	NothingToUpdate:     "no field to update",
+	ParamRepeated:       "parameter given more than once",
+	ParamUnknown:        "unknown parameter",
+	ParamRequired:       "required parameter",
+	IntegerExpected:     "integer expected",
+	NumberExpected:      "number expected",
+	AllowedValues:       "allowed values: %s",
+	MinBound:            "must be greater than or equal to %s",
+	MaxBound:            "must be less than or equal to %s",
+	UnknownValue:        "unknown value '%s' for %s (allowed values: %s)",
+	ThresholdUnbounded:  "only the last threshold may be unbounded",
+	ThresholdIncreasing: "must be strictly increasing (%s)",
+	ThresholdDuplicate:  "size already used by threshold %d",
+	PetSizeNotDerivable: "pet_size cannot be derived from the weights for species '%s'",
+	PetSizeInconsistent: "pet_size '%s' is inconsistent with the weights of %s (expected: %s)",
+	UnknownWeightUnit:   "unknown weight unit '%s' (allowed values: g, kg, lb)",
+	InvalidWeightJSON:   "invalid weight %s: number or string expected",
+	InvalidWeight:       "invalid weight '%s'",
+	WeightTooHigh:       "weight '%s' too high (maximum: %d g)",
+	AliasConflict:       "alias '%s' already refers to breed '%s'",
+	MaxLength:           "at most %s characters",
+
+	BodyEmpty:      "empty request body",
+	BodySyntax:     "invalid JSON at byte %d",
+	BodyTruncated:  "incomplete JSON",
+	BodyFieldType:  "invalid type for %s (got %s)",
+	BodyTooLarge:   "request body too large (maximum: %d bytes)",
+	BodyUnreadable: "unreadable request body",
+
+	CSVLine:             "line %d: %s",
+	CSVColumnCount:      "wrong number of columns (expected: %d, got: %d)",
+	CSVInvalidID:        "invalid ID '%s'",
+	CSVInvalidWeight:    "invalid %s '%s'",
+	CSVNegativeWeight:   "negative %s (%d)",
+	CSVWeightTooLow:     "%s %d too low for grams, CSV weights must be in grams (kilograms?)",
+	CSVWeightTooHigh:    "%s %d too high for grams (maximum: %d)",
+	CSVUnknownBreed:     "unknown breed '%s'",
+	CSVEmptyDisplayName: "empty display_name",
+	CSVEmptyAlias:       "empty alias",
+	MaleWeight:          "male weight",
+	FemaleWeight:        "female weight",
+
+	BreedCreated:               "Breed created successfully",
+	BreedUpdated:               "Breed updated successfully",
+	BreedDeleted:               "Breed deleted successfully",
+	ValueCreated:               "Value created successfully",
+	ValueUpdated:               "Value updated successfully",
+	ValueDeleted:               "Value deleted successfully",
+	ThresholdsUpdated:          "Size thresholds updated successfully",
+	ImportSucceeded:            "Breed import completed successfully",
+	TranslationSaved:           "Translation saved successfully",
+	TranslationDeleted:         "Translation deleted successfully",
+	TranslationImportSucceeded: "Translation import completed successfully",
+	AliasCreated:               "Alias created successfully",
+	AliasDeleted:               "Alias deleted successfully",
+	AliasImportSucceeded:       "Alias import completed successfully",
+	APIKeyCreated:              "Key created: store it now, it will not be shown again",
+	APIKeyRevoked:              "Key revoked successfully",
+
+	SemanticsMale:        "the average male weight is within [weight_min, weight_max]",
+	SemanticsFemale:      "the average female weight is within [weight_min, weight_max]",
+	SemanticsOverlap:     "the range between the known average male and female weights overlaps [weight_min, weight_max]",
+	SemanticsOverlapBoth: "both the average male and female weights are known and the range between them overlaps [weight_min, weight_max]",
+	SemanticsBoth:        "both the average male and female weights are within [weight_min, weight_max]",
+	SemanticsAny:         "at least one of the average male or female weights is within [weight_min, weight_max]",
+}
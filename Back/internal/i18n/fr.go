@@ -0,0 +1,106 @@
+package i18n
+
+var fr = map[Key]string{
+	TitleValidationFailed:    "Paramètres invalides",
+	TitleInvalidBody:         "Corps de requête invalide",
+	TitleInvalidID:           "ID invalide",
+	TitleInvalidCSV:          "Fichier CSV invalide",
+	TitleBreedNotFound:       "Race non trouvée",
+	TitleSpeciesNotFound:     "Espèce non trouvée",
+	TitlePetSizeNotFound:     "Taille non trouvée",
+	TitleTranslationNotFound: "Traduction non trouvée",
+	TitleAliasNotFound:       "Alias non trouvé",
+	TitleAliasConflict:       "Alias déjà utilisé",
+	TitleInvalidQuery:        "Requête GraphQL invalide",
+	TitleQueryTooDeep:        "Requête trop profonde",
+	TitleQueryTooComplex:     "Requête trop complexe",
+	TitleRouteNotFound:       "Ressource non trouvée",
+	TitleMethodNotAllowed:    "Méthode non autorisée",
+	TitleDuplicateName:       "Nom déjà utilisé",
+	TitleConflict:            "Conflit avec l'état de la ressource",
+	TitleUnauthenticated:     "Authentification requise",
+	TitleForbidden:           "Accès refusé",
+	TitleAPIKeyNotFound:      "Clé d'API non trouvée",
+	TitleRateLimited:         "Trop de requêtes",
+	TitleInternal:            "Erreur serveur",
+
+	InternalDetail:     "Une erreur interne est survenue, réessayez plus tard ou contactez le support avec l'identifiant de requête",
+	InvalidID:          "L'ID doit être un nombre entier",
+	DuplicateName:      "une ressource porte déjà ce nom",
+	StillReferenced:    "la ressource est encore utilisée",
+	InvalidData:        "données invalides",
+	QueryTooDeep:       "profondeur de requête %d supérieure au maximum autorisé (%d)",
+	QueryTooComplex:    "complexité de requête %d supérieure au maximum autorisé (%d)",
+	CredentialsMissing: "une clé d'API (en-tête X-API-Key) ou un jeton (Authorization: Bearer) est requis",
+	CredentialsInvalid: "clé d'API ou jeton invalide, expiré ou révoqué",
+	RoleRequired:       "le rôle '%s' est requis (rôle actuel: %s)",
+	RateLimited:        "limite de %d requêtes en %d s atteinte, réessayez dans %d s",
+
+	FieldRequired:       "champ requis",
+	MustBePositive:      "doit être supérieur à 0",
+	NothingToUpdate:     "aucun champ à mettre à jour",
+	ParamRepeated:       "paramètre fourni plusieurs fois",
+	ParamUnknown:        "paramètre inconnu",
+	ParamRequired:       "paramètre requis",
+	IntegerExpected:     "nombre entier attendu",
+	NumberExpected:      "nombre attendu",
+	AllowedValues:       "valeurs autorisées: %s",
+	MinBound:            "doit être supérieur ou égal à %s",
+	MaxBound:            "doit être inférieur ou égal à %s",
+	UnknownValue:        "valeur inconnue '%s' pour %s (valeurs autorisées: %s)",
+	ThresholdUnbounded:  "seul le dernier seuil peut être sans limite",
+	ThresholdIncreasing: "doit être strictement croissant (%s)",
+	ThresholdDuplicate:  "taille déjà présente au seuil %d",
+	PetSizeNotDerivable: "pet_size ne peut pas être déduit des poids pour l'espèce '%s'",
+	PetSizeInconsistent: "pet_size '%s' incohérent avec les poids de %s (attendu: %s)",
+	UnknownWeightUnit:   "unité de poids inconnue '%s' (valeurs autorisées: g, kg, lb)",
+	InvalidWeightJSON:   "poids invalide %s: nombre ou chaîne attendu",
+	InvalidWeight:       "poids invalide '%s'",
+	WeightTooHigh:       "poids trop élevé '%s' (maximum: %d g)",
+	AliasConflict:       "l'alias '%s' désigne déjà la race '%s'",
+	MaxLength:           "%s caractères au maximum",
+
+	BodyEmpty:      "corps de requête vide",
+	BodySyntax:     "JSON invalide à l'octet %d",
+	BodyTruncated:  "JSON incomplet",
+	BodyFieldType:  "type invalide pour %s (%s reçu)",
+	BodyTooLarge:   "corps de requête trop volumineux (maximum: %d octets)",
+	BodyUnreadable: "corps de requête illisible",
+
+	CSVLine:             "ligne %d: %s",
+	CSVColumnCount:      "nombre de colonnes incorrect (attendu: %d, reçu: %d)",
+	CSVInvalidID:        "ID invalide '%s'",
+	CSVInvalidWeight:    "%s invalide '%s'",
+	CSVNegativeWeight:   "%s négatif (%d)",
+	CSVWeightTooLow:     "%s %d trop faible pour des grammes, les poids du CSV doivent être en grammes (kilogrammes ?)",
+	CSVWeightTooHigh:    "%s %d trop élevé pour des grammes (maximum: %d)",
+	CSVUnknownBreed:     "race inconnue '%s'",
+	CSVEmptyDisplayName: "display_name vide",
+	CSVEmptyAlias:       "alias vide",
+	MaleWeight:          "poids mâle",
+	FemaleWeight:        "poids femelle",
+
+	BreedCreated:               "Race créée avec succès",
+	BreedUpdated:               "Race mise à jour avec succès",
+	BreedDeleted:               "Race supprimée avec succès",
+	ValueCreated:               "Valeur créée avec succès",
+	ValueUpdated:               "Valeur mise à jour avec succès",
+	ValueDeleted:               "Valeur supprimée avec succès",
+	ThresholdsUpdated:          "Seuils de taille mis à jour avec succès",
+	ImportSucceeded:            "Import des races terminé avec succès",
+	TranslationSaved:           "Traduction enregistrée avec succès",
+	TranslationDeleted:         "Traduction supprimée avec succès",
+	TranslationImportSucceeded: "Import des traductions terminé avec succès",
+	AliasCreated:               "Alias créé avec succès",
+	AliasDeleted:               "Alias supprimé avec succès",
+	AliasImportSucceeded:       "Import des alias terminé avec succès",
+	APIKeyCreated:              "Clé créée : conservez-la, elle ne sera plus affichée",
+	APIKeyRevoked:              "Clé révoquée avec succès",
+
+	SemanticsMale:        "le poids moyen mâle est compris dans [weight_min, weight_max]",
+	SemanticsFemale:      "le poids moyen femelle est compris dans [weight_min, weight_max]",
+	SemanticsOverlap:     "l'intervalle entre les poids moyens connus mâle et femelle chevauche [weight_min, weight_max]",
+	SemanticsOverlapBoth: "les poids moyens mâle et femelle sont tous deux connus et l'intervalle entre eux chevauche [weight_min, weight_max]",
+	SemanticsBoth:        "les poids moyens mâle et femelle sont tous deux compris dans [weight_min, weight_max]",
+	SemanticsAny:         "au moins un des poids moyens mâle ou femelle est compris dans [weight_min, weight_max]",
+}
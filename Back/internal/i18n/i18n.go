@@ -0,0 +1,213 @@
+package i18n
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Lang est une langue prise en charge par l'API
+type Lang string
+
+const (
+	French  Lang = "fr"
+	English Lang = "en"
+)
+
+// Default est la langue utilisée sans Accept-Language exploitable ; c'était
+// la seule langue de l'API avant l'ajout du catalogue
+const Default = French
+
+// catalogs associe chaque langue à son bundle de messages
+var catalogs = map[Lang]map[Key]string{
+	French:  fr,
+	English: en,
+}
+
+// Supported retourne les langues disponibles, triées
+func Supported() []Lang {
+	langs := make([]Lang, 0, len(catalogs))
+	for lang := range catalogs {
+		langs = append(langs, lang)
+	}
+	sort.Slice(langs, func(i, j int) bool { return langs[i] < langs[j] })
+	return langs
+}
+
+// T traduit un message ; une clé absente de la langue demandée retombe sur
+// la langue par défaut, puis sur la clé elle-même
+func T(lang Lang, key Key, args ...interface{}) string {
+	format, ok := catalogs[lang][key]
+	if !ok {
+		format, ok = catalogs[Default][key]
+	}
+	if !ok {
+		format = string(key)
+	}
+	if len(args) == 0 {
+		return format
+	}
+	return fmt.Sprintf(format, args...)
+}
+
+// Negotiate choisit la langue à partir d'un en-tête Accept-Language
+// (ex: "en-US,en;q=0.9,fr;q=0.8") ; la langue par défaut est retenue si
+// aucune langue prise en charge n'est acceptée
+func Negotiate(acceptLanguage string) Lang {
+	best, bestQuality := Default, 0.0
+	for _, part := range strings.Split(acceptLanguage, ",") {
+		tag, quality := parseLanguageRange(part)
+		if quality <= bestQuality {
+			continue
+		}
+		if tag == "*" {
+			best, bestQuality = Default, quality
+			continue
+		}
+		primary, _, _ := strings.Cut(tag, "-")
+		if _, ok := catalogs[Lang(primary)]; ok {
+			best, bestQuality = Lang(primary), quality
+		}
+	}
+	return best
+}
+
+func parseLanguageRange(part string) (string, float64) {
+	tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if tag == "" {
+		return "", 0
+	}
+
+	quality := 1.0
+	for _, param := range strings.Split(params, ";") {
+		name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if !ok || strings.TrimSpace(name) != "q" {
+			continue
+		}
+		parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return tag, 0
+		}
+		quality = parsed
+	}
+	return tag, quality
+}
+
+// FromRequest retourne la langue négociée pour la requête
+func FromRequest(r *http.Request) Lang {
+	return Negotiate(r.Header.Get("Accept-Language"))
+}
+
+// SetContentLanguage annonce la langue de la réponse, qui dépend de
+// l'en-tête Accept-Language pour les caches
+func SetContentLanguage(w http.ResponseWriter, lang Lang) {
+	w.Header().Set("Content-Language", string(lang))
+	w.Header().Add("Vary", "Accept-Language")
+}
+
+// Localizer est implémenté par tout ce qui sait se rendre dans une langue,
+// en particulier les erreurs destinées aux clients de l'API
+type Localizer interface {
+	Localize(lang Lang) string
+}
+
+// Message est un message du catalogue avec ses arguments, rendu au dernier
+// moment dans la langue de la requête ; il peut servir d'erreur
+type Message struct {
+	Key  Key
+	Args []interface{}
+}
+
+// New crée un message ; les arguments qui sont eux-mêmes des Localizer
+// (labels, erreurs imbriquées) sont traduits dans la même langue
+func New(key Key, args ...interface{}) *Message {
+	return &Message{Key: key, Args: args}
+}
+
+func (m *Message) Localize(lang Lang) string {
+	args := make([]interface{}, len(m.Args))
+	for i, arg := range m.Args {
+		switch value := arg.(type) {
+		case Localizer:
+			args[i] = value.Localize(lang)
+		case error:
+			args[i] = Localize(lang, value)
+		default:
+			args[i] = arg
+		}
+	}
+	return T(lang, m.Key, args...)
+}
+
+// Error rend le message dans la langue par défaut, pour les logs
+func (m *Message) Error() string {
+	return m.Localize(Default)
+}
+
+// Unwrap expose les erreurs passées en argument, pour errors.Is et errors.As
+func (m *Message) Unwrap() []error {
+	var errs []error
+	for _, arg := range m.Args {
+		if err, ok := arg.(error); ok {
+			errs = append(errs, err)
+		}
+	}
+	return errs
+}
+
+// Text est un texte déjà rendu, identique dans toutes les langues
+type Text string
+
+func (t Text) Localize(Lang) string {
+	return string(t)
+}
+
+// FromError retourne le Localizer porté par l'erreur, ou son texte brut si
+// elle n'est pas traduisible (erreurs de la bibliothèque standard par exemple)
+func FromError(err error) Localizer {
+	var localizer Localizer
+	if errors.As(err, &localizer) {
+		return localizer
+	}
+	return Text(err.Error())
+}
+
+// FromDecodeError traduit une erreur de lecture d'un corps JSON : les messages
+// de encoding/json, en anglais et citant les types Go, ne sont pas renvoyés
+// tels quels ; une erreur déjà traduisible (poids invalide) est conservée
+func FromDecodeError(err error) Localizer {
+	var localizer Localizer
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var tooLarge *http.MaxBytesError
+	switch {
+	case errors.As(err, &localizer):
+		return localizer
+	case errors.Is(err, io.EOF):
+		return New(BodyEmpty)
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return New(BodyTruncated)
+	case errors.As(err, &syntaxErr):
+		return New(BodySyntax, syntaxErr.Offset)
+	case errors.As(err, &typeErr):
+		field := typeErr.Field
+		if field == "" {
+			field = "body"
+		}
+		return New(BodyFieldType, field, typeErr.Value)
+	case errors.As(err, &tooLarge):
+		return New(BodyTooLarge, tooLarge.Limit)
+	}
+	return New(BodyUnreadable)
+}
+
+// Localize rend une erreur dans la langue demandée
+func Localize(lang Lang, err error) string {
+	return FromError(err).Localize(lang)
+}
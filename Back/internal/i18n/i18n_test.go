@@ -0,0 +1,78 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestCatalogsHaveSameKeys(t *testing.T) {
+	for lang, catalog := range catalogs {
+		for key := range catalogs[Default] {
+			if _, ok := catalog[key]; !ok {
+				t.Errorf("clé %s absente du catalogue %s", key, lang)
+			}
+		}
+		for key := range catalog {
+			if _, ok := catalogs[Default][key]; !ok {
+				t.Errorf("clé %s du catalogue %s absente du catalogue par défaut", key, lang)
+			}
+		}
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	tests := map[string]Lang{
+		"":                        French,
+		"en":                      English,
+		"en-US,en;q=0.9":          English,
+		"de-DE,en;q=0.5,fr;q=0.8": French,
+		"de, en;q=0.3":            English,
+		"fr;q=0, en;q=0.1":        English,
+		"*":                       French,
+		"EN-gb":                   English,
+		"es, it":                  French,
+	}
+	for header, expected := range tests {
+		if lang := Negotiate(header); lang != expected {
+			t.Errorf("Negotiate(%q): attendu %s, obtenu %s", header, expected, lang)
+		}
+	}
+}
+
+func TestMessageLocalizesNestedArguments(t *testing.T) {
+	err := New(CSVLine, 3, New(CSVNegativeWeight, New(MaleWeight), -2))
+	wrapped := fmt.Errorf("import: %w", err)
+
+	if message := Localize(English, wrapped); message != "line 3: negative male weight (-2)" {
+		t.Errorf("message anglais inattendu: %s", message)
+	}
+	if message := err.Error(); message != "ligne 3: poids mâle négatif (-2)" {
+		t.Errorf("message français inattendu: %s", message)
+	}
+}
+
+func TestFromDecodeError(t *testing.T) {
+	var target struct {
+		Name string `json:"name"`
+	}
+	tests := map[string]string{
+		``:              "empty request body",
+		`{"name":`:      "incomplete JSON",
+		`{"name": }`:    "invalid JSON at byte 10",
+		`{"name": 5}`:   "invalid type for name (got number)",
+		`["not", "an"]`: "invalid type for body (got array)",
+	}
+	for body, expected := range tests {
+		err := json.NewDecoder(strings.NewReader(body)).Decode(&target)
+		if message := FromDecodeError(err).Localize(English); message != expected {
+			t.Errorf("%q: attendu %q, obtenu %q", body, expected, message)
+		}
+	}
+
+	// Une erreur déjà traduisible est conservée
+	if message := FromDecodeError(New(InvalidWeight, "abc")).Localize(English); message != "invalid weight 'abc'" {
+		t.Errorf("message inattendu: %s", message)
+	}
+}
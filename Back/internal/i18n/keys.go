@@ -0,0 +1,128 @@
+package i18n
+
+// Key identifie un message du catalogue
+type Key string
+
+// Titres des erreurs RFC 7807, un par code d'erreur
+const (
+	TitleValidationFailed    Key = "problem.validation_failed"
+	TitleInvalidBody         Key = "problem.invalid_body"
+	TitleInvalidID           Key = "problem.invalid_id"
+	TitleInvalidCSV          Key = "problem.invalid_csv"
+	TitleBreedNotFound       Key = "problem.breed_not_found"
+	TitleSpeciesNotFound     Key = "problem.species_not_found"
+	TitlePetSizeNotFound     Key = "problem.pet_size_not_found"
+	TitleTranslationNotFound Key = "problem.translation_not_found"
+	TitleAliasNotFound       Key = "problem.alias_not_found"
+	TitleAliasConflict       Key = "problem.alias_conflict"
+	TitleInvalidQuery        Key = "problem.invalid_query"
+	TitleQueryTooDeep        Key = "problem.query_too_deep"
+	TitleQueryTooComplex     Key = "problem.query_too_complex"
+	TitleRouteNotFound       Key = "problem.route_not_found"
+	TitleMethodNotAllowed    Key = "problem.method_not_allowed"
+	TitleDuplicateName       Key = "problem.duplicate_name"
+	TitleConflict            Key = "problem.conflict"
+	TitleUnauthenticated     Key = "problem.unauthenticated"
+	TitleForbidden           Key = "problem.forbidden"
+	TitleAPIKeyNotFound      Key = "problem.api_key_not_found"
+	TitleRateLimited         Key = "problem.rate_limited"
+	TitleInternal            Key = "problem.internal_error"
+)
+
+// Détails des erreurs
+const (
+	InternalDetail     Key = "error.internal"
+	InvalidID          Key = "error.invalid_id"
+	DuplicateName      Key = "error.duplicate_name"
+	StillReferenced    Key = "error.still_referenced"
+	InvalidData        Key = "error.invalid_data"
+	QueryTooDeep       Key = "error.query_too_deep"
+	QueryTooComplex    Key = "error.query_too_complex"
+	CredentialsMissing Key = "error.credentials_missing"
+	CredentialsInvalid Key = "error.credentials_invalid"
+	RoleRequired       Key = "error.role_required"
+	RateLimited        Key = "error.rate_limited"
+)
+
+// Validation des champs et paramètres
+const (
+	FieldRequired       Key = "validation.required"
+	MustBePositive      Key = "validation.positive"
+	NothingToUpdate     Key = "validation.nothing_to_update"
+	ParamRepeated       Key = "validation.param_repeated"
+	ParamUnknown        Key = "validation.param_unknown"
+	ParamRequired       Key = "validation.param_required"
+	IntegerExpected     Key = "validation.integer_expected"
+	NumberExpected      Key = "validation.number_expected"
+	AllowedValues       Key = "validation.allowed_values"
+	MinBound            Key = "validation.min_bound"
+	MaxBound            Key = "validation.max_bound"
+	UnknownValue        Key = "validation.unknown_value"
+	ThresholdUnbounded  Key = "validation.threshold_unbounded"
+	ThresholdIncreasing Key = "validation.threshold_increasing"
+	ThresholdDuplicate  Key = "validation.threshold_duplicate"
+	PetSizeNotDerivable Key = "validation.pet_size_not_derivable"
+	PetSizeInconsistent Key = "validation.pet_size_inconsistent"
+	UnknownWeightUnit   Key = "validation.unknown_weight_unit"
+	InvalidWeightJSON   Key = "validation.invalid_weight_json"
+	InvalidWeight       Key = "validation.invalid_weight"
+	WeightTooHigh       Key = "validation.weight_too_high"
+	AliasConflict       Key = "validation.alias_conflict"
+	MaxLength           Key = "validation.max_length"
+)
+
+// Lecture du corps JSON d'une requête
+const (
+	BodyEmpty      Key = "body.empty"
+	BodySyntax     Key = "body.syntax"
+	BodyTruncated  Key = "body.truncated"
+	BodyFieldType  Key = "body.field_type"
+	BodyTooLarge   Key = "body.too_large"
+	BodyUnreadable Key = "body.unreadable"
+)
+
+// Import CSV
+const (
+	CSVLine             Key = "csv.line"
+	CSVColumnCount      Key = "csv.column_count"
+	CSVInvalidID        Key = "csv.invalid_id"
+	CSVInvalidWeight    Key = "csv.invalid_weight"
+	CSVNegativeWeight   Key = "csv.negative_weight"
+	CSVWeightTooLow     Key = "csv.weight_too_low"
+	CSVWeightTooHigh    Key = "csv.weight_too_high"
+	CSVUnknownBreed     Key = "csv.unknown_breed"
+	CSVEmptyDisplayName Key = "csv.empty_display_name"
+	CSVEmptyAlias       Key = "csv.empty_alias"
+	MaleWeight          Key = "csv.male_weight"
+	FemaleWeight        Key = "csv.female_weight"
+)
+
+// Messages de succès
+const (
+	BreedCreated               Key = "success.breed_created"
+	BreedUpdated               Key = "success.breed_updated"
+	BreedDeleted               Key = "success.breed_deleted"
+	ValueCreated               Key = "success.value_created"
+	ValueUpdated               Key = "success.value_updated"
+	ValueDeleted               Key = "success.value_deleted"
+	ThresholdsUpdated          Key = "success.thresholds_updated"
+	ImportSucceeded            Key = "success.import"
+	TranslationSaved           Key = "success.translation_saved"
+	TranslationDeleted         Key = "success.translation_deleted"
+	TranslationImportSucceeded Key = "success.translation_import"
+	AliasCreated               Key = "success.alias_created"
+	AliasDeleted               Key = "success.alias_deleted"
+	AliasImportSucceeded       Key = "success.alias_import"
+	APIKeyCreated              Key = "success.api_key_created"
+	APIKeyRevoked              Key = "success.api_key_revoked"
+)
+
+// Sémantique des filtres de poids de GET /breeds
+const (
+	SemanticsMale        Key = "semantics.male"
+	SemanticsFemale      Key = "semantics.female"
+	SemanticsOverlap     Key = "semantics.overlap"
+	SemanticsOverlapBoth Key = "semantics.overlap_both"
+	SemanticsBoth        Key = "semantics.both"
+	SemanticsAny         Key = "semantics.any"
+)
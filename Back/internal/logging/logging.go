@@ -0,0 +1,74 @@
+// Package logging construit le logger de l'application et, pour chaque
+// requête HTTP, un logger portant son identifiant, sa route et son appelant,
+// ainsi qu'une ligne de journal d'accès
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"time"
+
+	charmLog "github.com/charmbracelet/log"
+)
+
+// Format est le format des lignes de log
+type Format string
+
+const (
+	// Text est lisible dans un terminal, pour le développement
+	Text Format = "text"
+	// JSON écrit un objet par ligne, pour l'envoi des logs en production
+	JSON Format = "json"
+)
+
+// ParseFormat valide un format lu dans la configuration
+func ParseFormat(format string) (Format, error) {
+	switch Format(format) {
+	case Text, JSON:
+		return Format(format), nil
+	}
+	return "", fmt.Errorf("format de log inconnu : %q (text ou json attendu)", format)
+}
+
+// New crée le logger de l'application
+func New(w io.Writer, format Format, level charmLog.Level) *charmLog.Logger {
+	options := charmLog.Options{
+		ReportCaller:    true,
+		ReportTimestamp: true,
+		Level:           level,
+	}
+	if format == JSON {
+		// Horodatage complet et pas de préfixe décoratif : les lignes sont
+		// lues par l'outil de collecte, pas dans un terminal
+		options.Formatter = charmLog.JSONFormatter
+		options.TimeFormat = time.RFC3339Nano
+	} else {
+		options.Formatter = charmLog.TextFormatter
+		options.TimeFormat = time.Kitchen
+		options.Prefix = "🧑‍💻 backend-test"
+	}
+	return charmLog.NewWithOptions(w, options)
+}
+
+// FromContext retourne le logger de la requête, ou fallback hors d'une
+// requête HTTP (tâches de fond, tests)
+func FromContext(ctx context.Context, fallback *charmLog.Logger) *charmLog.Logger {
+	if logger, ok := ctx.Value(charmLog.ContextKey).(*charmLog.Logger); ok {
+		return logger
+	}
+	return fallback
+}
+
+// WithPrincipal ajoute l'appelant authentifié au logger de la requête et à sa
+// ligne de journal d'accès
+func WithPrincipal(ctx context.Context, subject string) context.Context {
+	if entry, ok := ctx.Value(entryKey{}).(*entry); ok {
+		entry.principal = subject
+	}
+	logger, ok := ctx.Value(charmLog.ContextKey).(*charmLog.Logger)
+	if !ok {
+		return ctx
+	}
+	return charmLog.WithContext(ctx, logger.With("principal", subject))
+}
@@ -0,0 +1,118 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/requestid"
+)
+
+// serve fait passer une requête par requestid.Middleware puis par un routeur
+// journalisé, et retourne les lignes JSON écrites
+func serve(t *testing.T, level charmLog.Level, method, path string, handler http.HandlerFunc) []map[string]interface{} {
+	t.Helper()
+
+	var out bytes.Buffer
+	logger := New(&out, JSON, level)
+
+	r := mux.NewRouter()
+	r.Use(Middleware(logger, "/livez"))
+	r.HandleFunc("/breeds/{id:[0-9]+}", handler)
+	r.HandleFunc("/livez", handler)
+	r.NotFoundHandler = Middleware(logger)(http.NotFoundHandler())
+
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set(requestid.Header, "req-42")
+	requestid.Middleware(r).ServeHTTP(httptest.NewRecorder(), req)
+
+	var lines []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("ligne de log invalide %q : %v", line, err)
+		}
+		lines = append(lines, entry)
+	}
+	return lines
+}
+
+func TestMiddleware_AccessLog(t *testing.T) {
+	lines := serve(t, charmLog.DebugLevel, http.MethodPut, "/breeds/7", func(w http.ResponseWriter, r *http.Request) {
+		ctx := WithPrincipal(r.Context(), "key:abc")
+		FromContext(ctx, nil).Warn("Dans le handler")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte("hello"))
+	})
+	if len(lines) != 2 {
+		t.Fatalf("%d lignes de log, attendu 2 : %v", len(lines), lines)
+	}
+
+	handler, access := lines[0], lines[1]
+	for _, line := range lines {
+		if line["request_id"] != "req-42" || line["method"] != "PUT" || line["route"] != "/breeds/{id:[0-9]+}" || line["principal"] != "key:abc" {
+			t.Errorf("contexte de requête incomplet : %v", line)
+		}
+	}
+	if handler["msg"] != "Dans le handler" || handler["level"] != "warn" {
+		t.Errorf("ligne du handler inattendue : %v", handler)
+	}
+	if access["level"] != "info" || access["status"] != float64(201) || access["bytes"] != float64(5) || access["path"] != "/breeds/7" {
+		t.Errorf("ligne d'accès inattendue : %v", access)
+	}
+	if _, ok := access["duration_ms"].(float64); !ok {
+		t.Errorf("durée absente de la ligne d'accès : %v", access)
+	}
+}
+
+func TestMiddleware_UnmatchedRoute(t *testing.T) {
+	lines := serve(t, charmLog.InfoLevel, http.MethodGet, "/nowhere", nil)
+	if len(lines) != 1 || lines[0]["route"] != "unmatched" || lines[0]["status"] != float64(404) {
+		t.Errorf("ligne d'accès inattendue : %v", lines)
+	}
+	if _, ok := lines[0]["principal"]; ok {
+		t.Errorf("appelant inattendu pour une requête anonyme : %v", lines[0])
+	}
+}
+
+func TestMiddleware_QuietRoute(t *testing.T) {
+	ok := func(w http.ResponseWriter, r *http.Request) {}
+
+	if lines := serve(t, charmLog.InfoLevel, http.MethodGet, "/livez", ok); len(lines) != 0 {
+		t.Errorf("sonde journalisée au niveau info : %v", lines)
+	}
+	if lines := serve(t, charmLog.DebugLevel, http.MethodGet, "/livez", ok); len(lines) != 1 || lines[0]["level"] != "debug" {
+		t.Errorf("sonde non journalisée au niveau debug : %v", lines)
+	}
+}
+
+func TestFromContext_Fallback(t *testing.T) {
+	fallback := New(&bytes.Buffer{}, Text, charmLog.InfoLevel)
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	if FromContext(req.Context(), fallback) != fallback {
+		t.Error("hors requête journalisée, le logger par défaut doit être retourné")
+	}
+	if ctx := WithPrincipal(req.Context(), "key:abc"); FromContext(ctx, fallback) != fallback {
+		t.Error("WithPrincipal ne doit pas créer de logger hors requête journalisée")
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	for _, format := range []string{"text", "json"} {
+		if _, err := ParseFormat(format); err != nil {
+			t.Errorf("format %q refusé : %v", format, err)
+		}
+	}
+	if _, err := ParseFormat("xml"); err == nil {
+		t.Error("format xml accepté")
+	}
+}
@@ -0,0 +1,107 @@
+package logging
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/requestid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// unmatchedRoute désigne les requêtes qu'aucune route ne reconnaît
+const unmatchedRoute = "unmatched"
+
+type entryKey struct{}
+
+// entry rassemble ce que les couches suivantes apprennent de la requête
+// (l'appelant, après l'authentification) pour la ligne de journal d'accès
+type entry struct {
+	principal string
+}
+
+// Middleware place dans le contexte un logger portant l'identifiant de
+// requête, la méthode et le modèle de la route, puis écrit une ligne par
+// requête avec son statut, sa taille et sa durée. Il s'installe avec
+// Router.Use, après requestid.Middleware, pour que la route soit connue ;
+// les requêtes des routes quiet (sondes, métriques) sont journalisées au
+// niveau debug.
+func Middleware(logger *charmLog.Logger, quiet ...string) func(http.Handler) http.Handler {
+	quietRoutes := make(map[string]bool, len(quiet))
+	for _, route := range quiet {
+		quietRoutes[route] = true
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			route := unmatchedRoute
+			if current := mux.CurrentRoute(r); current != nil {
+				if template, err := current.GetPathTemplate(); err == nil {
+					route = template
+				}
+			}
+
+			requestLogger := logger.With(
+				"request_id", requestid.FromContext(r.Context()),
+				"method", r.Method,
+				"route", route,
+			)
+			// Avec OpenTelemetry, les lignes se rattachent aussi à la trace
+			if span := trace.SpanContextFromContext(r.Context()); span.IsValid() {
+				requestLogger = requestLogger.With("trace_id", span.TraceID().String())
+			}
+			entry := &entry{}
+			ctx := charmLog.WithContext(r.Context(), requestLogger)
+			ctx = context.WithValue(ctx, entryKey{}, entry)
+
+			recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+			start := time.Now()
+			next.ServeHTTP(recorder, r.WithContext(ctx))
+
+			level := charmLog.InfoLevel
+			if quietRoutes[route] {
+				level = charmLog.DebugLevel
+			}
+			keyvals := []interface{}{
+				"path", r.URL.Path,
+				"status", recorder.status,
+				"bytes", recorder.bytes,
+				"duration_ms", float64(time.Since(start).Microseconds()) / 1000,
+			}
+			if entry.principal != "" {
+				keyvals = append(keyvals, "principal", entry.principal)
+			}
+			requestLogger.Log(level, "Requête traitée", keyvals...)
+		})
+	}
+}
+
+// responseRecorder retient le statut et la taille de la réponse
+type responseRecorder struct {
+	http.ResponseWriter
+	status      int
+	bytes       int
+	wroteHeader bool
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	n, err := r.ResponseWriter.Write(b)
+	r.bytes += n
+	return n, err
+}
+
+// Unwrap donne accès au ResponseWriter d'origine (http.ResponseController)
+func (r *responseRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
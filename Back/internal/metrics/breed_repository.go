@@ -0,0 +1,73 @@
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/repository"
+)
+
+// BreedRepository mesure la durée de chaque appel au repository des races
+type BreedRepository struct {
+	repo    repository.BreedRepositoryInterface
+	metrics *Metrics
+}
+
+// NewBreedRepository décore repo
+func NewBreedRepository(repo repository.BreedRepositoryInterface, m *Metrics) *BreedRepository {
+	return &BreedRepository{
+		repo:    repo,
+		metrics: m,
+	}
+}
+
+const breedRepository = "breed"
+
+func (r *BreedRepository) GetAll(ctx context.Context, filter repository.BreedFilter) ([]repository.Breed, error) {
+	start := time.Now()
+	breeds, err := r.repo.GetAll(ctx, filter)
+	r.metrics.observe(breedRepository, "GetAll", start, err)
+	return breeds, err
+}
+
+func (r *BreedRepository) GetByID(ctx context.Context, id int) (*repository.Breed, error) {
+	start := time.Now()
+	breed, err := r.repo.GetByID(ctx, id)
+	r.metrics.observe(breedRepository, "GetByID", start, err)
+	return breed, err
+}
+
+func (r *BreedRepository) Resolve(ctx context.Context, name string) (*repository.Breed, error) {
+	start := time.Now()
+	breed, err := r.repo.Resolve(ctx, name)
+	r.metrics.observe(breedRepository, "Resolve", start, err)
+	return breed, err
+}
+
+func (r *BreedRepository) Create(ctx context.Context, breed *repository.Breed) (*repository.Breed, error) {
+	start := time.Now()
+	created, err := r.repo.Create(ctx, breed)
+	r.metrics.observe(breedRepository, "Create", start, err)
+	return created, err
+}
+
+func (r *BreedRepository) Update(ctx context.Context, id int, breed *repository.Breed) (*repository.Breed, error) {
+	start := time.Now()
+	updated, err := r.repo.Update(ctx, id, breed)
+	r.metrics.observe(breedRepository, "Update", start, err)
+	return updated, err
+}
+
+func (r *BreedRepository) Delete(ctx context.Context, id int) error {
+	start := time.Now()
+	err := r.repo.Delete(ctx, id)
+	r.metrics.observe(breedRepository, "Delete", start, err)
+	return err
+}
+
+func (r *BreedRepository) ImportFromCSV(ctx context.Context, breeds []repository.Breed) (repository.ImportStats, error) {
+	start := time.Now()
+	stats, err := r.repo.ImportFromCSV(ctx, breeds)
+	r.metrics.observe(breedRepository, "ImportFromCSV", start, err)
+	return stats, err
+}
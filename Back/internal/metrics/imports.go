@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/repository"
+)
+
+// Import suit un import CSV, de la lecture du fichier à l'écriture en base
+type Import struct {
+	metrics *Metrics
+	name    string
+	start   time.Time
+	outcome string
+}
+
+// StartImport commence le suivi de l'import name (breeds, translations,
+// aliases) ; Finish doit être appelé à la fin, en defer
+func (m *Metrics) StartImport(name string) *Import {
+	return &Import{
+		metrics: m,
+		name:    name,
+		start:   time.Now(),
+		outcome: "error",
+	}
+}
+
+// Read compte les lignes lues dans le fichier
+func (i *Import) Read(rows int) {
+	i.add("read", rows)
+}
+
+// Reject compte les lignes d'un fichier refusé à la validation
+func (i *Import) Reject(rows int) {
+	i.add("rejected", rows)
+	i.outcome = "rejected"
+}
+
+// Succeed compte les lignes écrites en base et marque l'import réussi
+func (i *Import) Succeed(stats repository.ImportStats) {
+	i.add("inserted", stats.Inserted)
+	i.add("updated", stats.Updated)
+	i.add("unchanged", stats.Unchanged)
+	i.outcome = "success"
+}
+
+// Finish mesure la durée de l'import ; sans Reject ni Succeed, il a échoué
+func (i *Import) Finish() {
+	i.metrics.importDuration.WithLabelValues(i.name, i.outcome).Observe(time.Since(i.start).Seconds())
+}
+
+func (i *Import) add(outcome string, rows int) {
+	i.metrics.importRows.WithLabelValues(i.name, outcome).Add(float64(rows))
+}
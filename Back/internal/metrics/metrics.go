@@ -0,0 +1,137 @@
+// Package metrics expose au format Prometheus les requêtes HTTP, le pool de
+// connexions, les requêtes des repositories et les imports CSV
+package metrics
+
+import (
+	"database/sql"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "backend_test"
+
+// unmatchedRoute étiquette les requêtes qu'aucune route ne reconnaît, pour
+// que les chemins arbitraires ne créent pas de nouvelles séries
+const unmatchedRoute = "unmatched"
+
+// Metrics regroupe les métriques de l'application, dans un registre qui lui
+// est propre
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests        *prometheus.CounterVec
+	requestDuration *prometheus.HistogramVec
+	queryDuration   *prometheus.HistogramVec
+	importRows      *prometheus.CounterVec
+	importDuration  *prometheus.HistogramVec
+}
+
+// New crée les métriques ; db alimente les jauges du pool de connexions
+func New(db *sql.DB) *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "http_requests_total",
+			Help:      "Requêtes HTTP traitées, par route et statut.",
+		}, []string{"method", "route", "status"}),
+		requestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "http_request_duration_seconds",
+			Help:      "Durée de traitement des requêtes HTTP, par route.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route"}),
+		queryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "repository_query_duration_seconds",
+			Help:      "Durée des appels aux repositories, par méthode et issue.",
+			Buckets:   []float64{.001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5},
+		}, []string{"repository", "method", "outcome"}),
+		importRows: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "import_rows_total",
+			Help:      "Lignes des imports CSV : lues, insérées, mises à jour, inchangées ou rejetées.",
+		}, []string{"import", "outcome"}),
+		importDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "import_duration_seconds",
+			Help:      "Durée des imports CSV, par issue.",
+			Buckets:   []float64{.1, .25, .5, 1, 2.5, 5, 10, 30, 60, 120},
+		}, []string{"import", "outcome"}),
+	}
+
+	m.registry.MustRegister(
+		m.requests, m.requestDuration, m.queryDuration, m.importRows, m.importDuration,
+		collectors.NewDBStatsCollector(db, "myapp"),
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// Handler sert les métriques au format d'exposition Prometheus
+// GET /metrics
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Middleware mesure chaque requête, étiquetée par le modèle de sa route
+// (/v1/breeds/{id}) et non par son chemin ; il s'installe avec Router.Use
+// pour que la route soit connue
+func (m *Metrics) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		route := unmatchedRoute
+		if current := mux.CurrentRoute(r); current != nil {
+			if template, err := current.GetPathTemplate(); err == nil {
+				route = template
+			}
+		}
+
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+		next.ServeHTTP(recorder, r)
+
+		m.requestDuration.WithLabelValues(r.Method, route).Observe(time.Since(start).Seconds())
+		m.requests.WithLabelValues(r.Method, route, strconv.Itoa(recorder.status)).Inc()
+	})
+}
+
+// statusRecorder retient le statut écrit par le handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.status = status
+		r.wroteHeader = true
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *statusRecorder) Write(b []byte) (int, error) {
+	r.wroteHeader = true
+	return r.ResponseWriter.Write(b)
+}
+
+// Unwrap donne accès au ResponseWriter d'origine (http.ResponseController)
+func (r *statusRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// observe mesure un appel de repository
+func (m *Metrics) observe(repository, method string, start time.Time, err error) {
+	outcome := "success"
+	if err != nil {
+		outcome = "error"
+	}
+	m.queryDuration.WithLabelValues(repository, method, outcome).Observe(time.Since(start).Seconds())
+}
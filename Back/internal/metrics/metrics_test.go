@@ -0,0 +1,138 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/repository"
+)
+
+func newMetrics(t *testing.T) *Metrics {
+	t.Helper()
+
+	db, _, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return New(db)
+}
+
+// scrape renvoie l'exposition des métriques, comme la lirait Prometheus
+func scrape(t *testing.T, m *Metrics) string {
+	t.Helper()
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if w.Code != http.StatusOK {
+		t.Fatalf("statut %d, attendu 200", w.Code)
+	}
+	return w.Body.String()
+}
+
+func assertContains(t *testing.T, body string, lines ...string) {
+	t.Helper()
+
+	for _, line := range lines {
+		if !strings.Contains(body, line) {
+			t.Errorf("ligne %q absente des métriques", line)
+		}
+	}
+}
+
+func TestMiddleware_LabelsRouteTemplate(t *testing.T) {
+	m := newMetrics(t)
+	r := mux.NewRouter()
+	r.Use(m.Middleware)
+	r.HandleFunc("/v1/breeds/{id:[0-9]+}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	r.NotFoundHandler = m.Middleware(http.NotFoundHandler())
+
+	for _, path := range []string{"/v1/breeds/1", "/v1/breeds/2", "/nowhere"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, path, nil))
+	}
+
+	body := scrape(t, m)
+	assertContains(t, body,
+		`backend_test_http_requests_total{method="GET",route="/v1/breeds/{id:[0-9]+}",status="404"} 2`,
+		`backend_test_http_requests_total{method="GET",route="unmatched",status="404"} 1`,
+		`backend_test_http_request_duration_seconds_count{method="GET",route="/v1/breeds/{id:[0-9]+}"} 2`,
+		"go_goroutines",
+		"go_sql_open_connections",
+	)
+	if strings.Contains(body, "/nowhere") {
+		t.Error("le chemin d'une requête sans route ne doit pas servir d'étiquette")
+	}
+}
+
+func TestMiddleware_DefaultStatus(t *testing.T) {
+	m := newMetrics(t)
+	handler := m.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("ok"))
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/", nil))
+
+	assertContains(t, scrape(t, m),
+		`backend_test_http_requests_total{method="POST",route="unmatched",status="200"} 1`)
+}
+
+type mockBreedRepository struct {
+	repository.BreedRepositoryInterface
+	err error
+}
+
+func (m *mockBreedRepository) GetByID(ctx context.Context, id int) (*repository.Breed, error) {
+	return nil, m.err
+}
+
+func (m *mockBreedRepository) Delete(ctx context.Context, id int) error {
+	return m.err
+}
+
+func TestBreedRepository_ObservesOutcome(t *testing.T) {
+	m := newMetrics(t)
+	repo := NewBreedRepository(&mockBreedRepository{err: repository.ErrNotFound}, m)
+
+	if _, err := repo.GetByID(context.Background(), 1); err != repository.ErrNotFound {
+		t.Fatalf("erreur %v, attendu ErrNotFound", err)
+	}
+	repo.GetByID(context.Background(), 2)
+
+	assertContains(t, scrape(t, m),
+		`backend_test_repository_query_duration_seconds_count{method="GetByID",outcome="error",repository="breed"} 2`)
+}
+
+func TestImport_CountsRows(t *testing.T) {
+	m := newMetrics(t)
+
+	run := m.StartImport("breeds")
+	run.Read(4)
+	run.Succeed(repository.ImportStats{Inserted: 2, Updated: 1, Unchanged: 1})
+	run.Finish()
+
+	rejected := m.StartImport("aliases")
+	rejected.Read(3)
+	rejected.Reject(3)
+	rejected.Finish()
+
+	failed := m.StartImport("translations")
+	failed.Finish()
+
+	assertContains(t, scrape(t, m),
+		`backend_test_import_rows_total{import="breeds",outcome="read"} 4`,
+		`backend_test_import_rows_total{import="breeds",outcome="inserted"} 2`,
+		`backend_test_import_rows_total{import="breeds",outcome="updated"} 1`,
+		`backend_test_import_rows_total{import="breeds",outcome="unchanged"} 1`,
+		`backend_test_import_rows_total{import="aliases",outcome="rejected"} 3`,
+		`backend_test_import_duration_seconds_count{import="breeds",outcome="success"} 1`,
+		`backend_test_import_duration_seconds_count{import="aliases",outcome="rejected"} 1`,
+		`backend_test_import_duration_seconds_count{import="translations",outcome="error"} 1`,
+	)
+}
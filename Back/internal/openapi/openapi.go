@@ -0,0 +1,40 @@
+// Package openapi embarque la spécification OpenAPI de l'API et la page de
+// documentation qui l'affiche
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+
+	"github.com/japhy-tech/backend-test/internal/secheaders"
+)
+
+//go:embed openapi.json
+var document []byte
+
+//go:embed docs.html
+var docsPage []byte
+
+// Document retourne la spécification brute (JSON)
+func Document() []byte {
+	return document
+}
+
+// Handler sert la spécification
+// GET /openapi.json
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(document)
+	})
+}
+
+// DocsHandler sert la documentation interactive, qui lit /openapi.json
+// GET /docs
+func DocsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Header().Set("Content-Security-Policy", secheaders.PagePolicy)
+		w.Write(docsPage)
+	})
+}
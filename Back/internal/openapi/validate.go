@@ -0,0 +1,332 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"mime"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Spec est la spécification décodée, utilisée pour vérifier que les routes
+// et les réponses de l'API correspondent à ce qui est documenté
+type Spec struct {
+	doc   map[string]interface{}
+	paths map[string]interface{}
+	// templates associe le chemin complet (préfixe du serveur compris, ex:
+	// /v1/breeds/{id}) à sa clé dans paths
+	templates map[string]string
+}
+
+// Load décode la spécification embarquée
+func Load() (*Spec, error) {
+	var doc map[string]interface{}
+	if err := json.Unmarshal(document, &doc); err != nil {
+		return nil, fmt.Errorf("spécification OpenAPI invalide: %w", err)
+	}
+
+	paths, ok := doc["paths"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("spécification OpenAPI sans paths")
+	}
+
+	templates := map[string]string{}
+	for key, item := range paths {
+		base := serverPath(doc["servers"])
+		if servers, ok := item.(map[string]interface{})["servers"]; ok {
+			base = serverPath(servers)
+		}
+		templates[base+key] = key
+	}
+
+	return &Spec{doc: doc, paths: paths, templates: templates}, nil
+}
+
+// serverPath retourne le chemin de la première URL de serveur, sans « / » final
+func serverPath(servers interface{}) string {
+	list, _ := servers.([]interface{})
+	if len(list) == 0 {
+		return ""
+	}
+	server, _ := list[0].(map[string]interface{})
+	url, _ := server["url"].(string)
+	return strings.TrimSuffix(url, "/")
+}
+
+// HasOperation indique si la méthode est documentée pour ce modèle de chemin
+// complet (ex: /v1/breeds/{id})
+func (s *Spec) HasOperation(method, template string) bool {
+	item, ok := s.paths[s.templates[template]].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	_, ok = item[strings.ToLower(method)]
+	return ok
+}
+
+// Match retrouve la clé du chemin documenté correspondant à un chemin
+// concret ; un segment littéral l'emporte sur un paramètre
+// (/v1/breeds/resolve plutôt que /v1/breeds/{id})
+func (s *Spec) Match(path string) (string, bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	best, bestLiterals := "", -1
+	for template, key := range s.templates {
+		parts := strings.Split(strings.Trim(template, "/"), "/")
+		if len(parts) != len(segments) {
+			continue
+		}
+
+		literals := 0
+		matched := true
+		for i, part := range parts {
+			if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+				matched = segments[i] != ""
+			} else {
+				matched = part == segments[i]
+				literals++
+			}
+			if !matched {
+				break
+			}
+		}
+		if matched && literals > bestLiterals {
+			best, bestLiterals = key, literals
+		}
+	}
+
+	return best, bestLiterals >= 0
+}
+
+// ValidateResponse vérifie qu'une réponse est documentée pour l'opération
+// (statut ou default, type de contenu) et que son corps JSON respecte le
+// schéma annoncé
+func (s *Spec) ValidateResponse(method, path string, status int, contentType string, body []byte) error {
+	template, ok := s.Match(path)
+	if !ok {
+		return fmt.Errorf("chemin %s non documenté", path)
+	}
+	item := s.paths[template].(map[string]interface{})
+	operation, ok := item[strings.ToLower(method)].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("opération %s %s non documentée", method, template)
+	}
+
+	responses, _ := operation["responses"].(map[string]interface{})
+	response, ok := responses[strconv.Itoa(status)]
+	if !ok {
+		response, ok = responses["default"]
+	}
+	if !ok {
+		return fmt.Errorf("statut %d non documenté pour %s %s", status, method, template)
+	}
+	resolved, err := s.resolve(response.(map[string]interface{}))
+	if err != nil {
+		return err
+	}
+
+	content, _ := resolved["content"].(map[string]interface{})
+	if len(content) == 0 {
+		if len(body) > 0 {
+			return fmt.Errorf("%s %s (%d): corps non documenté", method, template, status)
+		}
+		return nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("%s %s (%d): Content-Type invalide '%s'", method, template, status, contentType)
+	}
+	media, ok := content[mediaType].(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("%s %s (%d): Content-Type %s non documenté", method, template, status, mediaType)
+	}
+	if mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json") {
+		return nil
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(body, &value); err != nil {
+		return fmt.Errorf("%s %s (%d): corps JSON invalide: %w", method, template, status, err)
+	}
+	schema, _ := media["schema"].(map[string]interface{})
+	if err := s.validate(schema, value, "#"); err != nil {
+		return fmt.Errorf("%s %s (%d): %w", method, template, status, err)
+	}
+
+	return nil
+}
+
+// resolve suit un $ref local (#/components/...) jusqu'à l'objet désigné
+func (s *Spec) resolve(node map[string]interface{}) (map[string]interface{}, error) {
+	for {
+		ref, ok := node["$ref"].(string)
+		if !ok {
+			return node, nil
+		}
+		if !strings.HasPrefix(ref, "#/") {
+			return nil, fmt.Errorf("référence non locale %s", ref)
+		}
+
+		var current interface{} = s.doc
+		for _, part := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+			object, ok := current.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("référence introuvable %s", ref)
+			}
+			current = object[part]
+		}
+
+		next, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("référence introuvable %s", ref)
+		}
+		node = next
+	}
+}
+
+// validate vérifie une valeur contre le sous-ensemble de JSON Schema utilisé
+// par la spécification : type, enum, properties, required,
+// additionalProperties, items, allOf et oneOf
+func (s *Spec) validate(schema map[string]interface{}, value interface{}, at string) error {
+	schema, err := s.resolve(schema)
+	if err != nil {
+		return err
+	}
+
+	if allOf, ok := schema["allOf"].([]interface{}); ok {
+		for _, sub := range allOf {
+			if err := s.validate(sub.(map[string]interface{}), value, at); err != nil {
+				return err
+			}
+		}
+	}
+
+	if oneOf, ok := schema["oneOf"].([]interface{}); ok {
+		matches := 0
+		for _, sub := range oneOf {
+			if s.validate(sub.(map[string]interface{}), value, at) == nil {
+				matches++
+			}
+		}
+		if matches != 1 {
+			return fmt.Errorf("%s: %d schémas oneOf correspondent au lieu d'un seul", at, matches)
+		}
+	}
+
+	if types := schemaTypes(schema["type"]); len(types) > 0 {
+		matched := false
+		for _, t := range types {
+			if hasType(value, t) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("%s: type %s attendu, reçu %v", at, strings.Join(types, " ou "), value)
+		}
+	}
+
+	if enum, ok := schema["enum"].([]interface{}); ok {
+		found := false
+		for _, allowed := range enum {
+			if reflect.DeepEqual(allowed, value) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("%s: valeur %v absente de l'enum %v", at, value, enum)
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return s.validateObject(schema, v, at)
+	case []interface{}:
+		if items, ok := schema["items"].(map[string]interface{}); ok {
+			for i, item := range v {
+				if err := s.validate(items, item, fmt.Sprintf("%s/%d", at, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func (s *Spec) validateObject(schema map[string]interface{}, value map[string]interface{}, at string) error {
+	properties, _ := schema["properties"].(map[string]interface{})
+
+	required, _ := schema["required"].([]interface{})
+	for _, name := range required {
+		if _, ok := value[name.(string)]; !ok {
+			return fmt.Errorf("%s: propriété requise '%s' absente", at, name)
+		}
+	}
+
+	names := make([]string, 0, len(value))
+	for name := range value {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		property, ok := properties[name].(map[string]interface{})
+		if !ok {
+			if additional, ok := schema["additionalProperties"].(bool); ok && !additional {
+				return fmt.Errorf("%s: propriété '%s' non documentée", at, name)
+			}
+			continue
+		}
+		if err := s.validate(property, value[name], at+"/"+name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func schemaTypes(raw interface{}) []string {
+	switch t := raw.(type) {
+	case string:
+		return []string{t}
+	case []interface{}:
+		types := make([]string, 0, len(t))
+		for _, item := range t {
+			types = append(types, item.(string))
+		}
+		return types
+	}
+	return nil
+}
+
+func hasType(value interface{}, t string) bool {
+	switch t {
+	case "null":
+		return value == nil
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		_, ok := value.(float64)
+		return ok
+	case "integer":
+		n, ok := value.(float64)
+		return ok && n == math.Trunc(n)
+	case "array":
+		_, ok := value.([]interface{})
+		return ok
+	case "object":
+		_, ok := value.(map[string]interface{})
+		return ok
+	}
+	return false
+}
@@ -0,0 +1,250 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/japhy-tech/backend-test/internal/i18n"
+	"github.com/japhy-tech/backend-test/internal/repository"
+	"github.com/japhy-tech/backend-test/internal/requestid"
+	"github.com/japhy-tech/backend-test/internal/service"
+)
+
+// ContentType est le type MIME des erreurs au format RFC 7807
+const ContentType = "application/problem+json"
+
+// Code est un identifiant d'erreur stable, destiné aux clients qui ne doivent
+// pas dépendre du texte des messages
+type Code string
+
+const (
+	CodeValidationFailed    Code = "validation_failed"
+	CodeInvalidBody         Code = "invalid_body"
+	CodeInvalidID           Code = "invalid_id"
+	CodeInvalidCSV          Code = "invalid_csv"
+	CodeBreedNotFound       Code = "breed_not_found"
+	CodeSpeciesNotFound     Code = "species_not_found"
+	CodePetSizeNotFound     Code = "pet_size_not_found"
+	CodeTranslationNotFound Code = "translation_not_found"
+	CodeAliasNotFound       Code = "alias_not_found"
+	CodeAliasConflict       Code = "alias_conflict"
+	CodeInvalidQuery        Code = "invalid_query"
+	CodeQueryTooDeep        Code = "query_too_deep"
+	CodeQueryTooComplex     Code = "query_too_complex"
+	CodeRouteNotFound       Code = "route_not_found"
+	CodeMethodNotAllowed    Code = "method_not_allowed"
+	CodeDuplicateName       Code = "duplicate_name"
+	CodeConflict            Code = "conflict"
+	CodeUnauthenticated     Code = "unauthenticated"
+	CodeForbidden           Code = "forbidden"
+	CodeAPIKeyNotFound      Code = "api_key_not_found"
+	CodeRateLimited         Code = "rate_limited"
+	CodeInternal            Code = "internal_error"
+)
+
+// titles donne le résumé lisible de chaque code, traduit à l'écriture
+var titles = map[Code]i18n.Key{
+	CodeValidationFailed:    i18n.TitleValidationFailed,
+	CodeInvalidBody:         i18n.TitleInvalidBody,
+	CodeInvalidID:           i18n.TitleInvalidID,
+	CodeInvalidCSV:          i18n.TitleInvalidCSV,
+	CodeBreedNotFound:       i18n.TitleBreedNotFound,
+	CodeSpeciesNotFound:     i18n.TitleSpeciesNotFound,
+	CodePetSizeNotFound:     i18n.TitlePetSizeNotFound,
+	CodeTranslationNotFound: i18n.TitleTranslationNotFound,
+	CodeAliasNotFound:       i18n.TitleAliasNotFound,
+	CodeAliasConflict:       i18n.TitleAliasConflict,
+	CodeInvalidQuery:        i18n.TitleInvalidQuery,
+	CodeQueryTooDeep:        i18n.TitleQueryTooDeep,
+	CodeQueryTooComplex:     i18n.TitleQueryTooComplex,
+	CodeRouteNotFound:       i18n.TitleRouteNotFound,
+	CodeMethodNotAllowed:    i18n.TitleMethodNotAllowed,
+	CodeDuplicateName:       i18n.TitleDuplicateName,
+	CodeConflict:            i18n.TitleConflict,
+	CodeUnauthenticated:     i18n.TitleUnauthenticated,
+	CodeForbidden:           i18n.TitleForbidden,
+	CodeAPIKeyNotFound:      i18n.TitleAPIKeyNotFound,
+	CodeRateLimited:         i18n.TitleRateLimited,
+	CodeInternal:            i18n.TitleInternal,
+}
+
+// FieldError décrit un paramètre ou un champ invalide
+type FieldError struct {
+	Field   string `json:"field"`
+	Value   string `json:"value,omitempty"`
+	Message string `json:"message"`
+
+	// message est traduit dans la langue de la requête par Write
+	message i18n.Localizer
+}
+
+// NewFieldError crée l'erreur d'un champ à partir d'une erreur, traduite si
+// elle provient du catalogue
+func NewFieldError(field, value string, err error) FieldError {
+	return newFieldError(field, value, i18n.FromError(err))
+}
+
+func newFieldError(field, value string, message i18n.Localizer) FieldError {
+	return FieldError{Field: field, Value: value, Message: message.Localize(i18n.Default), message: message}
+}
+
+// FieldErrors regroupe toutes les erreurs de validation d'une requête
+type FieldErrors []FieldError
+
+func (e FieldErrors) Error() string {
+	return e.Localize(i18n.Default)
+}
+
+// Localize joint les messages de chaque champ, dans la langue demandée
+func (e FieldErrors) Localize(lang i18n.Lang) string {
+	messages := make([]string, 0, len(e))
+	for _, fieldError := range e {
+		message := fieldError.Message
+		if fieldError.message != nil {
+			message = fieldError.message.Localize(lang)
+		}
+		messages = append(messages, fieldError.Field+": "+message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Add ajoute une erreur sur un champ, décrite par un message du catalogue
+func (e *FieldErrors) Add(field, value string, key i18n.Key, args ...interface{}) {
+	*e = append(*e, newFieldError(field, value, i18n.New(key, args...)))
+}
+
+// Details est le corps d'une réponse d'erreur RFC 7807, étendu avec le code
+// stable, l'identifiant de requête et le détail par champ
+type Details struct {
+	Type      string      `json:"type"`
+	Title     string      `json:"title"`
+	Status    int         `json:"status"`
+	Detail    string      `json:"detail,omitempty"`
+	Instance  string      `json:"instance,omitempty"`
+	Code      Code        `json:"code"`
+	RequestID string      `json:"request_id,omitempty"`
+	Errors    FieldErrors `json:"errors,omitempty"`
+
+	// detail est traduit dans la langue de la requête par Write
+	detail i18n.Localizer
+}
+
+// New crée un problème pour un code donné, avec un détail déjà rédigé
+func New(status int, code Code, detail string) *Details {
+	return Localized(status, code, i18n.Text(detail))
+}
+
+// Localized crée un problème dont le détail est traduit à l'écriture
+func Localized(status int, code Code, detail i18n.Localizer) *Details {
+	return &Details{
+		Type:   "/problems/" + string(code),
+		Title:  i18n.T(i18n.Default, titles[code]),
+		Status: status,
+		Detail: detail.Localize(i18n.Default),
+		Code:   code,
+		detail: detail,
+	}
+}
+
+// Validation crée une 400 listant chaque champ invalide
+func Validation(errs FieldErrors) *Details {
+	details := Localized(http.StatusBadRequest, CodeValidationFailed, errs)
+	details.Errors = errs
+	return details
+}
+
+// Internal crée une 500 au détail générique
+func Internal() *Details {
+	return Localized(http.StatusInternalServerError, CodeInternal, i18n.New(i18n.InternalDetail))
+}
+
+// Write envoie le problème dans la langue négociée avec Accept-Language, en
+// complétant l'instance et l'identifiant de requête
+func Write(w http.ResponseWriter, r *http.Request, details *Details) {
+	lang := i18n.FromRequest(r)
+	details.Translate(lang)
+	details.Instance = r.URL.Path
+	details.RequestID = requestid.FromContext(r.Context())
+
+	i18n.SetContentLanguage(w, lang)
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(details.Status)
+	json.NewEncoder(w).Encode(details)
+}
+
+// Translate traduit le titre, le détail et les erreurs par champ, pour les
+// API qui ne passent pas par Write (GraphQL)
+func (d *Details) Translate(lang i18n.Lang) {
+	d.Title = i18n.T(lang, titles[d.Code])
+	if d.detail != nil {
+		d.Detail = d.detail.Localize(lang)
+	}
+	for i, fieldError := range d.Errors {
+		if fieldError.message != nil {
+			d.Errors[i].Message = fieldError.message.Localize(lang)
+		}
+	}
+}
+
+// NotFoundHandler répond aux routes inconnues
+func NotFoundHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Write(w, r, New(http.StatusNotFound, CodeRouteNotFound, ""))
+	})
+}
+
+// MethodNotAllowedHandler répond aux méthodes non prises en charge par une route
+func MethodNotAllowedHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		Write(w, r, New(http.StatusMethodNotAllowed, CodeMethodNotAllowed, ""))
+	})
+}
+
+// FromRepositoryError traduit une erreur typée des repositories en problème ;
+// notFound est le code renvoyé pour ErrNotFound. Retourne nil pour une erreur
+// inattendue, à traiter comme une erreur interne.
+func FromRepositoryError(err error, notFound Code) *Details {
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		return New(http.StatusNotFound, notFound, "")
+	case errors.Is(err, repository.ErrDuplicateName):
+		return Localized(http.StatusConflict, CodeDuplicateName, i18n.New(i18n.DuplicateName))
+	case errors.Is(err, repository.ErrConflict):
+		return Localized(http.StatusConflict, CodeConflict, i18n.New(i18n.StillReferenced))
+	case errors.Is(err, repository.ErrInvalid):
+		var repoErr *repository.Error
+		var detail i18n.Localizer = i18n.New(i18n.InvalidData)
+		switch {
+		case errors.Is(err, repository.ErrNothingToUpdate):
+			detail = i18n.New(i18n.NothingToUpdate)
+		case errors.As(err, &repoErr) && repoErr.Err != nil && !isDriverError(repoErr.Err):
+			detail = i18n.FromError(repoErr.Err)
+		}
+		return Localized(http.StatusBadRequest, CodeValidationFailed, detail)
+	}
+	return nil
+}
+
+// FromValidationError traduit les champs refusés par les règles d'écriture du
+// service ; retourne nil si err n'en est pas une
+func FromValidationError(err error) FieldErrors {
+	var validation service.ValidationError
+	if !errors.As(err, &validation) {
+		return nil
+	}
+
+	errs := make(FieldErrors, 0, len(validation))
+	for _, fieldError := range validation {
+		errs = append(errs, NewFieldError(fieldError.Field, fieldError.Value, fieldError.Err))
+	}
+	return errs
+}
+
+// isDriverError évite d'exposer le texte brut des erreurs MySQL
+func isDriverError(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	return errors.As(err, &mysqlErr)
+}
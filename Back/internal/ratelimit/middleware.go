@@ -0,0 +1,107 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/auth"
+	"github.com/japhy-tech/backend-test/internal/i18n"
+	"github.com/japhy-tech/backend-test/internal/logging"
+	"github.com/japhy-tech/backend-test/internal/problem"
+)
+
+// Limiter applique aux routes le budget de leur classe
+type Limiter struct {
+	store  Store
+	limits map[Class]Limit
+	logger *charmLog.Logger
+	now    func() time.Time
+}
+
+// NewLimiter crée un limiteur ; une classe absente de limits n'est pas
+// limitée
+func NewLimiter(store Store, limits map[Class]Limit, logger *charmLog.Logger) *Limiter {
+	return &Limiter{
+		store:  store,
+		limits: limits,
+		logger: logger,
+		now:    time.Now,
+	}
+}
+
+// Limit décompte chaque requête dans le budget de sa classe, par appelant
+// authentifié ou à défaut par adresse IP, annonce le budget restant dans les
+// en-têtes RateLimit-* et refuse la requête en 429 une fois le budget épuisé.
+// Si le store est indisponible, la requête est laissée passer.
+func (l *Limiter) Limit(class Class, next http.HandlerFunc) http.Handler {
+	return l.limit(class, clientKey, next)
+}
+
+// LimitAddress décompte chaque requête dans le budget IP de son adresse,
+// avant toute authentification : des clés ou des jetons refusés l'épuisent
+// aussi, ce qui freine leur essai en série
+func (l *Limiter) LimitAddress(next http.Handler) http.Handler {
+	return l.limit(IP, addressKey, next)
+}
+
+func (l *Limiter) limit(class Class, key func(*http.Request) string, next http.Handler) http.Handler {
+	limit := l.limits[class]
+	if !limit.Enabled() {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		client := key(r)
+		result, err := l.store.Take(string(class)+":"+client, limit, l.now())
+		if err != nil {
+			logging.FromContext(r.Context(), l.logger).Error("Erreur lors de la limitation du débit", "error", err, "client", client)
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		header := w.Header()
+		header.Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", limit.Requests, int(limit.Window.Seconds())))
+		header.Set("RateLimit-Limit", strconv.Itoa(limit.Requests))
+		header.Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		header.Set("RateLimit-Reset", strconv.Itoa(seconds(result.Reset)))
+
+		if !result.Allowed {
+			retryAfter := seconds(result.RetryAfter)
+			header.Set("Retry-After", strconv.Itoa(retryAfter))
+			logging.FromContext(r.Context(), l.logger).Warn("Limite de débit atteinte", "class", class, "client", client, "path", r.URL.Path)
+			problem.Write(w, r, problem.Localized(http.StatusTooManyRequests, problem.CodeRateLimited,
+				i18n.New(i18n.RateLimited, limit.Requests, int(limit.Window.Seconds()), retryAfter)))
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// clientKey identifie l'appelant authentifié ou, à défaut, son adresse IP
+func clientKey(r *http.Request) string {
+	if subject := auth.Subject(r.Context()); subject != "" {
+		return subject
+	}
+	return addressKey(r)
+}
+
+// addressKey identifie l'adresse IP de l'appelant, sans son port source
+func addressKey(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "ip:" + host
+}
+
+// seconds arrondit un délai à la seconde supérieure, comme l'attendent les
+// en-têtes Retry-After et RateLimit-Reset
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
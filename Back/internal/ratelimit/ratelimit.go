@@ -0,0 +1,94 @@
+// Package ratelimit borne le nombre de requêtes de chaque client, avec un
+// seau de jetons par client et par classe de routes
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+// Class regroupe les routes qui partagent un même budget
+type Class string
+
+const (
+	// Read couvre les lectures (GET et requêtes GraphQL)
+	Read Class = "read"
+	// Write couvre les créations, modifications et suppressions
+	Write Class = "write"
+	// Import couvre les imports CSV, bien plus coûteux
+	Import Class = "import"
+	// IP couvre toutes les requêtes d'une adresse, authentifiées ou non,
+	// avant le budget de leur classe
+	IP Class = "ip"
+)
+
+// Limit est le budget d'une classe : Requests requêtes par Window, avec une
+// rafale d'au plus Requests requêtes. Un budget nul désactive la limite.
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// Enabled indique si la limite s'applique
+func (l Limit) Enabled() bool {
+	return l.Requests > 0 && l.Window > 0
+}
+
+// rate est le nombre de jetons rendus par seconde
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Window.Seconds()
+}
+
+// Result est l'issue d'une demande de jeton
+type Result struct {
+	Allowed   bool
+	Remaining int
+	// Reset est le délai avant que le seau soit de nouveau plein
+	Reset time.Duration
+	// RetryAfter est le délai avant le prochain jeton, nul si la requête
+	// est acceptée
+	RetryAfter time.Duration
+}
+
+// Bucket est l'état d'un seau de jetons, tel que conservé par un Store
+type Bucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+// full retourne le seau plein d'un client encore jamais vu
+func (l Limit) full(now time.Time) Bucket {
+	return Bucket{Tokens: float64(l.Requests), UpdatedAt: now}
+}
+
+// take remplit le seau du temps écoulé puis tente d'y prendre un jeton
+func (l Limit) take(bucket Bucket, now time.Time) (Bucket, Result) {
+	capacity := float64(l.Requests)
+	if elapsed := now.Sub(bucket.UpdatedAt).Seconds(); elapsed > 0 {
+		bucket.Tokens = math.Min(capacity, bucket.Tokens+elapsed*l.rate())
+	}
+	bucket.UpdatedAt = now
+
+	result := Result{}
+	if bucket.Tokens >= 1 {
+		bucket.Tokens--
+		result.Allowed = true
+	} else {
+		result.RetryAfter = l.duration(1 - bucket.Tokens)
+	}
+	result.Remaining = int(math.Floor(bucket.Tokens))
+	result.Reset = l.duration(capacity - bucket.Tokens)
+	return bucket, result
+}
+
+// duration est le temps nécessaire pour regagner le nombre de jetons donné,
+// arrondi à la nanoseconde pour absorber les erreurs de calcul flottant
+func (l Limit) duration(tokens float64) time.Duration {
+	return time.Duration(math.Round(tokens / l.rate() * float64(time.Second)))
+}
+
+// Store conserve les seaux de jetons ; Take doit être atomique pour une même
+// clé, y compris entre plusieurs instances si le store est partagé
+type Store interface {
+	Take(key string, limit Limit, now time.Time) (Result, error)
+}
@@ -0,0 +1,214 @@
+package ratelimit
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/auth"
+	"github.com/japhy-tech/backend-test/internal/repository"
+)
+
+var start = time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func TestTake_RefillsOverTime(t *testing.T) {
+	limit := Limit{Requests: 2, Window: time.Minute}
+	bucket := limit.full(start)
+
+	var result Result
+	for i := 0; i < 2; i++ {
+		bucket, result = limit.take(bucket, start)
+		if !result.Allowed {
+			t.Fatalf("requête %d refusée alors que le seau n'est pas vide", i+1)
+		}
+	}
+	if result.Remaining != 0 || result.Reset != time.Minute {
+		t.Errorf("attendu 0 restant et plein dans 60 s, obtenu %+v", result)
+	}
+
+	bucket, result = limit.take(bucket, start.Add(10*time.Second))
+	if result.Allowed || result.RetryAfter != 20*time.Second {
+		t.Errorf("attendu un refus avec 20 s d'attente, obtenu %+v", result)
+	}
+
+	// Un jeton est rendu toutes les 30 s
+	_, result = limit.take(bucket, start.Add(30*time.Second))
+	if !result.Allowed || result.RetryAfter != 0 {
+		t.Errorf("attendu une requête acceptée après 30 s, obtenu %+v", result)
+	}
+}
+
+func TestMemoryStore_SeparatesKeys(t *testing.T) {
+	store := NewMemoryStore(time.Hour)
+	limit := Limit{Requests: 1, Window: time.Minute}
+
+	if result, _ := store.Take("write:ip:10.0.0.1", limit, start); !result.Allowed {
+		t.Fatal("première requête refusée")
+	}
+	if result, _ := store.Take("write:ip:10.0.0.1", limit, start); result.Allowed {
+		t.Error("deuxième requête acceptée au-delà du budget")
+	}
+	if result, _ := store.Take("write:ip:10.0.0.2", limit, start); !result.Allowed {
+		t.Error("un autre client ne doit pas partager le budget")
+	}
+
+	// La purge oublie les seaux inutilisés, qui seraient de toute façon pleins
+	store.Take("write:ip:10.0.0.3", limit, start.Add(2*time.Hour))
+	if len(store.buckets) != 1 {
+		t.Errorf("attendu 1 seau après la purge, obtenu %d", len(store.buckets))
+	}
+}
+
+type mockRepository struct {
+	bucket *repository.RateLimitBucket
+	err    error
+	swept  []time.Time
+}
+
+func (m *mockRepository) Update(key string, initial repository.RateLimitBucket, update func(repository.RateLimitBucket) repository.RateLimitBucket) error {
+	if m.err != nil {
+		return m.err
+	}
+	if m.bucket == nil {
+		m.bucket = &initial
+	}
+	*m.bucket = update(*m.bucket)
+	return nil
+}
+
+func (m *mockRepository) DeleteIdle(before time.Time) (int64, error) {
+	m.swept = append(m.swept, before)
+	return 0, nil
+}
+
+func TestDatabaseStore_Take(t *testing.T) {
+	repo := &mockRepository{}
+	store := NewDatabaseStore(repo, time.Hour, log.NewWithOptions(nil, log.Options{}))
+	limit := Limit{Requests: 3, Window: time.Minute}
+
+	result, err := store.Take("read:ip:10.0.0.1", limit, start)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+	if !result.Allowed || result.Remaining != 2 || repo.bucket.Tokens != 2 {
+		t.Errorf("attendu 2 jetons restants, obtenu %+v (seau %+v)", result, repo.bucket)
+	}
+
+	// Close attend la purge lancée en arrière-plan par la première requête
+	store.Close()
+	if len(repo.swept) != 1 || !repo.swept[0].Equal(start.Add(-time.Hour)) {
+		t.Errorf("attendu une purge des seaux antérieurs à %v, obtenu %v", start.Add(-time.Hour), repo.swept)
+	}
+}
+
+func newTestLimiter(store Store) *Limiter {
+	limiter := NewLimiter(store, map[Class]Limit{
+		Write: {Requests: 1, Window: time.Minute},
+	}, log.NewWithOptions(nil, log.Options{}))
+	limiter.now = func() time.Time { return start }
+	return limiter
+}
+
+func TestLimit_RejectsOverBudget(t *testing.T) {
+	handler := newTestLimiter(NewMemoryStore(time.Hour)).Limit(Write, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	send := func(remoteAddr string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/v1/breeds", nil)
+		req.RemoteAddr = remoteAddr
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		return w
+	}
+
+	w := send("10.0.0.1:1234")
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("attendu 204, obtenu %d", w.Code)
+	}
+	if w.Header().Get("RateLimit-Policy") != "1;w=60" || w.Header().Get("RateLimit-Remaining") != "0" || w.Header().Get("RateLimit-Reset") != "60" {
+		t.Errorf("en-têtes RateLimit inattendus: %v", w.Header())
+	}
+
+	// Le port source change d'une connexion à l'autre, seule l'adresse compte
+	w = send("10.0.0.1:5678")
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("attendu 429, obtenu %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") != "60" {
+		t.Errorf("attendu Retry-After 60, obtenu %q", w.Header().Get("Retry-After"))
+	}
+	var body struct {
+		Code string `json:"code"`
+	}
+	if err := json.NewDecoder(w.Body).Decode(&body); err != nil || body.Code != "rate_limited" {
+		t.Errorf("attendu le code rate_limited, obtenu %q (%v)", body.Code, err)
+	}
+}
+
+func TestLimit_KeysByPrincipal(t *testing.T) {
+	handler := newTestLimiter(NewMemoryStore(time.Hour)).Limit(Write, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	// Deux clés derrière la même adresse ont chacune leur budget
+	for _, subject := range []string{"api_key:1", "api_key:2"} {
+		req := httptest.NewRequest(http.MethodPost, "/v1/breeds", nil)
+		req = req.WithContext(auth.WithPrincipal(req.Context(), &auth.Principal{Subject: subject, Role: auth.RoleEditor}))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		if w.Code != http.StatusNoContent {
+			t.Errorf("%s: attendu 204, obtenu %d", subject, w.Code)
+		}
+	}
+}
+
+func TestLimit_StoreErrorLetsThrough(t *testing.T) {
+	store := NewDatabaseStore(&mockRepository{err: errors.New("connexion perdue")}, time.Hour, log.NewWithOptions(nil, log.Options{}))
+	handler := newTestLimiter(store).Limit(Write, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/v1/breeds", nil))
+	if w.Code != http.StatusNoContent || w.Header().Get("RateLimit-Limit") != "" {
+		t.Errorf("attendu la requête transmise sans en-têtes, obtenu %d %v", w.Code, w.Header())
+	}
+}
+
+func TestLimit_DisabledClass(t *testing.T) {
+	handler := newTestLimiter(NewMemoryStore(time.Hour)).Limit(Read, func(w http.ResponseWriter, r *http.Request) {})
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/breeds", nil))
+	if w.Header().Get("RateLimit-Limit") != "" {
+		t.Error("une classe sans budget ne doit pas être limitée")
+	}
+}
+
+func TestLimitAddress_IgnoresPrincipal(t *testing.T) {
+	limiter := NewLimiter(NewMemoryStore(time.Hour), map[Class]Limit{
+		IP: {Requests: 1, Window: time.Minute},
+	}, log.NewWithOptions(nil, log.Options{}))
+	limiter.now = func() time.Time { return start }
+	handler := limiter.LimitAddress(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+
+	// Changer de clé ne rend pas de budget à l'adresse
+	codes := []int{}
+	for _, subject := range []string{"api_key:1", "api_key:2"} {
+		req := httptest.NewRequest(http.MethodGet, "/v1/breeds", nil)
+		req = req.WithContext(auth.WithPrincipal(req.Context(), &auth.Principal{Subject: subject}))
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		codes = append(codes, w.Code)
+	}
+	if codes[0] != http.StatusUnauthorized || codes[1] != http.StatusTooManyRequests {
+		t.Errorf("attendu 401 puis 429, obtenu %v", codes)
+	}
+}
@@ -0,0 +1,117 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/japhy-tech/backend-test/internal/repository"
+)
+
+// sweepInterval espace les purges des seaux inutilisés
+const sweepInterval = time.Minute
+
+// MemoryStore garde les seaux en mémoire : chaque instance a son propre
+// budget, ce qui suffit pour un déploiement à une seule instance
+type MemoryStore struct {
+	mu        sync.Mutex
+	buckets   map[string]Bucket
+	idle      time.Duration
+	lastSweep time.Time
+}
+
+// NewMemoryStore crée un store en mémoire ; un seau inutilisé depuis idle
+// (la plus longue fenêtre des budgets) est plein et peut être oublié
+func NewMemoryStore(idle time.Duration) *MemoryStore {
+	return &MemoryStore{
+		buckets: map[string]Bucket{},
+		idle:    idle,
+	}
+}
+
+// Take consomme un jeton du seau de la clé
+func (s *MemoryStore) Take(key string, limit Limit, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastSweep) >= sweepInterval {
+		for k, bucket := range s.buckets {
+			if now.Sub(bucket.UpdatedAt) > s.idle {
+				delete(s.buckets, k)
+			}
+		}
+		s.lastSweep = now
+	}
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = limit.full(now)
+	}
+	bucket, result := limit.take(bucket, now)
+	s.buckets[key] = bucket
+	return result, nil
+}
+
+// DatabaseStore partage les seaux entre les instances via la base
+type DatabaseStore struct {
+	repo   repository.RateLimitRepositoryInterface
+	idle   time.Duration
+	logger *charmLog.Logger
+
+	mu        sync.Mutex
+	lastSweep time.Time
+	sweeping  sync.WaitGroup
+}
+
+// NewDatabaseStore crée un store en base ; les seaux inutilisés depuis idle
+// sont purgés au fil de l'eau
+func NewDatabaseStore(repo repository.RateLimitRepositoryInterface, idle time.Duration, logger *charmLog.Logger) *DatabaseStore {
+	return &DatabaseStore{
+		repo:   repo,
+		idle:   idle,
+		logger: logger,
+	}
+}
+
+// Take consomme un jeton du seau de la clé, verrouillé le temps du calcul
+func (s *DatabaseStore) Take(key string, limit Limit, now time.Time) (Result, error) {
+	s.sweep(now)
+
+	var result Result
+	initial := limit.full(now)
+	err := s.repo.Update(key, repository.RateLimitBucket{Tokens: initial.Tokens, UpdatedAt: initial.UpdatedAt}, func(stored repository.RateLimitBucket) repository.RateLimitBucket {
+		var bucket Bucket
+		bucket, result = limit.take(Bucket{Tokens: stored.Tokens, UpdatedAt: stored.UpdatedAt}, now)
+		return repository.RateLimitBucket{Tokens: bucket.Tokens, UpdatedAt: bucket.UpdatedAt}
+	})
+	return result, err
+}
+
+// sweep purge les seaux inutilisés au plus une fois par sweepInterval et
+// par instance, en arrière-plan pour ne pas ralentir la requête
+func (s *DatabaseStore) sweep(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if now.Sub(s.lastSweep) < sweepInterval {
+		return
+	}
+	s.lastSweep = now
+
+	s.sweeping.Add(1)
+	go func() {
+		defer s.sweeping.Done()
+		deleted, err := s.repo.DeleteIdle(now.Add(-s.idle))
+		if err != nil {
+			s.logger.Warn("Erreur lors de la purge des seaux de limitation", "error", err)
+			return
+		}
+		if deleted > 0 {
+			s.logger.Debug("Seaux de limitation purgés", "count", deleted)
+		}
+	}()
+}
+
+// Close attend la fin de la purge en cours, avant la fermeture de la base
+func (s *DatabaseStore) Close() {
+	s.sweeping.Wait()
+}
@@ -0,0 +1,221 @@
+// Package replicas répartit les lectures qui tolèrent un léger retard entre
+// les réplicas MySQL, en écartant celles qui ne répondent plus ou dont la
+// réplication a trop de retard ; sans réplique disponible, les lectures vont
+// à la base principale
+package replicas
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	charmLog "github.com/charmbracelet/log"
+	"github.com/go-sql-driver/mysql"
+	"github.com/japhy-tech/backend-test/internal/retry"
+)
+
+// mysqlParseError est l'erreur de syntaxe que renvoie MySQL avant 8.0.22
+// pour SHOW REPLICA STATUS
+const mysqlParseError = 1064
+
+// errReplicationStopped signale une réplique dont la réplication est
+// arrêtée : son retard est inconnu
+var errReplicationStopped = errors.New("réplication arrêtée")
+
+// Options règle la surveillance des réplicas
+type Options struct {
+	// MaxLag est le retard de réplication au-delà duquel une réplique est
+	// écartée
+	MaxLag time.Duration
+	// CheckTimeout borne la vérification de chaque réplique
+	CheckTimeout time.Duration
+	Logger       *charmLog.Logger
+}
+
+// Set choisit la base des lectures, à tour de rôle parmi les réplicas
+// disponibles ; il implémente repository.ReadRouter
+type Set struct {
+	primary  *sql.DB
+	replicas []*replica
+	opts     Options
+	next     atomic.Uint64
+
+	stop     chan struct{}
+	checking sync.WaitGroup
+}
+
+type replica struct {
+	// name désigne la réplique dans les logs sans révéler son DSN
+	name      string
+	db        *sql.DB
+	available atomic.Bool
+}
+
+// New crée l'ensemble des réplicas de primary ; elles ne reçoivent des
+// lectures qu'une fois vérifiées par Check
+func New(primary *sql.DB, pools []*sql.DB, opts Options) *Set {
+	s := &Set{primary: primary, opts: opts}
+	for i, db := range pools {
+		s.replicas = append(s.replicas, &replica{name: "replica-" + strconv.Itoa(i+1), db: db})
+	}
+	return s
+}
+
+// Reader retourne la réplique disponible suivante, ou la base principale
+func (s *Set) Reader(ctx context.Context) *sql.DB {
+	n := uint64(len(s.replicas))
+	if n == 0 {
+		return s.primary
+	}
+
+	start := s.next.Add(1)
+	for i := uint64(0); i < n; i++ {
+		if r := s.replicas[(start+i)%n]; r.available.Load() {
+			return r.db
+		}
+	}
+	return s.primary
+}
+
+// Failed écarte une réplique dont la connexion vient d'échouer, jusqu'à la
+// prochaine vérification réussie ; les erreurs propres à la requête ne la
+// mettent pas en cause
+func (s *Set) Failed(db *sql.DB, err error) {
+	if !retry.Transient(err) {
+		return
+	}
+	for _, r := range s.replicas {
+		if r.db == db {
+			s.update(r, err)
+		}
+	}
+}
+
+// Check vérifie chaque réplique : elle doit répondre et avoir au plus
+// MaxLag de retard
+func (s *Set) Check(ctx context.Context) {
+	for _, r := range s.replicas {
+		s.update(r, s.probe(ctx, r.db))
+	}
+}
+
+// Start vérifie les réplicas tout de suite puis toutes les interval, jusqu'à
+// Close
+func (s *Set) Start(interval time.Duration) {
+	if len(s.replicas) == 0 {
+		return
+	}
+
+	s.stop = make(chan struct{})
+	s.checking.Add(1)
+	go func() {
+		defer s.checking.Done()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			s.Check(context.Background())
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Close arrête les vérifications et attend la dernière en cours
+func (s *Set) Close() {
+	if s.stop == nil {
+		return
+	}
+	close(s.stop)
+	s.checking.Wait()
+}
+
+func (s *Set) probe(ctx context.Context, db *sql.DB) error {
+	if s.opts.CheckTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.opts.CheckTimeout)
+		defer cancel()
+	}
+
+	if err := db.PingContext(ctx); err != nil {
+		return err
+	}
+	lag, err := replicationLag(ctx, db)
+	if err != nil {
+		return err
+	}
+	if lag > s.opts.MaxLag {
+		return fmt.Errorf("retard de réplication de %v, %v au plus", lag, s.opts.MaxLag)
+	}
+	return nil
+}
+
+// update rend la réplique disponible ou l'écarte, et journalise le
+// changement d'état
+func (s *Set) update(r *replica, err error) {
+	available := err == nil
+	if r.available.Swap(available) == available || s.opts.Logger == nil {
+		return
+	}
+	if available {
+		s.opts.Logger.Info("Réplique disponible pour les lectures", "replica", r.name)
+	} else {
+		s.opts.Logger.Warn("Réplique écartée des lectures", "replica", r.name, "error", err)
+	}
+}
+
+// replicationLag lit le retard de la réplique dans SHOW REPLICA STATUS, ou
+// SHOW SLAVE STATUS avant MySQL 8.0.22 ; une base qui ne réplique rien est
+// considérée à jour
+func replicationLag(ctx context.Context, db *sql.DB) (time.Duration, error) {
+	rows, err := db.QueryContext(ctx, "SHOW REPLICA STATUS")
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlParseError {
+		rows, err = db.QueryContext(ctx, "SHOW SLAVE STATUS")
+	}
+	if err != nil {
+		return 0, fmt.Errorf("erreur lors de la lecture de l'état de la réplication: %w", err)
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return 0, rows.Err()
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return 0, fmt.Errorf("erreur lors de la lecture de l'état de la réplication: %w", err)
+	}
+	values := make([]sql.NullString, len(columns))
+	dest := make([]interface{}, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err := rows.Scan(dest...); err != nil {
+		return 0, fmt.Errorf("erreur lors de la lecture de l'état de la réplication: %w", err)
+	}
+
+	for i, column := range columns {
+		// Seconds_Behind_Master avant MySQL 8.0.22
+		if column != "Seconds_Behind_Source" && column != "Seconds_Behind_Master" {
+			continue
+		}
+		if !values[i].Valid {
+			return 0, errReplicationStopped
+		}
+		seconds, err := strconv.Atoi(values[i].String)
+		if err != nil {
+			return 0, fmt.Errorf("retard de réplication illisible %q: %w", values[i].String, err)
+		}
+		return time.Duration(seconds) * time.Second, nil
+	}
+	return 0, errors.New("retard de réplication absent de SHOW REPLICA STATUS")
+}
@@ -0,0 +1,129 @@
+package replicas
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+)
+
+// newReplica crée une réplique simulée qui attend les vérifications de Check
+func newReplica(t *testing.T) (*sql.DB, sqlmock.Sqlmock) {
+	t.Helper()
+
+	db, mock, err := sqlmock.New(sqlmock.MonitorPingsOption(true))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db, mock
+}
+
+func expectStatus(mock sqlmock.Sqlmock, lag driver.Value) {
+	mock.ExpectPing()
+	mock.ExpectQuery("SHOW REPLICA STATUS").
+		WillReturnRows(sqlmock.NewRows([]string{"Replica_IO_State", "Seconds_Behind_Source"}).AddRow("Waiting for source to send event", lag))
+}
+
+func TestSet_RoundRobin(t *testing.T) {
+	primary := &sql.DB{}
+	first, firstMock := newReplica(t)
+	second, secondMock := newReplica(t)
+	expectStatus(firstMock, 0)
+	expectStatus(secondMock, 1)
+
+	set := New(primary, []*sql.DB{first, second}, Options{MaxLag: 5 * time.Second})
+	if set.Reader(context.Background()) != primary {
+		t.Error("une réplique non vérifiée ne doit pas recevoir de lectures")
+	}
+
+	set.Check(context.Background())
+	seen := map[*sql.DB]int{}
+	for range 4 {
+		seen[set.Reader(context.Background())]++
+	}
+	if seen[first] != 2 || seen[second] != 2 {
+		t.Errorf("lectures mal réparties : %v", seen)
+	}
+}
+
+func TestSet_EjectsLaggingReplica(t *testing.T) {
+	primary := &sql.DB{}
+	lagging, laggingMock := newReplica(t)
+	stopped, stoppedMock := newReplica(t)
+	expectStatus(laggingMock, 30)
+	expectStatus(stoppedMock, nil)
+
+	set := New(primary, []*sql.DB{lagging, stopped}, Options{MaxLag: 5 * time.Second})
+	set.Check(context.Background())
+	if set.Reader(context.Background()) != primary {
+		t.Error("une réplique en retard ou arrêtée doit être écartée")
+	}
+}
+
+func TestSet_Failed(t *testing.T) {
+	primary := &sql.DB{}
+	replica, mock := newReplica(t)
+	expectStatus(mock, 0)
+
+	set := New(primary, []*sql.DB{replica}, Options{MaxLag: 5 * time.Second})
+	set.Check(context.Background())
+
+	set.Failed(replica, &mysql.MySQLError{Number: 1062})
+	if set.Reader(context.Background()) != replica {
+		t.Error("une erreur de requête ne met pas la réplique en cause")
+	}
+
+	set.Failed(replica, driver.ErrBadConn)
+	if set.Reader(context.Background()) != primary {
+		t.Error("une réplique dont la connexion échoue doit être écartée")
+	}
+
+	// Elle revient à la vérification suivante
+	expectStatus(mock, 0)
+	set.Check(context.Background())
+	if set.Reader(context.Background()) != replica {
+		t.Error("la réplique doit être rétablie par une vérification réussie")
+	}
+}
+
+func TestSet_UnreachableReplica(t *testing.T) {
+	primary := &sql.DB{}
+	replica, mock := newReplica(t)
+	mock.ExpectPing().WillReturnError(errors.New("connection refused"))
+
+	set := New(primary, []*sql.DB{replica}, Options{MaxLag: 5 * time.Second})
+	set.Check(context.Background())
+	if set.Reader(context.Background()) != primary {
+		t.Error("une réplique injoignable doit être écartée")
+	}
+}
+
+func TestReplicationLag_NotReplicating(t *testing.T) {
+	db, mock := newReplica(t)
+	mock.ExpectQuery("SHOW REPLICA STATUS").WillReturnRows(sqlmock.NewRows([]string{"Seconds_Behind_Source"}))
+
+	if lag, err := replicationLag(context.Background(), db); err != nil || lag != 0 {
+		t.Errorf("retard %v, erreur %v : une base qui ne réplique rien est à jour", lag, err)
+	}
+}
+
+func TestReplicationLag_BeforeMySQL8022(t *testing.T) {
+	db, mock := newReplica(t)
+	mock.ExpectQuery("SHOW REPLICA STATUS").WillReturnError(&mysql.MySQLError{Number: 1064, Message: "You have an error in your SQL syntax"})
+	mock.ExpectQuery("SHOW SLAVE STATUS").
+		WillReturnRows(sqlmock.NewRows([]string{"Slave_IO_State", "Seconds_Behind_Master"}).AddRow("Waiting for master to send event", 3))
+
+	lag, err := replicationLag(context.Background(), db)
+	if err != nil || lag != 3*time.Second {
+		t.Errorf("retard %v, erreur %v : attendu 3s lu dans SHOW SLAVE STATUS", lag, err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// APIKey est une clé d'accès à l'API ; seule son empreinte SHA-256 est
+// stockée, la clé elle-même n'est affichée qu'à sa création
+type APIKey struct {
+	ID        int        `json:"id" db:"id"`
+	Name      string     `json:"name" db:"name"`
+	Prefix    string     `json:"prefix" db:"prefix"`
+	Hash      string     `json:"-" db:"key_hash"`
+	Role      string     `json:"role" db:"role"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+}
+
+type APIKeyRepositoryInterface interface {
+	List() ([]APIKey, error)
+	FindActiveByHash(hash string) (*APIKey, error)
+	Create(key *APIKey) error
+	Revoke(id int) error
+}
+
+type APIKeyRepository struct {
+	db *sql.DB
+}
+
+func NewAPIKeyRepository(db *sql.DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+const selectAPIKeysQuery = "SELECT id, name, prefix, key_hash, role, created_at, revoked_at FROM api_keys"
+
+// List retourne toutes les clés, révoquées comprises, des plus récentes aux
+// plus anciennes
+func (r *APIKeyRepository) List() ([]APIKey, error) {
+	rows, err := r.db.Query(selectAPIKeysQuery + " ORDER BY id DESC")
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des clés: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, *key)
+	}
+
+	return keys, rows.Err()
+}
+
+// FindActiveByHash retourne la clé non révoquée ayant cette empreinte, ou
+// ErrNotFound
+func (r *APIKeyRepository) FindActiveByHash(hash string) (*APIKey, error) {
+	row := r.db.QueryRow(selectAPIKeysQuery+" WHERE key_hash = ? AND revoked_at IS NULL", hash)
+	key, err := scanAPIKey(row)
+	if err != nil {
+		return nil, classify(err, "api_key")
+	}
+
+	return key, nil
+}
+
+// Create enregistre une clé et complète son ID et sa date de création ; une
+// empreinte déjà connue renvoie ErrDuplicateName
+func (r *APIKeyRepository) Create(key *APIKey) error {
+	createdAt := time.Now().UTC().Truncate(time.Second)
+	result, err := r.db.Exec("INSERT INTO api_keys (name, prefix, key_hash, role, created_at) VALUES (?, ?, ?, ?, ?)",
+		key.Name, key.Prefix, key.Hash, key.Role, createdAt)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la création de la clé: %w", classify(err, "api_key"))
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("erreur lors de la récupération de l'ID: %w", err)
+	}
+
+	key.ID = int(id)
+	key.CreatedAt = createdAt
+	return nil
+}
+
+// Revoke révoque une clé ; une clé inconnue ou déjà révoquée renvoie
+// ErrNotFound
+func (r *APIKeyRepository) Revoke(id int) error {
+	result, err := r.db.Exec("UPDATE api_keys SET revoked_at = ? WHERE id = ? AND revoked_at IS NULL", time.Now().UTC(), id)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la révocation de la clé: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erreur lors de la vérification de la révocation: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return newError(ErrNotFound, "api_key", nil)
+	}
+
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAPIKey(row rowScanner) (*APIKey, error) {
+	var key APIKey
+	var revokedAt sql.NullTime
+	if err := row.Scan(&key.ID, &key.Name, &key.Prefix, &key.Hash, &key.Role, &key.CreatedAt, &revokedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, err
+		}
+		return nil, fmt.Errorf("erreur lors du scan de la clé: %w", err)
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+
+	return &key, nil
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// BreedAlias est un autre nom sous lequel une race est connue ; l'alias est
+// stocké normalisé et ne peut désigner qu'une seule race
+type BreedAlias struct {
+	Alias     string `json:"alias" db:"alias"`
+	BreedID   int    `json:"breed_id" db:"breed_id"`
+	BreedName string `json:"breed_name" db:"name"`
+}
+
+type BreedAliasRepositoryInterface interface {
+	List() ([]BreedAlias, error)
+	ListForBreed(breedID int) ([]BreedAlias, error)
+	Create(alias *BreedAlias) error
+	Delete(breedID int, alias string) error
+	Import(aliases []BreedAlias) (ImportStats, error)
+}
+
+type BreedAliasRepository struct {
+	db *sql.DB
+}
+
+func NewBreedAliasRepository(db *sql.DB) *BreedAliasRepository {
+	return &BreedAliasRepository{db: db}
+}
+
+const selectAliasesQuery = "SELECT a.alias, a.breed_id, b.name FROM breed_aliases a JOIN breeds b ON b.id = a.breed_id"
+
+// List retourne tous les alias avec le nom canonique de leur race
+func (r *BreedAliasRepository) List() ([]BreedAlias, error) {
+	return r.query(selectAliasesQuery + " ORDER BY b.name, a.alias")
+}
+
+// ListForBreed retourne les alias d'une race
+func (r *BreedAliasRepository) ListForBreed(breedID int) ([]BreedAlias, error) {
+	return r.query(selectAliasesQuery+" WHERE a.breed_id = ? ORDER BY a.alias", breedID)
+}
+
+func (r *BreedAliasRepository) query(query string, args ...interface{}) ([]BreedAlias, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des alias: %w", err)
+	}
+	defer rows.Close()
+
+	aliases := []BreedAlias{}
+	for rows.Next() {
+		var alias BreedAlias
+		if err := rows.Scan(&alias.Alias, &alias.BreedID, &alias.BreedName); err != nil {
+			return nil, fmt.Errorf("erreur lors du scan de l'alias: %w", err)
+		}
+		aliases = append(aliases, alias)
+	}
+
+	return aliases, rows.Err()
+}
+
+// Create ajoute un alias ; un alias déjà utilisé renvoie ErrDuplicateName
+func (r *BreedAliasRepository) Create(alias *BreedAlias) error {
+	_, err := r.db.Exec("INSERT INTO breed_aliases (alias, breed_id) VALUES (?, ?)", alias.Alias, alias.BreedID)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la création de l'alias: %w", classify(err, "breed_alias"))
+	}
+
+	return nil
+}
+
+func (r *BreedAliasRepository) Delete(breedID int, alias string) error {
+	result, err := r.db.Exec("DELETE FROM breed_aliases WHERE breed_id = ? AND alias = ?", breedID, alias)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la suppression de l'alias: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erreur lors de la vérification de la suppression: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return newError(ErrNotFound, "breed_alias", nil)
+	}
+
+	return nil
+}
+
+// Import ajoute les alias en une transaction ; un alias déjà associé à la
+// même race est ignoré, un alias associé à une autre race fait échouer
+// l'import avec ErrDuplicateName
+func (r *BreedAliasRepository) Import(aliases []BreedAlias) (ImportStats, error) {
+	var stats ImportStats
+	tx, err := r.db.Begin()
+	if err != nil {
+		return ImportStats{}, fmt.Errorf("erreur lors du début de la transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare("INSERT INTO breed_aliases (alias, breed_id) VALUES (?, ?)")
+	if err != nil {
+		return ImportStats{}, fmt.Errorf("erreur lors de la préparation de la requête: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, alias := range aliases {
+		var existing int
+		err := tx.QueryRow("SELECT breed_id FROM breed_aliases WHERE alias = ? FOR UPDATE", alias.Alias).Scan(&existing)
+		switch {
+		case err == sql.ErrNoRows:
+		case err != nil:
+			return ImportStats{}, fmt.Errorf("erreur lors de la vérification de l'alias %s: %w", alias.Alias, err)
+		case existing == alias.BreedID:
+			stats.Unchanged++
+			continue
+		default:
+			return ImportStats{}, newError(ErrDuplicateName, "breed_alias", fmt.Errorf("alias %s déjà associé à la race %d", alias.Alias, existing))
+		}
+
+		if _, err := stmt.Exec(alias.Alias, alias.BreedID); err != nil {
+			return ImportStats{}, fmt.Errorf("erreur lors de l'insertion de l'alias %s: %w", alias.Alias, classify(err, "breed_alias"))
+		}
+		stats.Inserted++
+	}
+
+	return stats, tx.Commit()
+}
@@ -0,0 +1,238 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WeightSex indique quels poids moyens (mâle, femelle) sont comparés aux bornes
+type WeightSex string
+
+const (
+	// SexAny retient une race dont au moins un des deux poids correspond
+	SexAny WeightSex = "any"
+	// SexBoth retient une race dont les deux poids correspondent
+	SexBoth   WeightSex = "both"
+	SexMale   WeightSex = "male"
+	SexFemale WeightSex = "female"
+)
+
+// WeightMatch indique comment les poids sont comparés à l'intervalle demandé
+type WeightMatch string
+
+const (
+	// MatchContained compare chaque poids retenu à l'intervalle
+	MatchContained WeightMatch = "contained"
+	// MatchOverlap retient une race dont l'intervalle [plus petit, plus grand]
+	// des poids retenus chevauche l'intervalle demandé : avec SexAny, les poids
+	// connus ; avec SexBoth, les deux poids, qui doivent être connus. Avec
+	// SexMale ou SexFemale, un seul poids est retenu et les deux modes sont
+	// équivalents
+	MatchOverlap WeightMatch = "overlap"
+)
+
+// SortField est une colonne selon laquelle les races peuvent être triées
+type SortField string
+
+const (
+	SortByName         SortField = "name"
+	SortByID           SortField = "id"
+	SortByMaleWeight   SortField = "average_male_adult_weight"
+	SortByFemaleWeight SortField = "average_female_adult_weight"
+)
+
+// WeightRange est un intervalle de poids en grammes, bornes incluses et
+// optionnelles
+type WeightRange struct {
+	Min *int
+	Max *int
+}
+
+// IsSet indique si au moins une borne est renseignée
+func (wr WeightRange) IsSet() bool {
+	return wr.Min != nil || wr.Max != nil
+}
+
+// BreedFilter regroupe les critères de GetAll
+type BreedFilter struct {
+	Species string
+	PetSize string
+	// Name retient les races dont le nom ou l'un des alias contient ce texte,
+	// déjà normalisé comme un alias
+	Name string
+
+	// Weight est combiné selon Sex et Match
+	Weight WeightRange
+	Sex    WeightSex
+	Match  WeightMatch
+
+	// MaleWeight et FemaleWeight s'appliquent chacun à un seul poids et
+	// s'ajoutent (ET) au filtre Weight
+	MaleWeight   WeightRange
+	FemaleWeight WeightRange
+
+	// Sort vaut SortByName si vide ; Desc inverse l'ordre
+	Sort SortField
+	Desc bool
+
+	// Limit à 0 renvoie toutes les races
+	Limit  int
+	Offset int
+}
+
+// ParseWeightSex lit le paramètre sex ; une chaîne vide donne SexAny
+func ParseWeightSex(value string) (WeightSex, error) {
+	switch WeightSex(value) {
+	case "":
+		return SexAny, nil
+	case SexAny, SexBoth, SexMale, SexFemale:
+		return WeightSex(value), nil
+	}
+	return "", fmt.Errorf("valeur inconnue '%s' pour sex (valeurs autorisées: any, both, male, female)", value)
+}
+
+// ParseWeightMatch lit le paramètre weight_match ; une chaîne vide donne
+// MatchContained
+func ParseWeightMatch(value string) (WeightMatch, error) {
+	switch WeightMatch(value) {
+	case "":
+		return MatchContained, nil
+	case MatchContained, MatchOverlap:
+		return WeightMatch(value), nil
+	}
+	return "", fmt.Errorf("valeur inconnue '%s' pour weight_match (valeurs autorisées: contained, overlap)", value)
+}
+
+// weightConditions construit les conditions SQL des filtres de poids
+func (f BreedFilter) weightConditions() (string, []interface{}) {
+	query := ""
+	args := []interface{}{}
+
+	if f.Weight.IsSet() {
+		condition, conditionArgs := f.combinedWeightCondition()
+		query += " AND " + condition
+		args = append(args, conditionArgs...)
+	}
+
+	if f.MaleWeight.IsSet() {
+		condition, conditionArgs := rangeCondition("average_male_adult_weight", f.MaleWeight)
+		query += " AND " + condition
+		args = append(args, conditionArgs...)
+	}
+
+	if f.FemaleWeight.IsSet() {
+		condition, conditionArgs := rangeCondition("average_female_adult_weight", f.FemaleWeight)
+		query += " AND " + condition
+		args = append(args, conditionArgs...)
+	}
+
+	return query, args
+}
+
+func (f BreedFilter) combinedWeightCondition() (string, []interface{}) {
+	switch f.Sex {
+	case SexMale:
+		return rangeCondition("average_male_adult_weight", f.Weight)
+	case SexFemale:
+		return rangeCondition("average_female_adult_weight", f.Weight)
+	}
+
+	if f.Match == MatchOverlap {
+		return overlapCondition(f.Weight, f.Sex == SexBoth)
+	}
+
+	male, maleArgs := rangeCondition("average_male_adult_weight", f.Weight)
+	female, femaleArgs := rangeCondition("average_female_adult_weight", f.Weight)
+	operator := " OR "
+	if f.Sex == SexBoth {
+		operator = " AND "
+	}
+
+	return "(" + male + operator + female + ")", append(maleArgs, femaleArgs...)
+}
+
+// rangeCondition compare un poids à l'intervalle ; un poids à 0 est inconnu
+// et n'appartient à aucun intervalle, ce que la borne basse ne garantit que
+// si elle est positive
+func rangeCondition(column string, wr WeightRange) (string, []interface{}) {
+	var condition string
+	var args []interface{}
+	switch {
+	case wr.Min != nil && wr.Max != nil:
+		condition, args = column+" BETWEEN ? AND ?", []interface{}{*wr.Min, *wr.Max}
+	case wr.Min != nil:
+		condition, args = column+" >= ?", []interface{}{*wr.Min}
+	default:
+		condition, args = column+" <= ?", []interface{}{*wr.Max}
+	}
+
+	if !excludesUnknown(wr) {
+		condition = "(" + column + " > 0 AND " + condition + ")"
+	}
+	return condition, args
+}
+
+// overlapCondition teste le chevauchement de [plus petit, plus grand] des
+// poids avec l'intervalle demandé. Si both, les deux poids doivent être
+// connus ; sinon un poids inconnu (0) est remplacé par l'autre dans le plus
+// petit, et seule une race sans poids connu est écartée.
+func overlapCondition(wr WeightRange, both bool) (string, []interface{}) {
+	conditions := []string{}
+	args := []interface{}{}
+	smallest := "LEAST(COALESCE(NULLIF(average_male_adult_weight, 0), average_female_adult_weight), COALESCE(NULLIF(average_female_adult_weight, 0), average_male_adult_weight))"
+
+	if both {
+		conditions = append(conditions, "average_male_adult_weight > 0", "average_female_adult_weight > 0")
+		smallest = "LEAST(average_male_adult_weight, average_female_adult_weight)"
+	}
+	if wr.Min != nil {
+		conditions = append(conditions, "GREATEST(average_male_adult_weight, average_female_adult_weight) >= ?")
+		args = append(args, *wr.Min)
+	}
+	if !both && !excludesUnknown(wr) {
+		conditions = append(conditions, "GREATEST(average_male_adult_weight, average_female_adult_weight) > 0")
+	}
+	if wr.Max != nil {
+		conditions = append(conditions, smallest+" <= ?")
+		args = append(args, *wr.Max)
+	}
+
+	return "(" + strings.Join(conditions, " AND ") + ")", args
+}
+
+// excludesUnknown indique si la borne basse écarte déjà les poids inconnus
+func excludesUnknown(wr WeightRange) bool {
+	return wr.Min != nil && *wr.Min > 0
+}
+
+// nameCondition recherche le texte dans le nom canonique et dans les alias
+func (f BreedFilter) nameCondition() (string, []interface{}) {
+	if f.Name == "" {
+		return "", nil
+	}
+
+	pattern := "%" + likeEscaper.Replace(f.Name) + "%"
+	return " AND (name LIKE ? OR id IN (SELECT breed_id FROM breed_aliases WHERE alias LIKE ?))", []interface{}{pattern, pattern}
+}
+
+// likeEscaper neutralise les jokers de LIKE, « _ » séparant les mots des noms
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// orderClause construit le tri ; le nom, unique, départage les égalités pour
+// que la pagination reste stable
+func (f BreedFilter) orderClause() string {
+	column := SortByName
+	switch f.Sort {
+	case SortByID, SortByMaleWeight, SortByFemaleWeight:
+		column = f.Sort
+	}
+
+	clause := " ORDER BY " + string(column)
+	if f.Desc {
+		clause += " DESC"
+	}
+	if column != SortByName {
+		clause += ", name"
+	}
+	return clause
+}
@@ -1,7 +1,9 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 	_ "github.com/go-sql-driver/mysql"
@@ -17,60 +19,83 @@ type Breed struct {
 }
 
 type BreedRepositoryInterface interface {
-	GetAll(species string, weightMin, weightMax *int, petSize string, limit, offset int) ([]Breed, error)
-	GetByID(id int) (*Breed, error)
-	Create(breed *Breed) (*Breed, error)
-	Update(id int, breed *Breed) (*Breed, error)
-	Delete(id int) error
-	ImportFromCSV(breeds []Breed) error
+	GetAll(ctx context.Context, filter BreedFilter) ([]Breed, error)
+	GetByID(ctx context.Context, id int) (*Breed, error)
+	Resolve(ctx context.Context, name string) (*Breed, error)
+	Create(ctx context.Context, breed *Breed) (*Breed, error)
+	Update(ctx context.Context, id int, breed *Breed) (*Breed, error)
+	Delete(ctx context.Context, id int) error
+	ImportFromCSV(ctx context.Context, breeds []Breed) (ImportStats, error)
 }
 
 type BreedRepository struct {
-	db *sql.DB
+	db    *sql.DB
+	reads ReadRouter
 }
 
 func NewBreedRepository(db *sql.DB) *BreedRepository {
 	return &BreedRepository{db: db}
 }
 
-func (r *BreedRepository) GetAll(species string, weightMin, weightMax *int, petSize string, limit, offset int) ([]Breed, error) {
+// NewReplicatedBreedRepository crée un repository qui écrit sur db et lit
+// les listes et les races par ID sur la base choisie par reads
+func NewReplicatedBreedRepository(db *sql.DB, reads ReadRouter) *BreedRepository {
+	return &BreedRepository{db: db, reads: reads}
+}
+
+// reader retourne la base des lectures qui tolèrent un léger retard
+func (r *BreedRepository) reader(ctx context.Context) *sql.DB {
+	if r.reads == nil || PrimaryRequired(ctx) {
+		return r.db
+	}
+	return r.reads.Reader(ctx)
+}
+
+// failed signale l'échec d'une lecture sur une réplique
+func (r *BreedRepository) failed(db *sql.DB, err error) {
+	if r.reads != nil && db != r.db {
+		r.reads.Failed(db, err)
+	}
+}
+
+func (r *BreedRepository) GetAll(ctx context.Context, filter BreedFilter) ([]Breed, error) {
 	query := "SELECT id, species, pet_size, name, average_male_adult_weight, average_female_adult_weight FROM breeds WHERE 1=1"
 	args := []interface{}{}
 
-	if species != "" {
+	if filter.Species != "" {
 		query += " AND species = ?"
-		args = append(args, species)
+		args = append(args, filter.Species)
 	}
 
-	if petSize != "" {
+	if filter.PetSize != "" {
 		query += " AND pet_size = ?"
-		args = append(args, petSize)
+		args = append(args, filter.PetSize)
 	}
 
-	if weightMin != nil {
-		query += " AND (average_male_adult_weight >= ? OR average_female_adult_weight >= ?)"
-		args = append(args, *weightMin, *weightMin)
-	}
+	nameQuery, nameArgs := filter.nameCondition()
+	query += nameQuery
+	args = append(args, nameArgs...)
 
-	if weightMax != nil {
-		query += " AND (average_male_adult_weight <= ? OR average_female_adult_weight <= ?)"
-		args = append(args, *weightMax, *weightMax)
-	}
+	weightQuery, weightArgs := filter.weightConditions()
+	query += weightQuery
+	args = append(args, weightArgs...)
 
-	query += " ORDER BY name"
+	query += filter.orderClause()
 
-	if limit > 0 {
+	if filter.Limit > 0 {
 		query += " LIMIT ?"
-		args = append(args, limit)
+		args = append(args, filter.Limit)
 
-		if offset > 0 {
+		if filter.Offset > 0 {
 			query += " OFFSET ?"
-			args = append(args, offset)
+			args = append(args, filter.Offset)
 		}
 	}
 
-	rows, err := r.db.Query(query, args...)
+	db := r.reader(ctx)
+	rows, err := db.QueryContext(ctx, query, args...)
 	if err != nil {
+		r.failed(db, err)
 		return nil, fmt.Errorf("erreur lors de la récupération des races: %w", err)
 	}
 	defer rows.Close()
@@ -95,11 +120,15 @@ func (r *BreedRepository) GetAll(species string, weightMin, weightMax *int, petS
 	return breeds, nil
 }
 
-func (r *BreedRepository) GetByID(id int) (*Breed, error) {
+func (r *BreedRepository) GetByID(ctx context.Context, id int) (*Breed, error) {
+	return r.getByID(ctx, r.reader(ctx), id)
+}
+
+func (r *BreedRepository) getByID(ctx context.Context, db *sql.DB, id int) (*Breed, error) {
 	query := "SELECT id, species, pet_size, name, average_male_adult_weight, average_female_adult_weight FROM breeds WHERE id = ?"
 
 	var breed Breed
-	err := r.db.QueryRow(query, id).Scan(
+	err := db.QueryRowContext(ctx, query, id).Scan(
 		&breed.ID,
 		&breed.Species,
 		&breed.PetSize,
@@ -108,23 +137,51 @@ func (r *BreedRepository) GetByID(id int) (*Breed, error) {
 		&breed.AverageFemaleAdultWeight,
 	)
 
-	if err == sql.ErrNoRows {
-		return nil, nil
+	if err != nil {
+		if !errors.Is(err, sql.ErrNoRows) {
+			r.failed(db, err)
+		}
+		return nil, fmt.Errorf("erreur lors de la récupération de la race: %w", classify(err, "breed"))
 	}
+
+	return &breed, nil
+}
+
+// Resolve retrouve une race par son nom canonique ou l'un de ses alias, déjà
+// normalisé ; le nom canonique est prioritaire
+func (r *BreedRepository) Resolve(ctx context.Context, name string) (*Breed, error) {
+	query := `SELECT id, species, pet_size, name, average_male_adult_weight, average_female_adult_weight, 0 AS by_alias FROM breeds WHERE name = ?
+			  UNION ALL
+			  SELECT b.id, b.species, b.pet_size, b.name, b.average_male_adult_weight, b.average_female_adult_weight, 1 AS by_alias
+			  FROM breeds b JOIN breed_aliases a ON a.breed_id = b.id WHERE a.alias = ?
+			  ORDER BY by_alias LIMIT 1`
+
+	var breed Breed
+	var byAlias int
+	err := r.db.QueryRowContext(ctx, query, name, name).Scan(
+		&breed.ID,
+		&breed.Species,
+		&breed.PetSize,
+		&breed.Name,
+		&breed.AverageMaleAdultWeight,
+		&breed.AverageFemaleAdultWeight,
+		&byAlias,
+	)
+
 	if err != nil {
-		return nil, fmt.Errorf("erreur lors de la récupération de la race: %w", err)
+		return nil, fmt.Errorf("erreur lors de la résolution de la race %s: %w", name, classify(err, "breed"))
 	}
 
 	return &breed, nil
 }
 
-func (r *BreedRepository) Create(breed *Breed) (*Breed, error) {
+func (r *BreedRepository) Create(ctx context.Context, breed *Breed) (*Breed, error) {
 	query := `INSERT INTO breeds (species, pet_size, name, average_male_adult_weight, average_female_adult_weight) 
 			  VALUES (?, ?, ?, ?, ?)`
 
-	result, err := r.db.Exec(query, breed.Species, breed.PetSize, breed.Name, breed.AverageMaleAdultWeight, breed.AverageFemaleAdultWeight)
+	result, err := r.db.ExecContext(ctx, query, breed.Species, breed.PetSize, breed.Name, breed.AverageMaleAdultWeight, breed.AverageFemaleAdultWeight)
 	if err != nil {
-		return nil, fmt.Errorf("erreur lors de la création de la race: %w", err)
+		return nil, fmt.Errorf("erreur lors de la création de la race: %w", classify(err, "breed"))
 	}
 
 	id, err := result.LastInsertId()
@@ -136,7 +193,7 @@ func (r *BreedRepository) Create(breed *Breed) (*Breed, error) {
 	return breed, nil
 }
 
-func (r *BreedRepository) Update(id int, breed *Breed) (*Breed, error) {
+func (r *BreedRepository) Update(ctx context.Context, id int, breed *Breed) (*Breed, error) {
 	setParts := []string{}
 	args := []interface{}{}
 
@@ -162,26 +219,28 @@ func (r *BreedRepository) Update(id int, breed *Breed) (*Breed, error) {
 	}
 
 	if len(setParts) == 0 {
-		return nil, fmt.Errorf("aucun champ à mettre à jour")
+		return nil, newError(ErrInvalid, "breed", ErrNothingToUpdate)
 	}
 
 	query := "UPDATE breeds SET " + strings.Join(setParts, ", ") + " WHERE id = ?"
 	args = append(args, id)
 
-	_, err := r.db.Exec(query, args...)
+	_, err := r.db.ExecContext(ctx, query, args...)
 	if err != nil {
-		return nil, fmt.Errorf("erreur lors de la mise à jour de la race: %w", err)
+		return nil, fmt.Errorf("erreur lors de la mise à jour de la race: %w", classify(err, "breed"))
 	}
 
-	return r.GetByID(id)
+	// La race mise à jour est relue sur la base principale, les réplicas
+	// pouvant ne pas avoir encore reçu l'écriture
+	return r.getByID(ctx, r.db, id)
 }
 
-func (r *BreedRepository) Delete(id int) error {
+func (r *BreedRepository) Delete(ctx context.Context, id int) error {
 	query := "DELETE FROM breeds WHERE id = ?"
 
-	result, err := r.db.Exec(query, id)
+	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
-		return fmt.Errorf("erreur lors de la suppression de la race: %w", err)
+		return fmt.Errorf("erreur lors de la suppression de la race: %w", classify(err, "breed"))
 	}
 
 	rowsAffected, err := result.RowsAffected()
@@ -190,31 +249,35 @@ func (r *BreedRepository) Delete(id int) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("race non trouvée")
+		return newError(ErrNotFound, "breed", nil)
 	}
 
 	return nil
 }
 
-func (r *BreedRepository) ImportFromCSV(breeds []Breed) error {
-	tx, err := r.db.Begin()
+// ImportFromCSV enregistre toutes les races en une transaction, en mettant à
+// jour celles qui existent déjà
+func (r *BreedRepository) ImportFromCSV(ctx context.Context, breeds []Breed) (ImportStats, error) {
+	var stats ImportStats
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
-		return fmt.Errorf("erreur lors du début de la transaction: %w", err)
+		return ImportStats{}, fmt.Errorf("erreur lors du début de la transaction: %w", err)
 	}
 	defer tx.Rollback()
 
-	stmt, err := tx.Prepare("INSERT INTO breeds (species, pet_size, name, average_male_adult_weight, average_female_adult_weight) VALUES (?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE species=VALUES(species), pet_size=VALUES(pet_size), average_male_adult_weight=VALUES(average_male_adult_weight), average_female_adult_weight=VALUES(average_female_adult_weight)")
+	stmt, err := tx.PrepareContext(ctx, "INSERT INTO breeds (species, pet_size, name, average_male_adult_weight, average_female_adult_weight) VALUES (?, ?, ?, ?, ?) ON DUPLICATE KEY UPDATE species=VALUES(species), pet_size=VALUES(pet_size), average_male_adult_weight=VALUES(average_male_adult_weight), average_female_adult_weight=VALUES(average_female_adult_weight)")
 	if err != nil {
-		return fmt.Errorf("erreur lors de la préparation de la requête: %w", err)
+		return ImportStats{}, fmt.Errorf("erreur lors de la préparation de la requête: %w", err)
 	}
 	defer stmt.Close()
 
 	for _, breed := range breeds {
-		_, err := stmt.Exec(breed.Species, breed.PetSize, breed.Name, breed.AverageMaleAdultWeight, breed.AverageFemaleAdultWeight)
+		result, err := stmt.ExecContext(ctx, breed.Species, breed.PetSize, breed.Name, breed.AverageMaleAdultWeight, breed.AverageFemaleAdultWeight)
 		if err != nil {
-			return fmt.Errorf("erreur lors de l'insertion de la race %s: %w", breed.Name, err)
+			return ImportStats{}, fmt.Errorf("erreur lors de l'insertion de la race %s: %w", breed.Name, classify(err, "breed"))
 		}
+		stats.count(result)
 	}
 
-	return tx.Commit()
+	return stats, tx.Commit()
 }
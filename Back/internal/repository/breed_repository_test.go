@@ -1,9 +1,15 @@
 package repository
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
 )
 
 func TestGetAll(t *testing.T) {
@@ -20,12 +26,12 @@ func TestGetAll_WithMock(t *testing.T) {
 	repo := NewBreedRepository(db)
 
 	rows := sqlmock.NewRows([]string{"id", "species", "pet_size", "name", "average_male_adult_weight", "average_female_adult_weight"}).
-		AddRow(1, "dog", "medium", "Border Collie", 20, 18)
+		AddRow(1, "dog", "medium", "Border Collie", 20000, 18000)
 
 	mock.ExpectQuery("SELECT id, species, pet_size, name, average_male_adult_weight, average_female_adult_weight FROM breeds").
 		WillReturnRows(rows)
 
-	breeds, err := repo.GetAll("", nil, nil, "", 10, 0)
+	breeds, err := repo.GetAll(context.Background(), BreedFilter{Limit: 10})
 	if err != nil {
 		t.Fatalf("Erreur lors de GetAll avec mock: %v", err)
 	}
@@ -39,4 +45,311 @@ func TestGetAll_WithMock(t *testing.T) {
 	if err := mock.ExpectationsWereMet(); err != nil {
 		t.Errorf("Toutes les attentes du mock n'ont pas été satisfaites: %v", err)
 	}
-} 
\ No newline at end of file
+}
+
+func TestGetAll_WeightFilterModes(t *testing.T) {
+	min, max := 10000, 20000
+	columns := []string{"id", "species", "pet_size", "name", "average_male_adult_weight", "average_female_adult_weight"}
+
+	cases := []struct {
+		name   string
+		filter BreedFilter
+		where  string
+		args   []driver.Value
+	}{
+		{
+			name:   "any contained",
+			filter: BreedFilter{Weight: WeightRange{Min: &min, Max: &max}, Sex: SexAny, Match: MatchContained},
+			where:  "(average_male_adult_weight BETWEEN ? AND ? OR average_female_adult_weight BETWEEN ? AND ?)",
+			args:   []driver.Value{min, max, min, max},
+		},
+		{
+			name:   "both contained",
+			filter: BreedFilter{Weight: WeightRange{Min: &min, Max: &max}, Sex: SexBoth, Match: MatchContained},
+			where:  "(average_male_adult_weight BETWEEN ? AND ? AND average_female_adult_weight BETWEEN ? AND ?)",
+			args:   []driver.Value{min, max, min, max},
+		},
+		{
+			name:   "overlap",
+			filter: BreedFilter{Weight: WeightRange{Min: &min, Max: &max}, Sex: SexAny, Match: MatchOverlap},
+			where:  "(GREATEST(average_male_adult_weight, average_female_adult_weight) >= ? AND LEAST(COALESCE(NULLIF(average_male_adult_weight, 0), average_female_adult_weight), COALESCE(NULLIF(average_female_adult_weight, 0), average_male_adult_weight)) <= ?)",
+			args:   []driver.Value{min, max},
+		},
+		// Avec both, les deux poids doivent être connus et forment l'intervalle
+		{
+			name:   "overlap both",
+			filter: BreedFilter{Weight: WeightRange{Min: &min, Max: &max}, Sex: SexBoth, Match: MatchOverlap},
+			where:  "(average_male_adult_weight > 0 AND average_female_adult_weight > 0 AND GREATEST(average_male_adult_weight, average_female_adult_weight) >= ? AND LEAST(average_male_adult_weight, average_female_adult_weight) <= ?)",
+			args:   []driver.Value{min, max},
+		},
+		{
+			name:   "per sex",
+			filter: BreedFilter{MaleWeight: WeightRange{Min: &min}, FemaleWeight: WeightRange{Max: &max}},
+			where:  "average_male_adult_weight >= ? AND (average_female_adult_weight > 0 AND average_female_adult_weight <= ?)",
+			args:   []driver.Value{min, max},
+		},
+		// Un poids à 0 est inconnu : sans borne basse positive, il ne doit
+		// pas satisfaire la borne haute
+		{
+			name:   "any max only",
+			filter: BreedFilter{Weight: WeightRange{Max: &max}, Sex: SexAny, Match: MatchContained},
+			where:  "((average_male_adult_weight > 0 AND average_male_adult_weight <= ?) OR (average_female_adult_weight > 0 AND average_female_adult_weight <= ?))",
+			args:   []driver.Value{max, max},
+		},
+		{
+			name:   "overlap max only",
+			filter: BreedFilter{Weight: WeightRange{Max: &max}, Sex: SexAny, Match: MatchOverlap},
+			where:  "(GREATEST(average_male_adult_weight, average_female_adult_weight) > 0 AND LEAST(COALESCE(NULLIF(average_male_adult_weight, 0), average_female_adult_weight), COALESCE(NULLIF(average_female_adult_weight, 0), average_male_adult_weight)) <= ?)",
+			args:   []driver.Value{max},
+		},
+		// Un seul poids est retenu : le chevauchement revient à l'inclusion
+		{
+			name:   "male overlap",
+			filter: BreedFilter{Weight: WeightRange{Min: &min, Max: &max}, Sex: SexMale, Match: MatchOverlap},
+			where:  "average_male_adult_weight BETWEEN ? AND ?",
+			args:   []driver.Value{min, max},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+			if err != nil {
+				t.Fatalf("Erreur lors de la création du mock: %v", err)
+			}
+			defer db.Close()
+
+			mock.ExpectQuery("SELECT id, species, pet_size, name, average_male_adult_weight, average_female_adult_weight FROM breeds WHERE 1=1 AND " + c.where + " ORDER BY name").
+				WithArgs(c.args...).
+				WillReturnRows(sqlmock.NewRows(columns))
+
+			if _, err := NewBreedRepository(db).GetAll(context.Background(), c.filter); err != nil {
+				t.Fatalf("Erreur lors de GetAll avec mock: %v", err)
+			}
+			if err := mock.ExpectationsWereMet(); err != nil {
+				t.Errorf("Toutes les attentes du mock n'ont pas été satisfaites: %v", err)
+			}
+		})
+	}
+}
+
+func TestUpdate_NothingToUpdate(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Erreur lors de la création du mock: %v", err)
+	}
+	defer db.Close()
+
+	_, err = NewBreedRepository(db).Update(context.Background(), 1, &Breed{})
+	if !errors.Is(err, ErrInvalid) || !errors.Is(err, ErrNothingToUpdate) {
+		t.Errorf("attendu ErrInvalid causée par ErrNothingToUpdate, obtenu %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("aucune requête attendue: %v", err)
+	}
+}
+
+func TestCreate_DuplicateName(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Erreur lors de la création du mock: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("INSERT INTO breeds").
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry 'affenpinscher' for key 'name'"})
+
+	_, err = NewBreedRepository(db).Create(context.Background(), &Breed{Species: "dog", PetSize: "small", Name: "affenpinscher", AverageMaleAdultWeight: 6000, AverageFemaleAdultWeight: 5000})
+	if !errors.Is(err, ErrDuplicateName) {
+		t.Errorf("ErrDuplicateName attendu, obtenu %v", err)
+	}
+	var repoErr *Error
+	if !errors.As(err, &repoErr) || repoErr.Entity != "breed" {
+		t.Errorf("*Error attendu pour l'entité breed, obtenu %v", err)
+	}
+}
+
+func TestGetByID_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Erreur lors de la création du mock: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT id, species, pet_size, name, average_male_adult_weight, average_female_adult_weight FROM breeds WHERE id = ?").
+		WithArgs(42).
+		WillReturnError(sql.ErrNoRows)
+
+	breed, err := NewBreedRepository(db).GetByID(context.Background(), 42)
+	if breed != nil || !errors.Is(err, ErrNotFound) {
+		t.Errorf("ErrNotFound attendu, obtenu %v, %v", breed, err)
+	}
+}
+
+func TestDelete_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Erreur lors de la création du mock: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectExec("DELETE FROM breeds").WithArgs(42).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	if err := NewBreedRepository(db).Delete(context.Background(), 42); !errors.Is(err, ErrNotFound) {
+		t.Errorf("ErrNotFound attendu, obtenu %v", err)
+	}
+}
+
+func TestGetAll_NameSearchesAliases(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("Erreur lors de la création du mock: %v", err)
+	}
+	defer db.Close()
+
+	columns := []string{"id", "species", "pet_size", "name", "average_male_adult_weight", "average_female_adult_weight"}
+	mock.ExpectQuery("SELECT id, species, pet_size, name, average_male_adult_weight, average_female_adult_weight FROM breeds WHERE 1=1 AND (name LIKE ? OR id IN (SELECT breed_id FROM breed_aliases WHERE alias LIKE ?)) ORDER BY name").
+		WithArgs(`%yorkshire\_terrier%`, `%yorkshire\_terrier%`).
+		WillReturnRows(sqlmock.NewRows(columns))
+
+	if _, err := NewBreedRepository(db).GetAll(context.Background(), BreedFilter{Name: "yorkshire_terrier"}); err != nil {
+		t.Fatalf("Erreur lors de GetAll avec mock: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Toutes les attentes du mock n'ont pas été satisfaites: %v", err)
+	}
+}
+
+func TestGetAll_Sort(t *testing.T) {
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherEqual))
+	if err != nil {
+		t.Fatalf("Erreur lors de la création du mock: %v", err)
+	}
+	defer db.Close()
+
+	columns := []string{"id", "species", "pet_size", "name", "average_male_adult_weight", "average_female_adult_weight"}
+	mock.ExpectQuery("SELECT id, species, pet_size, name, average_male_adult_weight, average_female_adult_weight FROM breeds WHERE 1=1 ORDER BY average_male_adult_weight DESC, name LIMIT ?").
+		WithArgs(10).
+		WillReturnRows(sqlmock.NewRows(columns))
+	// Une colonne inconnue retombe sur le tri par nom
+	mock.ExpectQuery("SELECT id, species, pet_size, name, average_male_adult_weight, average_female_adult_weight FROM breeds WHERE 1=1 ORDER BY name").
+		WillReturnRows(sqlmock.NewRows(columns))
+
+	repo := NewBreedRepository(db)
+	if _, err := repo.GetAll(context.Background(), BreedFilter{Sort: SortByMaleWeight, Desc: true, Limit: 10}); err != nil {
+		t.Fatalf("Erreur lors de GetAll avec mock: %v", err)
+	}
+	if _, err := repo.GetAll(context.Background(), BreedFilter{Sort: "name; DROP TABLE breeds"}); err != nil {
+		t.Fatalf("Erreur lors de GetAll avec mock: %v", err)
+	}
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Toutes les attentes du mock n'ont pas été satisfaites: %v", err)
+	}
+}
+
+func TestResolve_NotFound(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Erreur lors de la création du mock: %v", err)
+	}
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM breeds WHERE name = \\?").
+		WithArgs("labradoodle", "labradoodle").
+		WillReturnError(sql.ErrNoRows)
+
+	breed, err := NewBreedRepository(db).Resolve(context.Background(), "labradoodle")
+	if breed != nil || !errors.Is(err, ErrNotFound) {
+		t.Errorf("ErrNotFound attendu, obtenu %v, %v", breed, err)
+	}
+}
+
+func TestRateLimitUpdate_LocksBucket(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Erreur lors de la création du mock: %v", err)
+	}
+	defer db.Close()
+
+	repo := NewRateLimitRepository(db)
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT IGNORE INTO rate_limit_buckets").
+		WithArgs("read:api_key:1", 10.0, now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("SELECT tokens, updated_at FROM rate_limit_buckets WHERE bucket_key = \\? FOR UPDATE").
+		WithArgs("read:api_key:1").
+		WillReturnRows(sqlmock.NewRows([]string{"tokens", "updated_at"}).AddRow(4.0, now.Add(-time.Second)))
+	mock.ExpectExec("UPDATE rate_limit_buckets SET tokens = \\?, updated_at = \\? WHERE bucket_key = \\?").
+		WithArgs(3.0, now, "read:api_key:1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	err = repo.Update("read:api_key:1", RateLimitBucket{Tokens: 10, UpdatedAt: now}, func(bucket RateLimitBucket) RateLimitBucket {
+		if bucket.Tokens != 4 {
+			t.Errorf("Attendu l'état stocké (4 jetons), obtenu %v", bucket.Tokens)
+		}
+		return RateLimitBucket{Tokens: bucket.Tokens - 1, UpdatedAt: now}
+	})
+	if err != nil {
+		t.Fatalf("Erreur inattendue: %v", err)
+	}
+
+	if err := mock.ExpectationsWereMet(); err != nil {
+		t.Errorf("Toutes les attentes du mock n'ont pas été satisfaites: %v", err)
+	}
+}
+
+type fixedReadRouter struct {
+	db     *sql.DB
+	failed error
+}
+
+func (f *fixedReadRouter) Reader(ctx context.Context) *sql.DB { return f.db }
+
+func (f *fixedReadRouter) Failed(db *sql.DB, err error) { f.failed = err }
+
+func TestReplicatedBreedRepository_ReadsOnReplica(t *testing.T) {
+	primary, primaryMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Erreur lors de la création du mock: %v", err)
+	}
+	defer primary.Close()
+	replica, replicaMock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("Erreur lors de la création du mock: %v", err)
+	}
+	defer replica.Close()
+
+	reads := &fixedReadRouter{db: replica}
+	repo := NewReplicatedBreedRepository(primary, reads)
+	columns := []string{"id", "species", "pet_size", "name", "average_male_adult_weight", "average_female_adult_weight"}
+
+	replicaMock.ExpectQuery("FROM breeds WHERE id = ?").WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "dog", "medium", "Border Collie", 20000, 18000))
+	if _, err := repo.GetByID(context.Background(), 1); err != nil {
+		t.Fatalf("Erreur lors de GetByID: %v", err)
+	}
+
+	// Une lecture qui doit voir les écritures récentes reste sur la base principale
+	primaryMock.ExpectQuery("FROM breeds WHERE id = ?").WithArgs(1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, "dog", "medium", "Border Collie", 20000, 18000))
+	if _, err := repo.GetByID(ReadPrimary(context.Background()), 1); err != nil {
+		t.Fatalf("Erreur lors de GetByID: %v", err)
+	}
+
+	replicaMock.ExpectQuery("FROM breeds WHERE 1=1").WillReturnError(mysql.ErrInvalidConn)
+	if _, err := repo.GetAll(context.Background(), BreedFilter{}); !errors.Is(err, mysql.ErrInvalidConn) {
+		t.Fatalf("Attendu mysql.ErrInvalidConn, obtenu %v", err)
+	}
+	if !errors.Is(reads.failed, mysql.ErrInvalidConn) {
+		t.Errorf("L'échec de la réplique n'a pas été signalé : %v", reads.failed)
+	}
+
+	for _, mock := range []sqlmock.Sqlmock{primaryMock, replicaMock} {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Errorf("Attentes non satisfaites: %v", err)
+		}
+	}
+}
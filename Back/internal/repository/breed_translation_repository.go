@@ -0,0 +1,138 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// BreedTranslation est le nom d'affichage d'une race dans une langue
+type BreedTranslation struct {
+	BreedID     int    `json:"breed_id" db:"breed_id"`
+	Locale      string `json:"locale" db:"locale"`
+	DisplayName string `json:"display_name" db:"display_name"`
+}
+
+type BreedTranslationRepositoryInterface interface {
+	ListForBreed(breedID int) ([]BreedTranslation, error)
+	DisplayNames(locale string, breedIDs []int) (map[int]string, error)
+	Upsert(translation *BreedTranslation) error
+	Delete(breedID int, locale string) error
+	Import(translations []BreedTranslation) (ImportStats, error)
+}
+
+type BreedTranslationRepository struct {
+	db *sql.DB
+}
+
+func NewBreedTranslationRepository(db *sql.DB) *BreedTranslationRepository {
+	return &BreedTranslationRepository{db: db}
+}
+
+// ListForBreed retourne toutes les traductions d'une race, triées par langue
+func (r *BreedTranslationRepository) ListForBreed(breedID int) ([]BreedTranslation, error) {
+	rows, err := r.db.Query("SELECT breed_id, locale, display_name FROM breed_translations WHERE breed_id = ? ORDER BY locale", breedID)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des traductions: %w", err)
+	}
+	defer rows.Close()
+
+	translations := []BreedTranslation{}
+	for rows.Next() {
+		var translation BreedTranslation
+		if err := rows.Scan(&translation.BreedID, &translation.Locale, &translation.DisplayName); err != nil {
+			return nil, fmt.Errorf("erreur lors du scan de la traduction: %w", err)
+		}
+		translations = append(translations, translation)
+	}
+
+	return translations, rows.Err()
+}
+
+// DisplayNames retourne, par ID de race, les noms traduits dans une langue ;
+// les races sans traduction sont absentes de la map
+func (r *BreedTranslationRepository) DisplayNames(locale string, breedIDs []int) (map[int]string, error) {
+	names := map[int]string{}
+	if len(breedIDs) == 0 {
+		return names, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(breedIDs)), ", ")
+	args := []interface{}{locale}
+	for _, id := range breedIDs {
+		args = append(args, id)
+	}
+
+	rows, err := r.db.Query("SELECT breed_id, display_name FROM breed_translations WHERE locale = ? AND breed_id IN ("+placeholders+")", args...)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des noms traduits: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id int
+		var name string
+		if err := rows.Scan(&id, &name); err != nil {
+			return nil, fmt.Errorf("erreur lors du scan du nom traduit: %w", err)
+		}
+		names[id] = name
+	}
+
+	return names, rows.Err()
+}
+
+// Upsert crée ou remplace la traduction d'une race dans une langue
+func (r *BreedTranslationRepository) Upsert(translation *BreedTranslation) error {
+	_, err := r.db.Exec(upsertTranslationQuery, translation.BreedID, translation.Locale, translation.DisplayName)
+	if err != nil {
+		return fmt.Errorf("erreur lors de l'enregistrement de la traduction: %w", classify(err, "breed_translation"))
+	}
+
+	return nil
+}
+
+func (r *BreedTranslationRepository) Delete(breedID int, locale string) error {
+	result, err := r.db.Exec("DELETE FROM breed_translations WHERE breed_id = ? AND locale = ?", breedID, locale)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la suppression de la traduction: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erreur lors de la vérification de la suppression: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return newError(ErrNotFound, "breed_translation", nil)
+	}
+
+	return nil
+}
+
+// Import enregistre toutes les traductions en une transaction
+func (r *BreedTranslationRepository) Import(translations []BreedTranslation) (ImportStats, error) {
+	var stats ImportStats
+	tx, err := r.db.Begin()
+	if err != nil {
+		return ImportStats{}, fmt.Errorf("erreur lors du début de la transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(upsertTranslationQuery)
+	if err != nil {
+		return ImportStats{}, fmt.Errorf("erreur lors de la préparation de la requête: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, translation := range translations {
+		result, err := stmt.Exec(translation.BreedID, translation.Locale, translation.DisplayName)
+		if err != nil {
+			return ImportStats{}, fmt.Errorf("erreur lors de l'insertion de la traduction %d/%s: %w", translation.BreedID, translation.Locale, classify(err, "breed_translation"))
+		}
+		stats.count(result)
+	}
+
+	return stats, tx.Commit()
+}
+
+const upsertTranslationQuery = "INSERT INTO breed_translations (breed_id, locale, display_name) VALUES (?, ?, ?) ON DUPLICATE KEY UPDATE display_name=VALUES(display_name)"
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// Erreurs sentinelles des repositories, à tester avec errors.Is
+var (
+	ErrNotFound      = errors.New("ressource non trouvée")
+	ErrDuplicateName = errors.New("nom déjà utilisé")
+	ErrConflict      = errors.New("conflit avec les données existantes")
+	ErrInvalid       = errors.New("données invalides")
+)
+
+// ErrNothingToUpdate est la cause d'un ErrInvalid renvoyé par une mise à jour
+// sans aucun champ
+var ErrNothingToUpdate = errors.New("aucun champ à mettre à jour")
+
+// Codes d'erreur MySQL traduits en erreurs sentinelles
+const (
+	mysqlDuplicateEntry     = 1062
+	mysqlRowIsReferenced    = 1451
+	mysqlNoReferencedRow    = 1452
+	mysqlBadNull            = 1048
+	mysqlDataTooLong        = 1406
+	mysqlOutOfRange         = 1264
+	mysqlTruncatedWrongData = 1366
+)
+
+// Error est l'erreur typée renvoyée par les repositories : Kind est l'une des
+// erreurs sentinelles, Err la cause d'origine (souvent une *mysql.MySQLError)
+type Error struct {
+	Kind   error
+	Entity string
+	Err    error
+}
+
+func (e *Error) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("%s: %v", e.Entity, e.Kind)
+	}
+	return fmt.Sprintf("%s: %v: %v", e.Entity, e.Kind, e.Err)
+}
+
+// Unwrap permet errors.Is sur la sentinelle comme sur la cause
+func (e *Error) Unwrap() []error {
+	if e.Err == nil {
+		return []error{e.Kind}
+	}
+	return []error{e.Kind, e.Err}
+}
+
+func newError(kind error, entity string, cause error) error {
+	return &Error{Kind: kind, Entity: entity, Err: cause}
+}
+
+// classify traduit une erreur du driver en *Error quand elle correspond à une
+// situation connue, et la renvoie telle quelle sinon
+func classify(err error, entity string) error {
+	if errors.Is(err, sql.ErrNoRows) {
+		return newError(ErrNotFound, entity, nil)
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return err
+	}
+
+	switch mysqlErr.Number {
+	case mysqlDuplicateEntry:
+		return newError(ErrDuplicateName, entity, err)
+	case mysqlRowIsReferenced:
+		return newError(ErrConflict, entity, err)
+	case mysqlNoReferencedRow, mysqlBadNull, mysqlDataTooLong, mysqlOutOfRange, mysqlTruncatedWrongData:
+		return newError(ErrInvalid, entity, err)
+	}
+	return err
+}
@@ -0,0 +1,27 @@
+package repository
+
+import "database/sql"
+
+// ImportStats compte les lignes écrites par un import
+type ImportStats struct {
+	Inserted  int
+	Updated   int
+	Unchanged int
+}
+
+// count classe une ligne d'après le nombre de lignes affectées par un
+// INSERT ... ON DUPLICATE KEY UPDATE : 1 pour une insertion, 2 pour une mise
+// à jour, 0 si la ligne existait déjà à l'identique
+func (s *ImportStats) count(result sql.Result) {
+	affected, err := result.RowsAffected()
+	switch {
+	case err != nil:
+		return
+	case affected == 1:
+		s.Inserted++
+	case affected == 0:
+		s.Unchanged++
+	default:
+		s.Updated++
+	}
+}
@@ -0,0 +1,66 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+)
+
+// RateLimitBucket est l'état d'un seau de jetons partagé entre les instances
+type RateLimitBucket struct {
+	Tokens    float64
+	UpdatedAt time.Time
+}
+
+type RateLimitRepositoryInterface interface {
+	Update(key string, initial RateLimitBucket, update func(RateLimitBucket) RateLimitBucket) error
+	DeleteIdle(before time.Time) (int64, error)
+}
+
+type RateLimitRepository struct {
+	db *sql.DB
+}
+
+func NewRateLimitRepository(db *sql.DB) *RateLimitRepository {
+	return &RateLimitRepository{db: db}
+}
+
+// Update lit et remplace l'état d'un seau dans une transaction qui le
+// verrouille, pour que deux instances ne consomment pas le même jeton ; un
+// seau inconnu est d'abord créé dans l'état initial
+func (r *RateLimitRepository) Update(key string, initial RateLimitBucket, update func(RateLimitBucket) RateLimitBucket) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("erreur lors du début de la transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec("INSERT IGNORE INTO rate_limit_buckets (bucket_key, tokens, updated_at) VALUES (?, ?, ?)", key, initial.Tokens, initial.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la création du seau %s: %w", key, err)
+	}
+
+	var bucket RateLimitBucket
+	err = tx.QueryRow("SELECT tokens, updated_at FROM rate_limit_buckets WHERE bucket_key = ? FOR UPDATE", key).Scan(&bucket.Tokens, &bucket.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la lecture du seau %s: %w", key, err)
+	}
+
+	bucket = update(bucket)
+	_, err = tx.Exec("UPDATE rate_limit_buckets SET tokens = ?, updated_at = ? WHERE bucket_key = ?", bucket.Tokens, bucket.UpdatedAt, key)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la mise à jour du seau %s: %w", key, err)
+	}
+
+	return tx.Commit()
+}
+
+// DeleteIdle supprime les seaux inutilisés depuis la date donnée
+func (r *RateLimitRepository) DeleteIdle(before time.Time) (int64, error) {
+	result, err := r.db.Exec("DELETE FROM rate_limit_buckets WHERE updated_at < ?", before)
+	if err != nil {
+		return 0, fmt.Errorf("erreur lors de la suppression des seaux inutilisés: %w", err)
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+// ReadRouter choisit la base des lectures qui tolèrent un léger retard :
+// une réplique en bonne santé, ou la base principale à défaut
+type ReadRouter interface {
+	Reader(ctx context.Context) *sql.DB
+	// Failed signale l'échec d'une requête sur une base renvoyée par Reader
+	Failed(db *sql.DB, err error)
+}
+
+type primaryKey struct{}
+
+// ReadPrimary fait lire sur la base principale les requêtes faites avec ce
+// contexte, pour relire ses propres écritures (lecture avant mise à jour)
+func ReadPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryKey{}, true)
+}
+
+// PrimaryRequired indique si le contexte exige la base principale
+func PrimaryRequired(ctx context.Context) bool {
+	required, _ := ctx.Value(primaryKey{}).(bool)
+	return required
+}
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// ReferenceValue représente une valeur de référence (espèce ou taille)
+type ReferenceValue struct {
+	Code  string `json:"code" db:"code"`
+	Label string `json:"label" db:"label"`
+}
+
+type ReferenceRepositoryInterface interface {
+	List() ([]ReferenceValue, error)
+	GetByCode(code string) (*ReferenceValue, error)
+	Create(value *ReferenceValue) (*ReferenceValue, error)
+	Update(code string, value *ReferenceValue) (*ReferenceValue, error)
+	Delete(code string) error
+}
+
+// ReferenceRepository gère une table de référence de la forme (code, label)
+type ReferenceRepository struct {
+	db    *sql.DB
+	table string
+}
+
+// NewSpeciesRepository crée le repository de la table species
+func NewSpeciesRepository(db *sql.DB) *ReferenceRepository {
+	return &ReferenceRepository{db: db, table: "species"}
+}
+
+// NewPetSizeRepository crée le repository de la table pet_sizes
+func NewPetSizeRepository(db *sql.DB) *ReferenceRepository {
+	return &ReferenceRepository{db: db, table: "pet_sizes"}
+}
+
+func (r *ReferenceRepository) List() ([]ReferenceValue, error) {
+	rows, err := r.db.Query("SELECT code, label FROM " + r.table + " ORDER BY code")
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération de %s: %w", r.table, err)
+	}
+	defer rows.Close()
+
+	values := []ReferenceValue{}
+	for rows.Next() {
+		var value ReferenceValue
+		if err := rows.Scan(&value.Code, &value.Label); err != nil {
+			return nil, fmt.Errorf("erreur lors du scan de %s: %w", r.table, err)
+		}
+		values = append(values, value)
+	}
+
+	return values, rows.Err()
+}
+
+func (r *ReferenceRepository) GetByCode(code string) (*ReferenceValue, error) {
+	var value ReferenceValue
+	err := r.db.QueryRow("SELECT code, label FROM "+r.table+" WHERE code = ?", code).Scan(&value.Code, &value.Label)
+
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération de %s: %w", r.table, classify(err, r.table))
+	}
+
+	return &value, nil
+}
+
+func (r *ReferenceRepository) Create(value *ReferenceValue) (*ReferenceValue, error) {
+	_, err := r.db.Exec("INSERT INTO "+r.table+" (code, label) VALUES (?, ?)", value.Code, value.Label)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la création dans %s: %w", r.table, classify(err, r.table))
+	}
+
+	return value, nil
+}
+
+// Update modifie le libellé et éventuellement le code ; les races liées
+// suivent grâce au ON UPDATE CASCADE de la clé étrangère
+func (r *ReferenceRepository) Update(code string, value *ReferenceValue) (*ReferenceValue, error) {
+	newCode := value.Code
+	if newCode == "" {
+		newCode = code
+	}
+
+	query := "UPDATE " + r.table + " SET code = ?"
+	args := []interface{}{newCode}
+	if value.Label != "" {
+		query += ", label = ?"
+		args = append(args, value.Label)
+	}
+	query += " WHERE code = ?"
+	args = append(args, code)
+
+	_, err := r.db.Exec(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la mise à jour dans %s: %w", r.table, classify(err, r.table))
+	}
+
+	return r.GetByCode(newCode)
+}
+
+func (r *ReferenceRepository) Delete(code string) error {
+	result, err := r.db.Exec("DELETE FROM "+r.table+" WHERE code = ?", code)
+	if err != nil {
+		return fmt.Errorf("erreur lors de la suppression dans %s: %w", r.table, classify(err, r.table))
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("erreur lors de la vérification de la suppression: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return newError(ErrNotFound, r.table, nil)
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type SchemaRepositoryInterface interface {
+	Ping(ctx context.Context) error
+	MigrationVersion(ctx context.Context) (version uint, dirty bool, err error)
+}
+
+type SchemaRepository struct {
+	db *sql.DB
+}
+
+func NewSchemaRepository(db *sql.DB) *SchemaRepository {
+	return &SchemaRepository{db: db}
+}
+
+// Ping vérifie que la base répond
+func (r *SchemaRepository) Ping(ctx context.Context) error {
+	return r.db.PingContext(ctx)
+}
+
+// MigrationVersion lit la version de schéma appliquée par golang-migrate, et
+// si la dernière migration s'est interrompue en cours de route (dirty)
+func (r *SchemaRepository) MigrationVersion(ctx context.Context) (uint, bool, error) {
+	var version uint
+	var dirty bool
+	err := r.db.QueryRowContext(ctx, "SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&version, &dirty)
+	if err == sql.ErrNoRows {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, fmt.Errorf("erreur lors de la lecture de la version du schéma: %w", err)
+	}
+
+	return version, dirty, nil
+}
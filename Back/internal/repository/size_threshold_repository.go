@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// SizeThreshold associe une taille au poids moyen maximal (en grammes) d'une
+// espèce ; MaxWeight nil signifie « sans limite supérieure »
+type SizeThreshold struct {
+	Species   string `json:"species" db:"species"`
+	PetSize   string `json:"pet_size" db:"pet_size"`
+	MaxWeight *int   `json:"max_weight" db:"max_weight"`
+}
+
+type SizeThresholdRepositoryInterface interface {
+	List() ([]SizeThreshold, error)
+	ReplaceForSpecies(species string, thresholds []SizeThreshold) error
+}
+
+type SizeThresholdRepository struct {
+	db *sql.DB
+}
+
+func NewSizeThresholdRepository(db *sql.DB) *SizeThresholdRepository {
+	return &SizeThresholdRepository{db: db}
+}
+
+// List retourne les seuils triés par espèce puis par poids croissant
+func (r *SizeThresholdRepository) List() ([]SizeThreshold, error) {
+	query := "SELECT species, pet_size, max_weight FROM pet_size_thresholds ORDER BY species, max_weight IS NULL, max_weight"
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la récupération des seuils de taille: %w", err)
+	}
+	defer rows.Close()
+
+	thresholds := []SizeThreshold{}
+	for rows.Next() {
+		var threshold SizeThreshold
+		var maxWeight sql.NullInt64
+		if err := rows.Scan(&threshold.Species, &threshold.PetSize, &maxWeight); err != nil {
+			return nil, fmt.Errorf("erreur lors du scan du seuil de taille: %w", err)
+		}
+		if maxWeight.Valid {
+			value := int(maxWeight.Int64)
+			threshold.MaxWeight = &value
+		}
+		thresholds = append(thresholds, threshold)
+	}
+
+	return thresholds, rows.Err()
+}
+
+// ReplaceForSpecies remplace en une transaction tous les seuils d'une espèce
+func (r *SizeThresholdRepository) ReplaceForSpecies(species string, thresholds []SizeThreshold) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("erreur lors du début de la transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec("DELETE FROM pet_size_thresholds WHERE species = ?", species); err != nil {
+		return fmt.Errorf("erreur lors de la suppression des seuils de taille: %w", err)
+	}
+
+	for _, threshold := range thresholds {
+		_, err := tx.Exec("INSERT INTO pet_size_thresholds (species, pet_size, max_weight) VALUES (?, ?, ?)", species, threshold.PetSize, threshold.MaxWeight)
+		if err != nil {
+			return fmt.Errorf("erreur lors de l'insertion du seuil %s: %w", threshold.PetSize, classify(err, "pet_size_thresholds"))
+		}
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,58 @@
+package requestid
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"regexp"
+)
+
+// Header est l'en-tête portant l'identifiant de requête
+const Header = "X-Request-ID"
+
+type contextKey struct{}
+
+// Un identifiant fourni par le client est repris s'il reste raisonnable,
+// pour ne pas injecter n'importe quoi dans les logs
+var validID = regexp.MustCompile(`^[A-Za-z0-9._\-]{1,128}$`)
+
+// Middleware reprend l'X-Request-ID entrant ou en génère un, le place dans le
+// contexte et le renvoie dans la réponse
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := Sanitize(r.Header.Get(Header))
+
+		w.Header().Set(Header, id)
+		next.ServeHTTP(w, r.WithContext(WithID(r.Context(), id)))
+	})
+}
+
+// Sanitize reprend un identifiant fourni par le client s'il est valide et en
+// génère un sinon
+func Sanitize(id string) string {
+	if !validID.MatchString(id) {
+		return New()
+	}
+	return id
+}
+
+// New génère un identifiant aléatoire de 32 caractères hexadécimaux
+func New() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(buf)
+}
+
+// WithID retourne un contexte portant l'identifiant
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext retourne l'identifiant de la requête, vide s'il n'y en a pas
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
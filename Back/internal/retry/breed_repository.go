@@ -0,0 +1,74 @@
+package retry
+
+import (
+	"context"
+	"time"
+
+	"github.com/japhy-tech/backend-test/internal/repository"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// BreedRepository relance les lectures du repository des races sur une
+// erreur passagère ; les écritures, qui ont pu aboutir avant que la
+// connexion ne soit perdue, ne sont jamais relancées
+type BreedRepository struct {
+	repository.BreedRepositoryInterface
+	policy Policy
+}
+
+// NewBreedRepository décore repo ; attempts compte la première tentative,
+// en dessous de 2 les lectures ne sont pas relancées
+func NewBreedRepository(repo repository.BreedRepositoryInterface, attempts int, backoff Backoff) *BreedRepository {
+	if attempts < 1 {
+		attempts = 1
+	}
+	return &BreedRepository{
+		BreedRepositoryInterface: repo,
+		policy: Policy{
+			Backoff:   backoff,
+			Attempts:  attempts,
+			Retryable: Transient,
+		},
+	}
+}
+
+func (r *BreedRepository) GetAll(ctx context.Context, filter repository.BreedFilter) ([]repository.Breed, error) {
+	var breeds []repository.Breed
+	err := r.read(ctx, func(ctx context.Context) (err error) {
+		breeds, err = r.BreedRepositoryInterface.GetAll(ctx, filter)
+		return err
+	})
+	return breeds, err
+}
+
+func (r *BreedRepository) GetByID(ctx context.Context, id int) (*repository.Breed, error) {
+	var breed *repository.Breed
+	err := r.read(ctx, func(ctx context.Context) (err error) {
+		breed, err = r.BreedRepositoryInterface.GetByID(ctx, id)
+		return err
+	})
+	return breed, err
+}
+
+func (r *BreedRepository) Resolve(ctx context.Context, name string) (*repository.Breed, error) {
+	var breed *repository.Breed
+	err := r.read(ctx, func(ctx context.Context) (err error) {
+		breed, err = r.BreedRepositoryInterface.Resolve(ctx, name)
+		return err
+	})
+	return breed, err
+}
+
+// read note chaque nouvelle tentative sur le span de l'appel
+func (r *BreedRepository) read(ctx context.Context, fn func(ctx context.Context) error) error {
+	policy := r.policy
+	policy.OnRetry = func(attempt int, delay time.Duration, err error) {
+		trace.SpanFromContext(ctx).AddEvent("retry", trace.WithAttributes(
+			attribute.Int("retry.attempt", attempt),
+			attribute.Int64("retry.delay_ms", delay.Milliseconds()),
+			attribute.String("retry.error", err.Error()),
+		))
+	}
+	return Do(ctx, policy, fn)
+}
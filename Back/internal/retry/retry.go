@@ -0,0 +1,112 @@
+// Package retry relance les opérations sur MySQL qui échouent pour une
+// raison passagère : connexion au démarrage, pendant que la base finit de
+// démarrer, et lectures interrompues par une connexion perdue
+package retry
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// Backoff décrit l'attente entre deux tentatives : elle double à chaque
+// échec, de Initial jusqu'à Max
+type Backoff struct {
+	Initial time.Duration
+	Max     time.Duration
+}
+
+// Delay retourne l'attente après l'échec numéro attempt (à partir de 1),
+// tirée au hasard entre la moitié et la totalité du délai
+// exponentiel pour que les instances redémarrées ensemble ne se
+// synchronisent pas
+func (b Backoff) Delay(attempt int) time.Duration {
+	delay := b.Initial
+	for i := 1; i < attempt && delay < b.Max; i++ {
+		delay *= 2
+	}
+	if delay > b.Max {
+		delay = b.Max
+	}
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// Policy décrit quand relancer une opération
+type Policy struct {
+	Backoff
+	// Attempts borne le nombre de tentatives ; 0 relance jusqu'à
+	// l'expiration du contexte
+	Attempts int
+	// Retryable indique si une erreur mérite une nouvelle tentative ; nil
+	// relance toute erreur
+	Retryable func(error) bool
+	// OnRetry est appelé avant chaque attente, pour journaliser
+	OnRetry func(attempt int, delay time.Duration, err error)
+}
+
+// Do exécute fn jusqu'à ce qu'elle réussisse, que son erreur ne soit pas
+// passagère, que les tentatives soient épuisées ou que ctx expire ; la
+// dernière erreur de fn est retournée
+func Do(ctx context.Context, policy Policy, fn func(ctx context.Context) error) error {
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+		if policy.Retryable != nil && !policy.Retryable(err) {
+			return err
+		}
+		if policy.Attempts > 0 && attempt >= policy.Attempts {
+			return err
+		}
+
+		delay := policy.Delay(attempt)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return err
+		}
+		if policy.OnRetry != nil {
+			policy.OnRetry(attempt, delay, err)
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// Transient indique si une erreur MySQL est passagère : connexion perdue ou
+// refusée, serveur qui redémarre, trop de connexions, verrou en attente ou
+// interblocage. Une requête invalide ou une contrainte violée ne l'est pas.
+func Transient(err error) bool {
+	if errors.Is(err, driver.ErrBadConn) || errors.Is(err, mysql.ErrInvalidConn) || errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) {
+		switch mysqlErr.Number {
+		case 1040, // ER_CON_COUNT_ERROR : trop de connexions
+			1053, // ER_SERVER_SHUTDOWN : arrêt du serveur en cours
+			1205, // ER_LOCK_WAIT_TIMEOUT
+			1213: // ER_LOCK_DEADLOCK
+			return true
+		}
+		return false
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
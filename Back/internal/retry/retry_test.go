@@ -0,0 +1,151 @@
+package retry
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
+	"github.com/japhy-tech/backend-test/internal/repository"
+)
+
+func TestBackoff_Delay(t *testing.T) {
+	backoff := Backoff{Initial: 100 * time.Millisecond, Max: time.Second}
+
+	for attempt, want := range map[int]time.Duration{
+		1: 100 * time.Millisecond,
+		2: 200 * time.Millisecond,
+		4: 800 * time.Millisecond,
+		5: time.Second,
+		9: time.Second,
+	} {
+		for range 20 {
+			delay := backoff.Delay(attempt)
+			if delay < want/2 || delay > want {
+				t.Fatalf("tentative %d : attente %v hors de [%v, %v]", attempt, delay, want/2, want)
+			}
+		}
+	}
+}
+
+func TestDo_StopsOnPermanentError(t *testing.T) {
+	calls := 0
+	permanent := &mysql.MySQLError{Number: 1045, Message: "Access denied"}
+
+	err := Do(context.Background(), Policy{Retryable: Transient}, func(context.Context) error {
+		calls++
+		return permanent
+	})
+	if !errors.Is(err, permanent) || calls != 1 {
+		t.Errorf("erreur %v après %d appels, attendu l'erreur d'origine après 1 appel", err, calls)
+	}
+}
+
+func TestDo_RetriesUntilSuccess(t *testing.T) {
+	calls := 0
+	var retried []int
+
+	err := Do(context.Background(), Policy{
+		Backoff:   Backoff{Initial: time.Millisecond, Max: time.Millisecond},
+		Retryable: Transient,
+		OnRetry:   func(attempt int, delay time.Duration, err error) { retried = append(retried, attempt) },
+	}, func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return mysql.ErrInvalidConn
+		}
+		return nil
+	})
+	if err != nil || calls != 3 || len(retried) != 2 {
+		t.Errorf("erreur %v, %d appels, relances %v", err, calls, retried)
+	}
+}
+
+func TestDo_Attempts(t *testing.T) {
+	calls := 0
+	err := Do(context.Background(), Policy{Backoff: Backoff{Initial: time.Millisecond, Max: time.Millisecond}, Attempts: 2}, func(context.Context) error {
+		calls++
+		return driver.ErrBadConn
+	})
+	if !errors.Is(err, driver.ErrBadConn) || calls != 2 {
+		t.Errorf("erreur %v après %d appels, attendu 2", err, calls)
+	}
+}
+
+func TestDo_StopsAtDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	err := Do(ctx, Policy{Backoff: Backoff{Initial: 10 * time.Millisecond, Max: 10 * time.Millisecond}}, func(context.Context) error {
+		return errors.New("connection refused")
+	})
+	if err == nil || time.Since(start) > time.Second {
+		t.Errorf("erreur %v après %v : la relance doit s'arrêter à l'expiration du contexte", err, time.Since(start))
+	}
+}
+
+func TestTransient(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{driver.ErrBadConn, true},
+		{fmt.Errorf("erreur lors de la récupération des races: %w", mysql.ErrInvalidConn), true},
+		{&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, true},
+		{&mysql.MySQLError{Number: 1040}, true},
+		{&mysql.MySQLError{Number: 1213}, true},
+		{&mysql.MySQLError{Number: 1062}, false},
+		{&mysql.MySQLError{Number: 1045}, false},
+		{repository.ErrNotFound, false},
+		{context.Canceled, false},
+	}
+	for _, tt := range tests {
+		if got := Transient(tt.err); got != tt.want {
+			t.Errorf("Transient(%v) = %v, attendu %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+type flakyBreedRepository struct {
+	repository.BreedRepositoryInterface
+	failures int
+	calls    int
+}
+
+func (m *flakyBreedRepository) GetByID(ctx context.Context, id int) (*repository.Breed, error) {
+	m.calls++
+	if m.calls <= m.failures {
+		return nil, mysql.ErrInvalidConn
+	}
+	return &repository.Breed{ID: id}, nil
+}
+
+func (m *flakyBreedRepository) Delete(ctx context.Context, id int) error {
+	m.calls++
+	return mysql.ErrInvalidConn
+}
+
+func TestBreedRepository_RetriesReadsOnly(t *testing.T) {
+	backoff := Backoff{Initial: time.Millisecond, Max: time.Millisecond}
+
+	flaky := &flakyBreedRepository{failures: 2}
+	breed, err := NewBreedRepository(flaky, 3, backoff).GetByID(context.Background(), 7)
+	if err != nil || breed.ID != 7 || flaky.calls != 3 {
+		t.Errorf("lecture : %v, %v après %d appels", breed, err, flaky.calls)
+	}
+
+	flaky = &flakyBreedRepository{failures: 5}
+	if _, err := NewBreedRepository(flaky, 3, backoff).GetByID(context.Background(), 7); err == nil || flaky.calls != 3 {
+		t.Errorf("lecture : %v après %d appels, attendu l'échec après 3", err, flaky.calls)
+	}
+
+	flaky = &flakyBreedRepository{}
+	if err := NewBreedRepository(flaky, 3, backoff).Delete(context.Background(), 7); err == nil || flaky.calls != 1 {
+		t.Errorf("une écriture ne doit pas être relancée : %d appels", flaky.calls)
+	}
+}
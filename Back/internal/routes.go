@@ -0,0 +1,195 @@
+package internal
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/auth"
+	"github.com/japhy-tech/backend-test/internal/deprecation"
+	"github.com/japhy-tech/backend-test/internal/graphql"
+	"github.com/japhy-tech/backend-test/internal/grpcapi"
+	"github.com/japhy-tech/backend-test/internal/handlers"
+	"github.com/japhy-tech/backend-test/internal/logging"
+	"github.com/japhy-tech/backend-test/internal/openapi"
+	"github.com/japhy-tech/backend-test/internal/problem"
+	"github.com/japhy-tech/backend-test/internal/ratelimit"
+	"github.com/japhy-tech/backend-test/internal/spa"
+	"github.com/japhy-tech/backend-test/internal/tracing"
+	"google.golang.org/grpc"
+)
+
+// v1Handlers regroupe les handlers de l'API v1 ; une v2 aurait sa propre
+// structure, construite dans NewApp sur les mêmes repositories et services
+type v1Handlers struct {
+	breeds             *handlers.BreedHandler
+	translations       *handlers.BreedTranslationHandler
+	aliases            *handlers.BreedAliasHandler
+	species            *handlers.ReferenceHandler
+	petSizes           *handlers.ReferenceHandler
+	sizeClassification *handlers.SizeClassificationHandler
+	apiKeys            *handlers.APIKeyHandler
+}
+
+// ExposedHeaders sont les en-têtes de réponse de l'API que le front doit
+// pouvoir lire malgré CORS
+var ExposedHeaders = []string{
+	"Content-Language", "X-Request-ID", "Deprecation", "Sunset", "Link",
+	"RateLimit-Policy", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset", "Retry-After",
+}
+
+// QuietPaths sont les sondes et métriques, appelées en continu : elles ne
+// sont ni tracées ni journalisées au-delà du niveau debug
+var QuietPaths = []string{"/livez", "/health", "/readyz", "/metrics"}
+
+// RegisterRoutes monte l'API sous /v1 ; les anciens chemins sans version
+// restent servis par les mêmes handlers, avec les en-têtes Deprecation et
+// Sunset, le temps que les clients migrent. Chaque route exige un rôle :
+// reader pour les lectures, editor pour les créations et modifications,
+// admin pour toutes les suppressions, les imports et la gestion des clés. Chaque route
+// décompte aussi ses requêtes dans un budget : lecture, écriture ou import.
+func (a *App) RegisterRoutes(r *mux.Router) {
+	// Chaque requête est mesurée, journalisée avec un logger portant son
+	// identifiant et sa route, et son span nommé d'après la route ; Use ne
+	// s'appliquant qu'aux routes reconnues, les 404 et 405 le sont à part.
+	// Les sondes et métriques, appelées en continu, ne sont journalisées
+	// qu'au niveau debug.
+	accessLog := logging.Middleware(a.logger, QuietPaths...)
+	r.Use(a.metrics.Middleware, accessLog, tracing.Middleware)
+
+	// Sondes, hors version et publiques pour l'orchestrateur, qui n'a pas
+	// d'identifiants ; /health reste l'alias de /livez pour les healthchecks
+	// existants
+	r.Handle("/livez", a.health.LiveHandler()).Methods(http.MethodGet)
+	r.Handle("/health", a.health.LiveHandler()).Methods(http.MethodGet)
+	r.Handle("/readyz", a.health.ReadyHandler()).Methods(http.MethodGet)
+
+	// Les métriques révèlent les routes, les volumes et l'état de la base :
+	// Prometheus les lit avec une clé reader
+	r.Handle("/metrics", a.guard(auth.RoleReader, ratelimit.Read)(a.metrics.Handler().ServeHTTP)).Methods(http.MethodGet)
+
+	// Documentation de l'API, commune à toutes les versions et publique : elle
+	// ne décrit que les routes et leurs schémas, déjà connus du front, et les
+	// clients en ont besoin avant d'avoir une clé
+	r.Handle("/openapi.json", openapi.Handler()).Methods(http.MethodGet)
+	r.Handle("/docs", openapi.DocsHandler()).Methods(http.MethodGet)
+
+	// GraphQL, hors version : le schéma évolue sans casser les clients ; les
+	// mutations contrôlent elles-mêmes le rôle de l'appelant
+	r.Handle("/graphql", a.guard(auth.RoleReader, ratelimit.Read)(a.graphql.ServeHTTP)).Methods(http.MethodPost)
+	if a.graphiql {
+		r.Handle("/graphql", graphql.GraphiQLHandler()).Methods(http.MethodGet)
+	}
+
+	// Le préfixe est ajouté à chaque chemin plutôt que porté par
+	// PathPrefix : les routes d'un sous-routeur PathPrefix héritent de son
+	// matcher, qui efface l'erreur de méthode et transforme les 405 en 404
+	v1 := r.NewRoute().Subrouter()
+	a.registerV1Routes(v1, "/v1")
+
+	// La gestion des clés n'existe qu'en v1
+	admin := a.guard(auth.RoleAdmin, ratelimit.Write)
+	v1.Handle("/v1/api-keys", admin(a.v1.apiKeys.List)).Methods(http.MethodGet)
+	v1.Handle("/v1/api-keys", admin(a.v1.apiKeys.Create)).Methods(http.MethodPost)
+	v1.Handle("/v1/api-keys/{id:[0-9]+}", admin(a.v1.apiKeys.Revoke)).Methods(http.MethodDelete)
+
+	legacy := r.NewRoute().Subrouter()
+	legacy.Use(deprecation.Middleware(a.legacyPolicy))
+	a.registerV1Routes(legacy, "")
+
+	notFound := problem.NotFoundHandler()
+	if a.front != nil {
+		// Les chemins inconnus du routeur vont au front, sauf sous les
+		// préfixes de l'API (/v1, anciens chemins, /graphql, /docs...) où ils
+		// restent des 404 de l'API
+		notFound = spa.Handler(a.front, spa.Options{
+			ContentSecurityPolicy: a.frontPolicy,
+			ReservedPrefixes:      apiPrefixes(r),
+		}, notFound)
+	}
+	r.NotFoundHandler = a.metrics.Middleware(accessLog(notFound))
+	r.MethodNotAllowedHandler = a.metrics.Middleware(accessLog(problem.MethodNotAllowedHandler()))
+}
+
+// apiPrefixes retourne le premier segment du chemin de chaque route
+// enregistrée, pour que le front ne puisse masquer aucune route de l'API
+func apiPrefixes(r *mux.Router) []string {
+	seen := map[string]bool{}
+	var prefixes []string
+	r.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		if err != nil {
+			// Sous-routeur sans chemin
+			return nil
+		}
+		segment, _, _ := strings.Cut(strings.TrimPrefix(template, "/"), "/")
+		if prefix := "/" + segment; !seen[prefix] {
+			seen[prefix] = true
+			prefixes = append(prefixes, prefix)
+		}
+		return nil
+	})
+	return prefixes
+}
+
+// NewGRPCServer crée le serveur gRPC des races, servi sur son propre port,
+// avec le même contrôle d'accès que l'API HTTP
+func (a *App) NewGRPCServer() *grpc.Server {
+	s := grpc.NewServer(grpcapi.ServerOptions(a.authenticator, a.logger)...)
+	a.grpc.Register(s)
+	return s
+}
+
+func (a *App) registerV1Routes(r *mux.Router, prefix string) {
+	h := a.v1
+	reader := a.guard(auth.RoleReader, ratelimit.Read)
+	editor := a.guard(auth.RoleEditor, ratelimit.Write)
+	admin := a.guard(auth.RoleAdmin, ratelimit.Write)
+	importer := a.guard(auth.RoleAdmin, ratelimit.Import)
+
+	// Routes pour les races
+	r.Handle(prefix+"/breeds", reader(h.breeds.GetAllBreeds)).Methods(http.MethodGet)
+	r.Handle(prefix+"/breeds", editor(h.breeds.CreateBreed)).Methods(http.MethodPost)
+	r.Handle(prefix+"/breeds/size-consistency", reader(h.breeds.GetSizeInconsistencies)).Methods(http.MethodGet)
+	r.Handle(prefix+"/breeds/resolve", reader(h.breeds.ResolveBreed)).Methods(http.MethodGet)
+	r.Handle(prefix+"/breeds/{id:[0-9]+}", reader(h.breeds.GetBreedByID)).Methods(http.MethodGet)
+	r.Handle(prefix+"/breeds/{id:[0-9]+}", editor(h.breeds.UpdateBreed)).Methods(http.MethodPut)
+	r.Handle(prefix+"/breeds/{id:[0-9]+}", admin(h.breeds.DeleteBreed)).Methods(http.MethodDelete)
+	r.Handle(prefix+"/import-breeds", importer(a.ImportBreedsFromCSV)).Methods(http.MethodPost)
+
+	// Routes pour les noms d'affichage traduits
+	r.Handle(prefix+"/breeds/{id:[0-9]+}/translations", reader(h.translations.List)).Methods(http.MethodGet)
+	r.Handle(prefix+"/breeds/{id:[0-9]+}/translations/{locale}", editor(h.translations.Put)).Methods(http.MethodPut)
+	r.Handle(prefix+"/breeds/{id:[0-9]+}/translations/{locale}", admin(h.translations.Delete)).Methods(http.MethodDelete)
+	r.Handle(prefix+"/import-breed-translations", importer(a.ImportBreedTranslationsFromCSV)).Methods(http.MethodPost)
+
+	// Routes pour les alias des races
+	r.Handle(prefix+"/breeds/{id:[0-9]+}/aliases", reader(h.aliases.List)).Methods(http.MethodGet)
+	r.Handle(prefix+"/breeds/{id:[0-9]+}/aliases", editor(h.aliases.Create)).Methods(http.MethodPost)
+	r.Handle(prefix+"/breeds/{id:[0-9]+}/aliases/{alias}", admin(h.aliases.Delete)).Methods(http.MethodDelete)
+	r.Handle(prefix+"/import-breed-aliases", importer(a.ImportBreedAliasesFromCSV)).Methods(http.MethodPost)
+	r.Handle(prefix+"/export-breed-aliases", reader(a.ExportBreedAliasesToCSV)).Methods(http.MethodGet)
+
+	// Routes pour les données de référence
+	registerReferenceRoutes(r, prefix+"/species", h.species, reader, editor, admin)
+	registerReferenceRoutes(r, prefix+"/pet-sizes", h.petSizes, reader, editor, admin)
+	r.Handle(prefix+"/size-classification", reader(h.sizeClassification.List)).Methods(http.MethodGet)
+	r.Handle(prefix+"/size-classification/{species}", editor(h.sizeClassification.Replace)).Methods(http.MethodPut)
+}
+
+// guard décompte la requête dans le budget de son adresse IP, exige un rôle
+// puis la décompte dans le budget de sa classe, par appelant authentifié ;
+// les requêtes refusées à l'authentification épuisent ainsi leur budget IP
+func (a *App) guard(role auth.Role, class ratelimit.Class) func(http.HandlerFunc) http.Handler {
+	return func(next http.HandlerFunc) http.Handler {
+		return a.limiter.LimitAddress(a.access.Require(role, a.limiter.Limit(class, next).ServeHTTP))
+	}
+}
+
+func registerReferenceRoutes(r *mux.Router, prefix string, h *handlers.ReferenceHandler, reader, editor, admin func(http.HandlerFunc) http.Handler) {
+	r.Handle(prefix, reader(h.List)).Methods(http.MethodGet)
+	r.Handle(prefix, editor(h.Create)).Methods(http.MethodPost)
+	r.Handle(prefix+"/{code}", reader(h.Get)).Methods(http.MethodGet)
+	r.Handle(prefix+"/{code}", editor(h.Update)).Methods(http.MethodPut)
+	r.Handle(prefix+"/{code}", admin(h.Delete)).Methods(http.MethodDelete)
+}
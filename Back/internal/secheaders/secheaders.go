@@ -0,0 +1,40 @@
+// Package secheaders ajoute aux réponses les en-têtes de sécurité attendus
+// par les navigateurs
+package secheaders
+
+import "net/http"
+
+// PagePolicy est la Content-Security-Policy des pages HTML servies par l'API
+// (/docs, GraphiQL), qui chargent leurs scripts et styles depuis unpkg
+const PagePolicy = "default-src 'self'; script-src 'self' 'unsafe-inline' https://unpkg.com; " +
+	"style-src 'self' 'unsafe-inline' https://unpkg.com; img-src 'self' data: https:; " +
+	"connect-src 'self'; frame-ancestors 'none'"
+
+// Policy décrit les en-têtes de sécurité de l'API
+type Policy struct {
+	// ContentSecurityPolicy s'applique aux réponses JSON ; les pages HTML la
+	// remplacent par PagePolicy
+	ContentSecurityPolicy string
+	// FrameOptions vaut DENY ou SAMEORIGIN
+	FrameOptions string
+}
+
+// Middleware ajoute Content-Security-Policy, X-Content-Type-Options,
+// X-Frame-Options et Referrer-Policy à chaque réponse ; un handler peut les
+// remplacer avant d'écrire sa réponse
+func Middleware(policy Policy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := w.Header()
+			if policy.ContentSecurityPolicy != "" {
+				header.Set("Content-Security-Policy", policy.ContentSecurityPolicy)
+			}
+			if policy.FrameOptions != "" {
+				header.Set("X-Frame-Options", policy.FrameOptions)
+			}
+			header.Set("X-Content-Type-Options", "nosniff")
+			header.Set("Referrer-Policy", "no-referrer")
+			next.ServeHTTP(w, r)
+		})
+	}
+}
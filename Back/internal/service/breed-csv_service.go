@@ -3,9 +3,13 @@ package service
 import (
 	"encoding/csv"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
+	"strings"
+	"github.com/japhy-tech/backend-test/internal/i18n"
 	"github.com/japhy-tech/backend-test/internal/repository"
+	"github.com/japhy-tech/backend-test/internal/units"
 )
 
 type CSVService struct{}
@@ -34,22 +38,22 @@ func (s *CSVService) ReadBreedsFromCSV(filename string) ([]repository.Breed, err
 	var breeds []repository.Breed
 	for i, record := range records[1:] {
 		if len(record) != 6 {
-			return nil, fmt.Errorf("ligne %d: nombre de colonnes incorrect (attendu: 6, reçu: %d)", i+2, len(record))
+			return nil, i18n.New(i18n.CSVLine, i+2, i18n.New(i18n.CSVColumnCount, 6, len(record)))
 		}
 
 		id, err := strconv.Atoi(record[0])
 		if err != nil {
-			return nil, fmt.Errorf("ligne %d: ID invalide '%s': %w", i+2, record[0], err)
+			return nil, i18n.New(i18n.CSVLine, i+2, i18n.New(i18n.CSVInvalidID, record[0]))
 		}
 
 		maleWeight, err := strconv.Atoi(record[4])
 		if err != nil {
-			return nil, fmt.Errorf("ligne %d: poids mâle invalide '%s': %w", i+2, record[4], err)
+			return nil, i18n.New(i18n.CSVLine, i+2, i18n.New(i18n.CSVInvalidWeight, i18n.New(i18n.MaleWeight), record[4]))
 		}
 
 		femaleWeight, err := strconv.Atoi(record[5])
 		if err != nil {
-			return nil, fmt.Errorf("ligne %d: poids femelle invalide '%s': %w", i+2, record[5], err)
+			return nil, i18n.New(i18n.CSVLine, i+2, i18n.New(i18n.CSVInvalidWeight, i18n.New(i18n.FemaleWeight), record[5]))
 		}
 
 		breed := repository.Breed{
@@ -65,4 +69,246 @@ func (s *CSVService) ReadBreedsFromCSV(filename string) ([]repository.Breed, err
 	}
 
 	return breeds, nil
-}
\ No newline at end of file
+}
+
+// ValidateReferences normalise l'espèce et la taille de chaque race importée
+// et rejette les valeurs absentes des données de référence ; une taille vide
+// est laissée telle quelle pour être déduite des poids
+func (s *CSVService) ValidateReferences(breeds []repository.Breed, refs *ReferenceSet) error {
+	for i := range breeds {
+		species, err := refs.NormalizeSpecies(breeds[i].Species)
+		if err != nil {
+			return i18n.New(i18n.CSVLine, i+2, err)
+		}
+		breeds[i].Species = species
+
+		if breeds[i].PetSize == "" {
+			continue
+		}
+		petSize, err := refs.NormalizePetSize(breeds[i].PetSize)
+		if err != nil {
+			return i18n.New(i18n.CSVLine, i+2, err)
+		}
+		breeds[i].PetSize = petSize
+	}
+
+	return nil
+}
+
+// ApplySizeClassification déduit les tailles manquantes à partir des poids et,
+// si la cohérence est imposée, rejette les tailles qui contredisent les poids
+func (s *CSVService) ApplySizeClassification(breeds []repository.Breed, classification *SizeClassification) error {
+	for i := range breeds {
+		if err := classification.Resolve(&breeds[i]); err != nil {
+			return i18n.New(i18n.CSVLine, i+2, err)
+		}
+	}
+
+	return nil
+}
+
+// ValidateWeightMagnitudes rejette les poids dont l'ordre de grandeur laisse
+// penser qu'ils ne sont pas en grammes (ex: 6 au lieu de 6000) ; un poids à 0
+// est considéré comme inconnu
+func (s *CSVService) ValidateWeightMagnitudes(breeds []repository.Breed) error {
+	for i, breed := range breeds {
+		for _, weight := range []struct {
+			label *i18n.Message
+			value int
+		}{
+			{i18n.New(i18n.MaleWeight), breed.AverageMaleAdultWeight},
+			{i18n.New(i18n.FemaleWeight), breed.AverageFemaleAdultWeight},
+		} {
+			switch {
+			case weight.value < 0:
+				return i18n.New(i18n.CSVLine, i+2, i18n.New(i18n.CSVNegativeWeight, weight.label, weight.value))
+			case weight.value > 0 && weight.value < units.MinPlausibleGrams:
+				return i18n.New(i18n.CSVLine, i+2, i18n.New(i18n.CSVWeightTooLow, weight.label, weight.value))
+			case weight.value > units.MaxPlausibleGrams:
+				return i18n.New(i18n.CSVLine, i+2, i18n.New(i18n.CSVWeightTooHigh, weight.label, weight.value, units.MaxPlausibleGrams))
+			}
+		}
+	}
+
+	return nil
+}
+
+// BreedTranslationRow est une ligne du CSV des traductions : la race y est
+// désignée par son identifiant (name), stable d'un import à l'autre
+type BreedTranslationRow struct {
+	Name        string
+	Locale      string
+	DisplayName string
+}
+
+// ReadBreedTranslationsFromCSV lit un fichier de colonnes name, locale, display_name
+func (s *CSVService) ReadBreedTranslationsFromCSV(filename string) ([]BreedTranslationRow, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'ouverture du fichier CSV: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la lecture du fichier CSV: %w", err)
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("le fichier CSV est vide")
+	}
+
+	var rows []BreedTranslationRow
+	for i, record := range records[1:] {
+		if len(record) != 3 {
+			return nil, i18n.New(i18n.CSVLine, i+2, i18n.New(i18n.CSVColumnCount, 3, len(record)))
+		}
+
+		rows = append(rows, BreedTranslationRow{
+			Name:        strings.TrimSpace(record[0]),
+			Locale:      record[1],
+			DisplayName: strings.TrimSpace(record[2]),
+		})
+	}
+
+	return rows, nil
+}
+
+// ResolveBreedTranslations valide la langue et le nom de chaque ligne et
+// associe la traduction à l'ID de la race connue sous ce nom
+func (s *CSVService) ResolveBreedTranslations(rows []BreedTranslationRow, breeds []repository.Breed) ([]repository.BreedTranslation, error) {
+	ids := map[string]int{}
+	for _, breed := range breeds {
+		ids[breed.Name] = breed.ID
+	}
+
+	translations := make([]repository.BreedTranslation, 0, len(rows))
+	for i, row := range rows {
+		id, ok := ids[row.Name]
+		if !ok {
+			return nil, i18n.New(i18n.CSVLine, i+2, i18n.New(i18n.CSVUnknownBreed, row.Name))
+		}
+
+		locale, err := NormalizeLocale(row.Locale)
+		if err != nil {
+			return nil, i18n.New(i18n.CSVLine, i+2, err)
+		}
+
+		if row.DisplayName == "" {
+			return nil, i18n.New(i18n.CSVLine, i+2, i18n.New(i18n.CSVEmptyDisplayName))
+		}
+
+		translations = append(translations, repository.BreedTranslation{
+			BreedID:     id,
+			Locale:      locale,
+			DisplayName: row.DisplayName,
+		})
+	}
+
+	return translations, nil
+}
+
+// BreedAliasRow est une ligne du CSV des alias : la race y est désignée par
+// son identifiant (name)
+type BreedAliasRow struct {
+	Name  string
+	Alias string
+}
+
+// ReadBreedAliasesFromCSV lit un fichier de colonnes name, alias
+func (s *CSVService) ReadBreedAliasesFromCSV(filename string) ([]BreedAliasRow, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de l'ouverture du fichier CSV: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la lecture du fichier CSV: %w", err)
+	}
+
+	if len(records) == 0 {
+		return nil, fmt.Errorf("le fichier CSV est vide")
+	}
+
+	var rows []BreedAliasRow
+	for i, record := range records[1:] {
+		if len(record) != 2 {
+			return nil, i18n.New(i18n.CSVLine, i+2, i18n.New(i18n.CSVColumnCount, 2, len(record)))
+		}
+
+		rows = append(rows, BreedAliasRow{
+			Name:  strings.TrimSpace(record[0]),
+			Alias: record[1],
+		})
+	}
+
+	return rows, nil
+}
+
+// ResolveBreedAliases normalise les alias, les associe à l'ID de leur race et
+// rejette tout alias qui désignerait deux races : nom canonique d'une autre
+// race, alias existant d'une autre race ou alias répété dans le fichier.
+// Les alias égaux au nom de leur propre race ou déjà connus sont ignorés.
+func (s *CSVService) ResolveBreedAliases(rows []BreedAliasRow, breeds []repository.Breed, existing []repository.BreedAlias) ([]repository.BreedAlias, error) {
+	ids := map[string]int{}
+	names := map[int]string{}
+	for _, breed := range breeds {
+		ids[breed.Name] = breed.ID
+		names[breed.ID] = breed.Name
+	}
+	owners := map[string]int{}
+	for _, alias := range existing {
+		owners[alias.Alias] = alias.BreedID
+	}
+
+	aliases := []repository.BreedAlias{}
+	for i, row := range rows {
+		id, ok := ids[row.Name]
+		if !ok {
+			return nil, i18n.New(i18n.CSVLine, i+2, i18n.New(i18n.CSVUnknownBreed, row.Name))
+		}
+
+		alias := NormalizeAlias(row.Alias)
+		if alias == "" {
+			return nil, i18n.New(i18n.CSVLine, i+2, i18n.New(i18n.CSVEmptyAlias))
+		}
+
+		if owner, ok := ids[alias]; ok {
+			if owner == id {
+				continue
+			}
+			return nil, i18n.New(i18n.CSVLine, i+2, &AliasConflictError{Alias: alias, Breed: names[owner]})
+		}
+		if owner, ok := owners[alias]; ok {
+			if owner == id {
+				continue
+			}
+			return nil, i18n.New(i18n.CSVLine, i+2, &AliasConflictError{Alias: alias, Breed: names[owner]})
+		}
+
+		owners[alias] = id
+		aliases = append(aliases, repository.BreedAlias{Alias: alias, BreedID: id, BreedName: row.Name})
+	}
+
+	return aliases, nil
+}
+
+// WriteBreedAliasesCSV exporte les alias au format lu par ReadBreedAliasesFromCSV
+func (s *CSVService) WriteBreedAliasesCSV(w io.Writer, aliases []repository.BreedAlias) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"name", "alias"}); err != nil {
+		return err
+	}
+	for _, alias := range aliases {
+		if err := writer.Write([]string{alias.BreedName, alias.Alias}); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/japhy-tech/backend-test/internal/i18n"
+)
+
+// AliasConflictError signale un alias qui désigne déjà une autre race, sous
+// forme d'alias ou de nom canonique
+type AliasConflictError struct {
+	Alias string
+	Breed string
+}
+
+func (e *AliasConflictError) Error() string {
+	return e.Localize(i18n.Default)
+}
+
+func (e *AliasConflictError) Localize(lang i18n.Lang) string {
+	return i18n.T(lang, i18n.AliasConflict, e.Alias, e.Breed)
+}
+
+// NormalizeAlias met un nom saisi sous la forme des identifiants de races :
+// "Yorkshire Terrier" et "yorkshire-terrier" donnent "yorkshire_terrier"
+func NormalizeAlias(value string) string {
+	words := strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return r == '_' || r == '-' || unicode.IsSpace(r)
+	})
+	return strings.Join(words, "_")
+}
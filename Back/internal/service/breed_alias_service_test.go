@@ -0,0 +1,41 @@
+package service
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/japhy-tech/backend-test/internal/repository"
+)
+
+func TestResolveBreedAliases(t *testing.T) {
+	breeds := []repository.Breed{
+		{ID: 1, Name: "yorkshire_terrier"},
+		{ID: 2, Name: "german_shepherd"},
+	}
+	existing := []repository.BreedAlias{{Alias: "alsatian", BreedID: 2}}
+
+	aliases, err := NewCSVService().ResolveBreedAliases([]BreedAliasRow{
+		{Name: "yorkshire_terrier", Alias: "Yorkie"},
+		{Name: "yorkshire_terrier", Alias: "Yorkshire Terrier"},
+		{Name: "german_shepherd", Alias: "Alsatian"},
+		{Name: "yorkshire_terrier", Alias: "yorkie"},
+	}, breeds, existing)
+	if err != nil {
+		t.Fatalf("erreur inattendue: %v", err)
+	}
+	if len(aliases) != 1 || aliases[0].Alias != "yorkie" || aliases[0].BreedID != 1 {
+		t.Errorf("seul l'alias yorkie devait être retenu, obtenu %+v", aliases)
+	}
+
+	for _, rows := range [][]BreedAliasRow{
+		{{Name: "yorkshire_terrier", Alias: "German Shepherd"}},
+		{{Name: "yorkshire_terrier", Alias: "alsatian"}},
+		{{Name: "yorkshire_terrier", Alias: "shepherd"}, {Name: "german_shepherd", Alias: "Shepherd"}},
+	} {
+		_, err := NewCSVService().ResolveBreedAliases(rows, breeds, existing)
+		var conflict *AliasConflictError
+		if !errors.As(err, &conflict) {
+			t.Errorf("conflit attendu pour %+v, obtenu %v", rows, err)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/japhy-tech/backend-test/internal/repository"
+)
+
+// ReferenceSource fournit les espèces et tailles autorisées
+type ReferenceSource interface {
+	Snapshot() (*ReferenceSet, error)
+}
+
+// SizeSource fournit les seuils de taille par espèce
+type SizeSource interface {
+	Snapshot() (*SizeClassification, error)
+}
+
+// BreedService applique les règles d'écriture d'une race (données de
+// référence, taille déduite des poids) pour toutes les API qui en modifient
+type BreedService struct {
+	repo       repository.BreedRepositoryInterface
+	references ReferenceSource
+	sizes      SizeSource
+}
+
+func NewBreedService(repo repository.BreedRepositoryInterface, references ReferenceSource, sizes SizeSource) *BreedService {
+	return &BreedService{
+		repo:       repo,
+		references: references,
+		sizes:      sizes,
+	}
+}
+
+// Create normalise l'espèce et la taille, déduit la taille absente puis crée
+// la race ; une règle non respectée renvoie une ValidationError
+func (s *BreedService) Create(ctx context.Context, breed *repository.Breed) (*repository.Breed, error) {
+	if err := s.normalizeReferences(breed); err != nil {
+		return nil, err
+	}
+	if err := s.resolvePetSize(breed); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Create(ctx, breed)
+}
+
+// Update applique une mise à jour partielle (chaînes vides et poids à 0
+// inchangés) après avoir vérifié la cohérence de la race qui en résulte
+func (s *BreedService) Update(ctx context.Context, id int, changes *repository.Breed) (*repository.Breed, error) {
+	// La race est lue sur la base principale : une réplique en retard
+	// fausserait la vérification de la race fusionnée
+	existing, err := s.repo.GetByID(repository.ReadPrimary(ctx), id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.normalizeReferences(changes); err != nil {
+		return nil, err
+	}
+
+	merged := *existing
+	if changes.Species != "" {
+		merged.Species = changes.Species
+	}
+	if changes.PetSize != "" {
+		merged.PetSize = changes.PetSize
+	}
+	if changes.AverageMaleAdultWeight > 0 {
+		merged.AverageMaleAdultWeight = changes.AverageMaleAdultWeight
+	}
+	if changes.AverageFemaleAdultWeight > 0 {
+		merged.AverageFemaleAdultWeight = changes.AverageFemaleAdultWeight
+	}
+	if err := s.resolvePetSize(&merged); err != nil {
+		return nil, err
+	}
+
+	return s.repo.Update(ctx, id, changes)
+}
+
+// normalizeReferences valide l'espèce et la taille, si elles sont
+// renseignées, contre les données de référence
+func (s *BreedService) normalizeReferences(breed *repository.Breed) error {
+	refs, err := s.references.Snapshot()
+	if err != nil {
+		return fmt.Errorf("erreur lors du chargement des données de référence: %w", err)
+	}
+
+	var errs ValidationError
+	if breed.Species != "" {
+		normalized, err := refs.NormalizeSpecies(breed.Species)
+		if err != nil {
+			errs = append(errs, FieldError{Field: "species", Value: breed.Species, Err: err})
+		}
+		breed.Species = normalized
+	}
+
+	if breed.PetSize != "" {
+		normalized, err := refs.NormalizePetSize(breed.PetSize)
+		if err != nil {
+			errs = append(errs, FieldError{Field: "pet_size", Value: breed.PetSize, Err: err})
+		}
+		breed.PetSize = normalized
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// resolvePetSize déduit la taille si elle est absente et vérifie sa
+// cohérence avec les poids si elle est imposée
+func (s *BreedService) resolvePetSize(breed *repository.Breed) error {
+	classification, err := s.sizes.Snapshot()
+	if err != nil {
+		return fmt.Errorf("erreur lors du chargement des seuils de taille: %w", err)
+	}
+
+	if err := classification.Resolve(breed); err != nil {
+		return ValidationError{{Field: "pet_size", Value: breed.PetSize, Err: err}}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/japhy-tech/backend-test/internal/i18n"
+	"github.com/japhy-tech/backend-test/internal/repository"
+)
+
+// BreedTranslationService fournit les noms d'affichage des races
+type BreedTranslationService struct {
+	repo repository.BreedTranslationRepositoryInterface
+}
+
+func NewBreedTranslationService(repo repository.BreedTranslationRepositoryInterface) *BreedTranslationService {
+	return &BreedTranslationService{repo: repo}
+}
+
+// DisplayNames retourne le nom d'affichage de chaque race dans la langue
+// demandée, en humanisant l'identifiant des races sans traduction
+func (s *BreedTranslationService) DisplayNames(locale string, breeds []repository.Breed) (map[int]string, error) {
+	ids := make([]int, 0, len(breeds))
+	for _, breed := range breeds {
+		ids = append(ids, breed.ID)
+	}
+
+	names, err := s.repo.DisplayNames(locale, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, breed := range breeds {
+		if _, ok := names[breed.ID]; !ok {
+			names[breed.ID] = HumanizeBreedName(breed.Name)
+		}
+	}
+
+	return names, nil
+}
+
+// HumanizeBreedName transforme un identifiant comme "bichon_frize" en
+// "Bichon Frize"
+func HumanizeBreedName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return r == '_' || r == '-' || unicode.IsSpace(r)
+	})
+	for i, word := range words {
+		first, size := utf8.DecodeRuneInString(word)
+		words[i] = string(unicode.ToUpper(first)) + word[size:]
+	}
+	return strings.Join(words, " ")
+}
+
+// SupportedLocales liste les langues acceptées pour les traductions, qui
+// sont celles du catalogue de messages
+func SupportedLocales() []string {
+	locales := []string{}
+	for _, lang := range i18n.Supported() {
+		locales = append(locales, string(lang))
+	}
+	return locales
+}
+
+// NormalizeLocale retourne la langue normalisée ou une UnknownValueError
+func NormalizeLocale(value string) (string, error) {
+	return normalizeAgainst("locale", value, SupportedLocales())
+}
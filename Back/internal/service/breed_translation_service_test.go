@@ -0,0 +1,17 @@
+package service
+
+import "testing"
+
+func TestHumanizeBreedName(t *testing.T) {
+	tests := map[string]string{
+		"bichon_frize":                "Bichon Frize",
+		"miniature_american_shepherd": "Miniature American Shepherd",
+		"épagneul_breton":             "Épagneul Breton",
+		"affenpinscher":               "Affenpinscher",
+	}
+	for name, expected := range tests {
+		if humanized := HumanizeBreedName(name); humanized != expected {
+			t.Errorf("HumanizeBreedName(%q): attendu %q, obtenu %q", name, expected, humanized)
+		}
+	}
+}
@@ -0,0 +1,23 @@
+package service
+
+import "strings"
+
+// FieldError signale un champ qui ne respecte pas une règle d'écriture ; Err
+// porte le message, traduisible avec i18n.FromError
+type FieldError struct {
+	Field string
+	Value string
+	Err   error
+}
+
+// ValidationError regroupe les champs refusés par les règles d'écriture,
+// communes à toutes les API, que chacune traduit dans son format d'erreur
+type ValidationError []FieldError
+
+func (e ValidationError) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, fieldError := range e {
+		messages = append(messages, fieldError.Field+": "+fieldError.Err.Error())
+	}
+	return strings.Join(messages, "; ")
+}
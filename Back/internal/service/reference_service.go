@@ -0,0 +1,91 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/japhy-tech/backend-test/internal/i18n"
+	"github.com/japhy-tech/backend-test/internal/repository"
+)
+
+// UnknownValueError signale une valeur absente des données de référence
+type UnknownValueError struct {
+	Field   string
+	Value   string
+	Allowed []string
+}
+
+func (e *UnknownValueError) Error() string {
+	return e.Localize(i18n.Default)
+}
+
+func (e *UnknownValueError) Localize(lang i18n.Lang) string {
+	return i18n.T(lang, i18n.UnknownValue, e.Value, e.Field, strings.Join(e.Allowed, ", "))
+}
+
+// ReferenceSet est un instantané des espèces et tailles autorisées
+type ReferenceSet struct {
+	Species  []string
+	PetSizes []string
+}
+
+// ReferenceService valide les espèces et tailles contre les tables de référence
+type ReferenceService struct {
+	species  repository.ReferenceRepositoryInterface
+	petSizes repository.ReferenceRepositoryInterface
+}
+
+func NewReferenceService(species, petSizes repository.ReferenceRepositoryInterface) *ReferenceService {
+	return &ReferenceService{
+		species:  species,
+		petSizes: petSizes,
+	}
+}
+
+// Snapshot charge les valeurs autorisées en une seule fois, pour valider
+// une requête ou un import complet sans interroger la base à chaque ligne
+func (s *ReferenceService) Snapshot() (*ReferenceSet, error) {
+	species, err := s.species.List()
+	if err != nil {
+		return nil, err
+	}
+	petSizes, err := s.petSizes.List()
+	if err != nil {
+		return nil, err
+	}
+
+	set := &ReferenceSet{}
+	for _, value := range species {
+		set.Species = append(set.Species, value.Code)
+	}
+	for _, value := range petSizes {
+		set.PetSizes = append(set.PetSizes, value.Code)
+	}
+
+	return set, nil
+}
+
+// NormalizeReference met une valeur saisie sous la forme stockée en base
+func NormalizeReference(value string) string {
+	return strings.ToLower(strings.TrimSpace(value))
+}
+
+// NormalizeSpecies retourne l'espèce normalisée ou une UnknownValueError
+func (rs *ReferenceSet) NormalizeSpecies(value string) (string, error) {
+	return normalizeAgainst("species", value, rs.Species)
+}
+
+// NormalizePetSize retourne la taille normalisée ou une UnknownValueError
+func (rs *ReferenceSet) NormalizePetSize(value string) (string, error) {
+	return normalizeAgainst("pet_size", value, rs.PetSizes)
+}
+
+func normalizeAgainst(field, value string, allowed []string) (string, error) {
+	normalized := NormalizeReference(value)
+	for _, code := range allowed {
+		if code == normalized {
+			return normalized, nil
+		}
+	}
+
+	return "", &UnknownValueError{Field: field, Value: value, Allowed: allowed}
+}
@@ -0,0 +1,123 @@
+package service
+
+import (
+	"github.com/japhy-tech/backend-test/internal/i18n"
+	"github.com/japhy-tech/backend-test/internal/repository"
+)
+
+// SizeInconsistency décrit une race dont la taille déclarée contredit ses poids
+type SizeInconsistency struct {
+	Breed           repository.Breed `json:"breed"`
+	ExpectedPetSize string           `json:"expected_pet_size"`
+}
+
+// SizeClassification est un instantané des seuils de taille par espèce
+type SizeClassification struct {
+	// Enforce rejette les écritures dont la taille contredit les poids
+	Enforce    bool
+	thresholds map[string][]repository.SizeThreshold
+}
+
+// SizeClassifier déduit la taille d'une race à partir de ses poids moyens
+type SizeClassifier struct {
+	repo    repository.SizeThresholdRepositoryInterface
+	enforce bool
+}
+
+func NewSizeClassifier(repo repository.SizeThresholdRepositoryInterface, enforce bool) *SizeClassifier {
+	return &SizeClassifier{
+		repo:    repo,
+		enforce: enforce,
+	}
+}
+
+// Snapshot charge les seuils en une seule fois
+func (s *SizeClassifier) Snapshot() (*SizeClassification, error) {
+	thresholds, err := s.repo.List()
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSizeClassification(thresholds, s.enforce), nil
+}
+
+// NewSizeClassification construit une classification à partir de seuils
+// déjà triés par poids croissant (sans limite en dernier)
+func NewSizeClassification(thresholds []repository.SizeThreshold, enforce bool) *SizeClassification {
+	classification := &SizeClassification{
+		Enforce:    enforce,
+		thresholds: map[string][]repository.SizeThreshold{},
+	}
+	for _, threshold := range thresholds {
+		classification.thresholds[threshold.Species] = append(classification.thresholds[threshold.Species], threshold)
+	}
+
+	return classification
+}
+
+// Classify retourne la taille correspondant aux poids, ou false si l'espèce
+// n'a pas de seuils ou si aucun poids n'est connu
+func (c *SizeClassification) Classify(species string, maleWeight, femaleWeight int) (string, bool) {
+	thresholds := c.thresholds[species]
+	if len(thresholds) == 0 {
+		return "", false
+	}
+
+	weight, ok := averageWeight(maleWeight, femaleWeight)
+	if !ok {
+		return "", false
+	}
+
+	for _, threshold := range thresholds {
+		if threshold.MaxWeight == nil || weight <= *threshold.MaxWeight {
+			return threshold.PetSize, true
+		}
+	}
+
+	return "", false
+}
+
+// Check retourne l'incohérence de la race, ou nil si sa taille est cohérente
+// ou ne peut pas être déduite
+func (c *SizeClassification) Check(breed repository.Breed) *SizeInconsistency {
+	expected, ok := c.Classify(breed.Species, breed.AverageMaleAdultWeight, breed.AverageFemaleAdultWeight)
+	if !ok || expected == breed.PetSize {
+		return nil
+	}
+
+	return &SizeInconsistency{Breed: breed, ExpectedPetSize: expected}
+}
+
+// Resolve complète la taille si elle est absente, puis vérifie la cohérence
+// si elle est imposée
+func (c *SizeClassification) Resolve(breed *repository.Breed) error {
+	if breed.PetSize == "" {
+		petSize, ok := c.Classify(breed.Species, breed.AverageMaleAdultWeight, breed.AverageFemaleAdultWeight)
+		if !ok {
+			return i18n.New(i18n.PetSizeNotDerivable, breed.Species)
+		}
+		breed.PetSize = petSize
+		return nil
+	}
+
+	if c.Enforce {
+		if inconsistency := c.Check(*breed); inconsistency != nil {
+			return i18n.New(i18n.PetSizeInconsistent, breed.PetSize, breed.Name, inconsistency.ExpectedPetSize)
+		}
+	}
+
+	return nil
+}
+
+// averageWeight ignore les poids inconnus (0) ; les chats du CSV n'en ont aucun
+func averageWeight(maleWeight, femaleWeight int) (int, bool) {
+	switch {
+	case maleWeight > 0 && femaleWeight > 0:
+		return (maleWeight + femaleWeight) / 2, true
+	case maleWeight > 0:
+		return maleWeight, true
+	case femaleWeight > 0:
+		return femaleWeight, true
+	}
+	return 0, false
+}
@@ -0,0 +1,185 @@
+// Package spa sert le build du front Vue (Front/dist) depuis le binaire Go,
+// avec un repli sur index.html pour les routes gérées côté navigateur
+package spa
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// Le build du front est copié dans dist/ avant la compilation pour être
+// embarqué ; sans build, le dossier ne contient que .gitkeep
+//
+//go:embed all:dist
+var embedded embed.FS
+
+// index est la page servie pour toute route inconnue du navigateur
+const index = "index.html"
+
+// immutableCache s'applique aux fichiers dont le nom porte l'empreinte de
+// leur contenu (dossier assets/ de Vite) : ils ne changent jamais
+const immutableCache = "public, max-age=31536000, immutable"
+
+// ErrNoIndex signale un dossier sans index.html, qui n'est pas un build
+var ErrNoIndex = errors.New("index.html introuvable")
+
+// encodings sont les variantes précompressées recherchées, de la plus
+// efficace à la moins efficace
+var encodings = []struct {
+	name, extension string
+}{
+	{"br", ".br"},
+	{"gzip", ".gz"},
+}
+
+// Options règle le service du front
+type Options struct {
+	// ContentSecurityPolicy remplace celle de l'API, trop stricte pour une
+	// page qui charge ses scripts et styles
+	ContentSecurityPolicy string
+	// ReservedPrefixes sont les préfixes de l'API : un chemin inconnu sous
+	// l'un d'eux reste une 404 de l'API au lieu de servir index.html
+	ReservedPrefixes []string
+}
+
+// Embedded retourne le build embarqué dans le binaire
+func Embedded() (fs.FS, error) {
+	dist, err := fs.Sub(embedded, "dist")
+	if err != nil {
+		return nil, err
+	}
+	return dist, checkIndex(dist)
+}
+
+// Dir retourne un build lu depuis un dossier, par exemple ../Front/dist
+func Dir(dir string) (fs.FS, error) {
+	dist := os.DirFS(dir)
+	return dist, checkIndex(dist)
+}
+
+func checkIndex(dist fs.FS) error {
+	if _, err := fs.Stat(dist, index); err != nil {
+		return ErrNoIndex
+	}
+	return nil
+}
+
+// Handler sert les fichiers du build et, pour les autres chemins, index.html.
+// Les requêtes qui ne sont pas des GET ou HEAD, ou qui visent un préfixe
+// réservé à l'API, sont confiées à fallback.
+func Handler(dist fs.FS, options Options, fallback http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if (r.Method != http.MethodGet && r.Method != http.MethodHead) || reserved(r.URL.Path, options.ReservedPrefixes) {
+			fallback.ServeHTTP(w, r)
+			return
+		}
+
+		name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+		if name == "" {
+			name = index
+		}
+
+		if info, err := fs.Stat(dist, name); err != nil || info.IsDir() {
+			// Un fichier manquant (asset supprimé par un nouveau build) est une
+			// vraie 404 ; les autres chemins sont des routes du front
+			if path.Ext(name) != "" {
+				http.NotFound(w, r)
+				return
+			}
+			name = index
+		}
+
+		header := w.Header()
+		if options.ContentSecurityPolicy != "" {
+			header.Set("Content-Security-Policy", options.ContentSecurityPolicy)
+		}
+		if strings.HasPrefix(name, "assets/") {
+			header.Set("Cache-Control", immutableCache)
+		} else {
+			header.Set("Cache-Control", "no-cache")
+		}
+
+		serveFile(w, r, dist, name)
+	})
+}
+
+// reserved indique si le chemin appartient à l'un des préfixes de l'API
+func reserved(urlPath string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if urlPath == prefix || strings.HasPrefix(urlPath, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// serveFile sert la variante précompressée acceptée par le client si le
+// build en contient une (name.br, name.gz), sinon le fichier lui-même
+func serveFile(w http.ResponseWriter, r *http.Request, dist fs.FS, name string) {
+	w.Header().Add("Vary", "Accept-Encoding")
+
+	accepted := r.Header.Get("Accept-Encoding")
+	for _, encoding := range encodings {
+		if acceptsEncoding(accepted, encoding.name) && serveContent(w, r, dist, name+encoding.extension, name, encoding.name) {
+			return
+		}
+	}
+
+	if !serveContent(w, r, dist, name, name, "") {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
+
+// serveContent sert le fichier file sous le nom name, qui donne le
+// Content-Type ; il retourne false si le fichier n'a pas pu être ouvert
+func serveContent(w http.ResponseWriter, r *http.Request, dist fs.FS, file, name, encoding string) bool {
+	f, err := dist.Open(file)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		return false
+	}
+
+	// Les fichiers embarqués et ceux d'un dossier sont déjà des
+	// io.ReadSeeker, les autres sont lus en mémoire
+	content, ok := f.(io.ReadSeeker)
+	if !ok {
+		data, err := io.ReadAll(f)
+		if err != nil {
+			return false
+		}
+		content = bytes.NewReader(data)
+	}
+
+	if encoding != "" {
+		w.Header().Set("Content-Encoding", encoding)
+	}
+	http.ServeContent(w, r, name, info.ModTime(), content)
+	return true
+}
+
+// acceptsEncoding indique si l'en-tête Accept-Encoding accepte le codage,
+// sans qu'il soit exclu par q=0
+func acceptsEncoding(header, encoding string) bool {
+	for _, part := range strings.Split(header, ",") {
+		value, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(value), encoding) {
+			continue
+		}
+		q, err := strconv.ParseFloat(strings.TrimPrefix(strings.ReplaceAll(params, " ", ""), "q="), 64)
+		return params == "" || err != nil || q > 0
+	}
+	return false
+}
@@ -0,0 +1,106 @@
+package spa
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"testing/fstest"
+)
+
+var dist = fstest.MapFS{
+	"index.html":                {Data: []byte("<!doctype html><div id=app></div>")},
+	"vite.svg":                  {Data: []byte("<svg></svg>")},
+	"assets/index-4f3a9c.js":    {Data: []byte("console.log('app')")},
+	"assets/index-4f3a9c.js.br": {Data: []byte("brotli")},
+	"assets/index-4f3a9c.js.gz": {Data: []byte("gzip")},
+}
+
+func serve(t *testing.T, method, target string, header map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+
+	handler := Handler(dist, Options{ContentSecurityPolicy: "default-src 'self'", ReservedPrefixes: []string{"/v1"}},
+		http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTeapot)
+		}))
+	req := httptest.NewRequest(method, target, nil)
+	for name, value := range header {
+		req.Header.Set(name, value)
+	}
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	return w
+}
+
+func TestHandler_FallsBackToIndex(t *testing.T) {
+	for _, target := range []string{"/", "/game/3", "/index.html"} {
+		w := serve(t, http.MethodGet, target, nil)
+		if w.Code != http.StatusOK || w.Body.String() != "<!doctype html><div id=app></div>" {
+			t.Errorf("%s: attendu index.html, obtenu %d %q", target, w.Code, w.Body.String())
+		}
+		if w.Header().Get("Cache-Control") != "no-cache" || w.Header().Get("Content-Security-Policy") != "default-src 'self'" {
+			t.Errorf("%s: en-têtes inattendus %v", target, w.Header())
+		}
+	}
+
+	if w := serve(t, http.MethodGet, "/assets/index-0000.js", nil); w.Code != http.StatusNotFound {
+		t.Errorf("un asset manquant doit être une 404, obtenu %d", w.Code)
+	}
+}
+
+func TestHandler_LeavesAPIRoutes(t *testing.T) {
+	for _, tc := range []struct{ method, target string }{
+		{http.MethodGet, "/v1"},
+		{http.MethodGet, "/v1/unknown"},
+		{http.MethodPost, "/game"},
+	} {
+		if w := serve(t, tc.method, tc.target, nil); w.Code != http.StatusTeapot {
+			t.Errorf("%s %s: attendu le handler de repli, obtenu %d", tc.method, tc.target, w.Code)
+		}
+	}
+
+	// /v1xyz n'est pas sous le préfixe /v1
+	if w := serve(t, http.MethodGet, "/v1xyz", nil); w.Code != http.StatusOK {
+		t.Errorf("attendu index.html pour /v1xyz, obtenu %d", w.Code)
+	}
+}
+
+func TestHandler_HashedAssets(t *testing.T) {
+	tests := []struct {
+		name     string
+		accept   string
+		body     string
+		encoding string
+	}{
+		{name: "sans compression", body: "console.log('app')"},
+		{name: "brotli préféré", accept: "gzip, deflate, br", body: "brotli", encoding: "br"},
+		{name: "gzip", accept: "gzip", body: "gzip", encoding: "gzip"},
+		{name: "brotli refusé", accept: "br;q=0, gzip;q=0.5", body: "gzip", encoding: "gzip"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			w := serve(t, http.MethodGet, "/assets/index-4f3a9c.js", map[string]string{"Accept-Encoding": tc.accept})
+
+			if w.Code != http.StatusOK || w.Body.String() != tc.body || w.Header().Get("Content-Encoding") != tc.encoding {
+				t.Fatalf("attendu %q (%q), obtenu %d %q (%q)", tc.body, tc.encoding, w.Code, w.Body.String(), w.Header().Get("Content-Encoding"))
+			}
+			if w.Header().Get("Cache-Control") != immutableCache {
+				t.Errorf("Cache-Control inattendu %q", w.Header().Get("Cache-Control"))
+			}
+			if got := w.Header().Get("Content-Type"); got != "text/javascript; charset=utf-8" {
+				t.Errorf("Content-Type inattendu %q", got)
+			}
+		})
+	}
+
+	// Les fichiers sans empreinte sont revalidés
+	if w := serve(t, http.MethodGet, "/vite.svg", nil); w.Header().Get("Cache-Control") != "no-cache" {
+		t.Errorf("Cache-Control inattendu %q", w.Header().Get("Cache-Control"))
+	}
+}
+
+func TestDir_WithoutIndex(t *testing.T) {
+	if _, err := Dir(t.TempDir()); err != ErrNoIndex {
+		t.Errorf("attendu ErrNoIndex pour un dossier sans build, obtenu %v", err)
+	}
+}
@@ -0,0 +1,116 @@
+package tracing
+
+import (
+	"context"
+
+	"github.com/japhy-tech/backend-test/internal/repository"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// BreedRepository ouvre un span autour de chaque appel au repository des
+// races, avec les filtres et le nombre de lignes renvoyées
+type BreedRepository struct {
+	repo repository.BreedRepositoryInterface
+}
+
+// NewBreedRepository décore repo
+func NewBreedRepository(repo repository.BreedRepositoryInterface) *BreedRepository {
+	return &BreedRepository{repo: repo}
+}
+
+func (r *BreedRepository) GetAll(ctx context.Context, filter repository.BreedFilter) ([]repository.Breed, error) {
+	ctx, span := Start(ctx, "BreedRepository.GetAll", filterAttributes(filter)...)
+	breeds, err := r.repo.GetAll(ctx, filter)
+	span.SetAttributes(attribute.Int("db.rows", len(breeds)))
+	End(span, err)
+	return breeds, err
+}
+
+func (r *BreedRepository) GetByID(ctx context.Context, id int) (*repository.Breed, error) {
+	ctx, span := Start(ctx, "BreedRepository.GetByID", attribute.Int("breed.id", id))
+	breed, err := r.repo.GetByID(ctx, id)
+	End(span, err)
+	return breed, err
+}
+
+func (r *BreedRepository) Resolve(ctx context.Context, name string) (*repository.Breed, error) {
+	ctx, span := Start(ctx, "BreedRepository.Resolve", attribute.String("breed.name", name))
+	breed, err := r.repo.Resolve(ctx, name)
+	End(span, err)
+	return breed, err
+}
+
+func (r *BreedRepository) Create(ctx context.Context, breed *repository.Breed) (*repository.Breed, error) {
+	ctx, span := Start(ctx, "BreedRepository.Create", attribute.String("breed.name", breed.Name))
+	created, err := r.repo.Create(ctx, breed)
+	End(span, err)
+	return created, err
+}
+
+func (r *BreedRepository) Update(ctx context.Context, id int, breed *repository.Breed) (*repository.Breed, error) {
+	ctx, span := Start(ctx, "BreedRepository.Update", attribute.Int("breed.id", id))
+	updated, err := r.repo.Update(ctx, id, breed)
+	End(span, err)
+	return updated, err
+}
+
+func (r *BreedRepository) Delete(ctx context.Context, id int) error {
+	ctx, span := Start(ctx, "BreedRepository.Delete", attribute.Int("breed.id", id))
+	err := r.repo.Delete(ctx, id)
+	End(span, err)
+	return err
+}
+
+func (r *BreedRepository) ImportFromCSV(ctx context.Context, breeds []repository.Breed) (repository.ImportStats, error) {
+	ctx, span := Start(ctx, "BreedRepository.ImportFromCSV", attribute.Int("import.rows", len(breeds)))
+	stats, err := r.repo.ImportFromCSV(ctx, breeds)
+	span.SetAttributes(StatsAttributes(stats)...)
+	End(span, err)
+	return stats, err
+}
+
+// StatsAttributes décrit le résultat d'un import
+func StatsAttributes(stats repository.ImportStats) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.Int("import.inserted", stats.Inserted),
+		attribute.Int("import.updated", stats.Updated),
+		attribute.Int("import.unchanged", stats.Unchanged),
+	}
+}
+
+// filterAttributes ne retient que les critères renseignés
+func filterAttributes(filter repository.BreedFilter) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		attribute.Int("filter.limit", filter.Limit),
+		attribute.Int("filter.offset", filter.Offset),
+	}
+	for _, criterion := range []attribute.KeyValue{
+		attribute.String("filter.species", filter.Species),
+		attribute.String("filter.pet_size", filter.PetSize),
+		attribute.String("filter.name", filter.Name),
+		attribute.String("filter.sex", string(filter.Sex)),
+		attribute.String("filter.match", string(filter.Match)),
+		attribute.String("filter.sort", string(filter.Sort)),
+	} {
+		if criterion.Value.AsString() != "" {
+			attrs = append(attrs, criterion)
+		}
+	}
+	if filter.Desc {
+		attrs = append(attrs, attribute.Bool("filter.desc", true))
+	}
+	attrs = appendRange(attrs, "filter.weight", filter.Weight)
+	attrs = appendRange(attrs, "filter.male_weight", filter.MaleWeight)
+	attrs = appendRange(attrs, "filter.female_weight", filter.FemaleWeight)
+	return attrs
+}
+
+func appendRange(attrs []attribute.KeyValue, prefix string, weights repository.WeightRange) []attribute.KeyValue {
+	if weights.Min != nil {
+		attrs = append(attrs, attribute.Int(prefix+"_min", *weights.Min))
+	}
+	if weights.Max != nil {
+		attrs = append(attrs, attribute.Int(prefix+"_max", *weights.Max))
+	}
+	return attrs
+}
@@ -0,0 +1,48 @@
+package tracing
+
+import (
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/requestid"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Handler ouvre le span de chaque requête HTTP, enfant de celui de
+// l'appelant s'il envoie un en-tête traceparent ; les chemins quiet (sondes,
+// métriques), appelés en continu, ne sont pas tracés
+func Handler(next http.Handler, quiet ...string) http.Handler {
+	skipped := make(map[string]bool, len(quiet))
+	for _, path := range quiet {
+		skipped[path] = true
+	}
+
+	return otelhttp.NewHandler(next, "HTTP",
+		otelhttp.WithFilter(func(r *http.Request) bool {
+			return !skipped[r.URL.Path]
+		}),
+		otelhttp.WithSpanNameFormatter(func(operation string, r *http.Request) string {
+			return r.Method
+		}),
+	)
+}
+
+// Middleware nomme le span de la requête d'après le modèle de sa route
+// (GET /v1/breeds/{id}), connu seulement une fois la route choisie ; il
+// s'installe avec Router.Use
+func Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		span := trace.SpanFromContext(r.Context())
+		if current := mux.CurrentRoute(r); current != nil {
+			if template, err := current.GetPathTemplate(); err == nil {
+				span.SetName(r.Method + " " + template)
+				span.SetAttributes(attribute.String("http.route", template))
+			}
+		}
+		span.SetAttributes(attribute.String("request.id", requestid.FromContext(r.Context())))
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,128 @@
+// Package tracing configure OpenTelemetry : un span par requête HTTP,
+// propagé par l'en-tête W3C traceparent, et des spans enfants autour des
+// appels au repository des races et des phases des imports CSV
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/japhy-tech/backend-test/internal/repository"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentation nomme le tracer de l'application
+const instrumentation = "github.com/japhy-tech/backend-test"
+
+// Exporteurs des spans
+const (
+	// ExporterNone ne produit aucun span ; traceparent reste propagé
+	ExporterNone = "none"
+	// ExporterOTLP envoie les spans en gRPC à un collecteur, configuré par les
+	// variables OTEL_EXPORTER_OTLP_* standard
+	ExporterOTLP = "otlp"
+	// ExporterStdout écrit les spans en JSON sur la sortie standard ou dans un
+	// fichier, sans collecteur
+	ExporterStdout = "stdout"
+)
+
+// Options décrit l'export des spans
+type Options struct {
+	ServiceName string
+	Exporter    string
+	// File reçoit les spans de l'exporteur stdout ; vide, la sortie standard
+	File string
+	// SampleRatio est la part des traces démarrées ici qui sont conservées ;
+	// celles d'un appelant suivent sa décision
+	SampleRatio float64
+}
+
+// Setup installe le fournisseur de spans et la propagation W3C (traceparent
+// et baggage) ; la fonction retournée vide les spans en attente à l'arrêt
+func Setup(ctx context.Context, opts Options) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{},
+	))
+
+	var (
+		exporter sdktrace.SpanExporter
+		closer   io.Closer
+		err      error
+	)
+	switch opts.Exporter {
+	case ExporterNone, "":
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		exporter, err = otlptracegrpc.New(ctx)
+	case ExporterStdout:
+		var out io.Writer = os.Stdout
+		if opts.File != "" {
+			file, openErr := os.OpenFile(opts.File, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+			if openErr != nil {
+				return nil, fmt.Errorf("erreur lors de l'ouverture du fichier des traces: %w", openErr)
+			}
+			out, closer = file, file
+		}
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(out))
+	default:
+		return nil, fmt.Errorf("exporteur de traces inconnu : %q (none, otlp ou stdout attendu)", opts.Exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la création de l'exporteur de traces: %w", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		attribute.String("service.name", opts.ServiceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("erreur lors de la description du service: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(opts.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if closer != nil {
+			err = errors.Join(err, closer.Close())
+		}
+		return err
+	}, nil
+}
+
+// Start ouvre un span enfant de celui du contexte
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentation).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End ferme le span en le marquant en erreur si besoin ; une ressource
+// absente ou une donnée refusée est une réponse normale, pas une panne
+func End(span trace.Span, err error) {
+	if err != nil && !expected(err) {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+func expected(err error) bool {
+	return errors.Is(err, repository.ErrNotFound) ||
+		errors.Is(err, repository.ErrDuplicateName) ||
+		errors.Is(err, repository.ErrConflict) ||
+		errors.Is(err, repository.ErrInvalid)
+}
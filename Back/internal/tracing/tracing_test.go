@@ -0,0 +1,166 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/japhy-tech/backend-test/internal/repository"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// record installe un fournisseur qui garde les spans terminés en mémoire
+func record(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		provider.Shutdown(context.Background())
+		otel.SetTracerProvider(previous)
+	})
+	return recorder
+}
+
+func attributes(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	values := map[attribute.Key]attribute.Value{}
+	for _, attr := range span.Attributes() {
+		values[attr.Key] = attr.Value
+	}
+	return values
+}
+
+func TestHandler_NamesSpanAfterRoute(t *testing.T) {
+	recorder := record(t)
+
+	r := mux.NewRouter()
+	r.Use(Middleware)
+	r.HandleFunc("/breeds/{id:[0-9]+}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	r.HandleFunc("/livez", func(w http.ResponseWriter, r *http.Request) {})
+	handler := Handler(r, "/livez")
+
+	req := httptest.NewRequest(http.MethodGet, "/breeds/12", nil)
+	req.Header.Set("traceparent", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/livez", nil))
+
+	spans := recorder.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("%d spans, attendu 1 (les sondes ne sont pas tracées)", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "GET /breeds/{id:[0-9]+}" {
+		t.Errorf("nom du span %q", span.Name())
+	}
+	if span.SpanContext().TraceID().String() != "4bf92f3577b34da6a3ce929d0e0e4736" || span.Parent().SpanID().String() != "00f067aa0ba902b7" {
+		t.Errorf("le span doit prolonger la trace de l'appelant : %v, parent %v", span.SpanContext().TraceID(), span.Parent().SpanID())
+	}
+	if route := attributes(span)["http.route"].AsString(); route != "/breeds/{id:[0-9]+}" {
+		t.Errorf("http.route = %q", route)
+	}
+}
+
+type mockBreedRepository struct {
+	repository.BreedRepositoryInterface
+	err error
+}
+
+func (m *mockBreedRepository) GetAll(ctx context.Context, filter repository.BreedFilter) ([]repository.Breed, error) {
+	return []repository.Breed{{ID: 1}, {ID: 2}}, m.err
+}
+
+func (m *mockBreedRepository) GetByID(ctx context.Context, id int) (*repository.Breed, error) {
+	return nil, m.err
+}
+
+func TestBreedRepository_ChildSpan(t *testing.T) {
+	recorder := record(t)
+	repo := NewBreedRepository(&mockBreedRepository{})
+
+	ctx, parent := Start(context.Background(), "parent")
+	min := 5000
+	repo.GetAll(ctx, repository.BreedFilter{Species: "dog", Weight: repository.WeightRange{Min: &min}, Limit: 10})
+	parent.End()
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("%d spans, attendu 2", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "BreedRepository.GetAll" || span.Parent().SpanID() != parent.SpanContext().SpanID() {
+		t.Errorf("span %q, parent %v : attendu enfant du span de la requête", span.Name(), span.Parent().SpanID())
+	}
+
+	attrs := attributes(span)
+	if attrs["filter.species"].AsString() != "dog" || attrs["filter.weight_min"].AsInt64() != 5000 || attrs["filter.limit"].AsInt64() != 10 || attrs["db.rows"].AsInt64() != 2 {
+		t.Errorf("attributs inattendus : %v", attrs)
+	}
+	if _, ok := attrs["filter.pet_size"]; ok {
+		t.Error("un critère vide ne doit pas être un attribut")
+	}
+}
+
+func TestBreedRepository_ErrorStatus(t *testing.T) {
+	recorder := record(t)
+
+	NewBreedRepository(&mockBreedRepository{err: repository.ErrNotFound}).GetByID(context.Background(), 1)
+	NewBreedRepository(&mockBreedRepository{err: errors.New("connexion perdue")}).GetByID(context.Background(), 2)
+
+	spans := recorder.Ended()
+	if len(spans) != 2 {
+		t.Fatalf("%d spans, attendu 2", len(spans))
+	}
+	if spans[0].Status().Code == codes.Error {
+		t.Error("une race absente n'est pas une erreur du span")
+	}
+	if spans[1].Status().Code != codes.Error || len(spans[1].Events()) == 0 {
+		t.Errorf("erreur non enregistrée : %v", spans[1].Status())
+	}
+}
+
+func TestSetup_UnknownExporter(t *testing.T) {
+	if _, err := Setup(context.Background(), Options{Exporter: "zipkin"}); err == nil {
+		t.Error("exporteur inconnu accepté")
+	}
+}
+
+func TestSetup_FileExporter(t *testing.T) {
+	previous := otel.GetTracerProvider()
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	path := filepath.Join(t.TempDir(), "traces.json")
+	shutdown, err := Setup(context.Background(), Options{ServiceName: "test", Exporter: ExporterStdout, File: path, SampleRatio: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, span := Start(context.Background(), "csv.parse")
+	span.End()
+	if err := shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(content), `"Name":"csv.parse"`) {
+		t.Errorf("span absent du fichier : %s", content)
+	}
+}
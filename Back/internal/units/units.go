@@ -0,0 +1,192 @@
+package units
+
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/japhy-tech/backend-test/internal/i18n"
+)
+
+// Unit est une unité de poids ; les poids sont stockés en grammes
+type Unit string
+
+const (
+	Grams     Unit = "g"
+	Kilograms Unit = "kg"
+	Pounds    Unit = "lb"
+)
+
+const gramsPerPound = 453.59237
+
+// Bornes au-delà desquelles un poids moyen exprimé en grammes est suspect
+// (un chihuahua pèse environ 2000 g, un dogue allemand environ 80000 g)
+const (
+	MinPlausibleGrams = 500
+	MaxPlausibleGrams = 150000
+)
+
+// spellings liste les écritures acceptées de chaque unité, la première
+// étant le code de l'unité
+var spellings = [][]string{
+	{"g", "gram", "grams"},
+	{"kg", "kilogram", "kilograms"},
+	{"lb", "lbs", "pound", "pounds"},
+}
+
+// ParseUnit lit une unité, sans tenir compte de la casse ; une chaîne vide
+// donne les grammes
+func ParseUnit(value string) (Unit, error) {
+	normalized := strings.ToLower(strings.TrimSpace(value))
+	if normalized == "" {
+		return Grams, nil
+	}
+	for _, names := range spellings {
+		for _, name := range names {
+			if normalized == name {
+				return Unit(names[0]), nil
+			}
+		}
+	}
+	return "", i18n.New(i18n.UnknownWeightUnit, value)
+}
+
+// Names retourne les écritures acceptées par ParseUnit, en minuscules
+func Names() []string {
+	var names []string
+	for _, unitNames := range spellings {
+		names = append(names, unitNames...)
+	}
+	return names
+}
+
+// ToGrams convertit une valeur exprimée dans l'unité en grammes arrondis ;
+// la valeur doit avoir été bornée par TooHigh
+func (u Unit) ToGrams(value float64) int {
+	return int(math.Round(u.grams(value)))
+}
+
+// TooHigh indique si la valeur dépasse MaxPlausibleGrams, en valeur absolue
+func (u Unit) TooHigh(value float64) bool {
+	return math.Abs(u.grams(value)) > MaxPlausibleGrams
+}
+
+// MaxValue retourne MaxPlausibleGrams exprimé dans l'unité
+func (u Unit) MaxValue() float64 {
+	return u.FromGrams(MaxPlausibleGrams)
+}
+
+func (u Unit) grams(value float64) float64 {
+	switch u {
+	case Kilograms:
+		return value * 1000
+	case Pounds:
+		return value * gramsPerPound
+	}
+	return value
+}
+
+// FromGrams convertit des grammes dans l'unité (au centième pour les livres)
+func (u Unit) FromGrams(grams int) float64 {
+	switch u {
+	case Kilograms:
+		return float64(grams) / 1000
+	case Pounds:
+		return math.Round(float64(grams)/gramsPerPound*100) / 100
+	}
+	return float64(grams)
+}
+
+// Weight est un poids reçu en JSON, soit un nombre nu (unité de la requête),
+// soit une chaîne suffixée par son unité comme "20kg" ou "44 lb". Un poids
+// au-delà de MaxPlausibleGrams est rejeté : un nombre nu dépassant cette
+// valeur est trop élevé quelle que soit l'unité, le gramme étant la plus
+// petite.
+type Weight struct {
+	Value float64
+	Unit  Unit
+}
+
+func (w *Weight) UnmarshalJSON(data []byte) error {
+	var number float64
+	if err := json.Unmarshal(data, &number); err == nil {
+		if Grams.TooHigh(number) {
+			return i18n.New(i18n.WeightTooHigh, string(data), MaxPlausibleGrams)
+		}
+		*w = Weight{Value: number}
+		return nil
+	}
+
+	var text string
+	if err := json.Unmarshal(data, &text); err != nil {
+		return i18n.New(i18n.InvalidWeightJSON, string(data))
+	}
+
+	text = strings.TrimSpace(text)
+	split := numberLength(text)
+	number, err := strconv.ParseFloat(text[:split], 64)
+	if split == 0 || err != nil {
+		return i18n.New(i18n.InvalidWeight, text)
+	}
+	unit := Unit("")
+	if suffix := strings.TrimSpace(text[split:]); suffix != "" {
+		if unit, err = ParseUnit(suffix); err != nil {
+			return err
+		}
+	}
+	if unit.TooHigh(number) {
+		return i18n.New(i18n.WeightTooHigh, text, MaxPlausibleGrams)
+	}
+
+	*w = Weight{Value: number, Unit: unit}
+	return nil
+}
+
+// numberLength retourne la longueur du nombre décimal en tête de text :
+// signe, chiffres, partie décimale et exposant ("1e3kg" donne 3)
+func numberLength(text string) int {
+	i := 0
+	digits := func() int {
+		start := i
+		for i < len(text) && text[i] >= '0' && text[i] <= '9' {
+			i++
+		}
+		return i - start
+	}
+
+	if i < len(text) && (text[i] == '-' || text[i] == '+') {
+		i++
+	}
+	mantissa := digits()
+	if i < len(text) && text[i] == '.' {
+		i++
+		mantissa += digits()
+	}
+	if mantissa == 0 {
+		return 0
+	}
+
+	// L'exposant n'est retenu que s'il porte au moins un chiffre
+	if i < len(text) && (text[i] == 'e' || text[i] == 'E') {
+		end := i
+		i++
+		if i < len(text) && (text[i] == '-' || text[i] == '+') {
+			i++
+		}
+		if digits() == 0 {
+			i = end
+		}
+	}
+	return i
+}
+
+// Grams convertit le poids en grammes, avec l'unité par défaut de la requête
+// si la valeur n'en porte pas
+func (w Weight) Grams(defaultUnit Unit) int {
+	unit := w.Unit
+	if unit == "" {
+		unit = defaultUnit
+	}
+	return unit.ToGrams(w.Value)
+}
@@ -0,0 +1,35 @@
+package units
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestWeight_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		json string
+		want Weight
+	}{
+		{`18`, Weight{Value: 18}},
+		{`"20kg"`, Weight{Value: 20, Unit: Kilograms}},
+		{`" 44 lbs "`, Weight{Value: 44, Unit: Pounds}},
+		{`"1e2kg"`, Weight{Value: 100, Unit: Kilograms}},
+		{`"150kg"`, Weight{Value: 150, Unit: Kilograms}},
+		{`"2.5e1 lb"`, Weight{Value: 25, Unit: Pounds}},
+		{`"2.5E-1kg"`, Weight{Value: 0.25, Unit: Kilograms}},
+		{`"1500"`, Weight{Value: 1500}},
+	}
+	for _, tt := range tests {
+		var got Weight
+		if err := json.Unmarshal([]byte(tt.json), &got); err != nil || got != tt.want {
+			t.Errorf("%s : obtenu %+v (%v), attendu %+v", tt.json, got, err, tt.want)
+		}
+	}
+
+	for _, invalid := range []string{`"kg"`, `"NaN"`, `"Inf kg"`, `"1e309kg"`, `"1e kg"`, `"20 stone"`, `true`, `"1e300kg"`, `"151kg"`, `"-1e300 lb"`, `1e300`, `150001`} {
+		var got Weight
+		if err := json.Unmarshal([]byte(invalid), &got); err == nil {
+			t.Errorf("%s : accepté comme %+v", invalid, got)
+		}
+	}
+}
@@ -1,18 +1,30 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	charmLog "github.com/charmbracelet/log"
-	"github.com/gorilla/mux"
 	_ "github.com/go-sql-driver/mysql"
+	"github.com/gorilla/mux"
 	"github.com/japhy-tech/backend-test/database_actions"
 	"github.com/japhy-tech/backend-test/internal"
+	"github.com/japhy-tech/backend-test/internal/config"
+	"github.com/japhy-tech/backend-test/internal/cors"
+	"github.com/japhy-tech/backend-test/internal/logging"
+	"github.com/japhy-tech/backend-test/internal/requestid"
+	"github.com/japhy-tech/backend-test/internal/retry"
+	"github.com/japhy-tech/backend-test/internal/secheaders"
+	"github.com/japhy-tech/backend-test/internal/tracing"
+	"google.golang.org/grpc"
 )
 
 const (
@@ -21,16 +33,46 @@ const (
 )
 
 func main() {
-	logger := charmLog.NewWithOptions(os.Stderr, charmLog.Options{
-		Formatter:       charmLog.TextFormatter,
-		ReportCaller:    true,
-		ReportTimestamp: true,
-		TimeFormat:      time.Kitchen,
-		Prefix:          "🧑‍💻 backend-test",
-		Level:           charmLog.DebugLevel,
+	cfg := config.Load()
+
+	logFormat, err := logging.ParseFormat(cfg.LogFormat)
+	if err != nil {
+		charmLog.Fatal(err.Error())
+	}
+	logLevel, err := charmLog.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		charmLog.Fatal(err.Error())
+	}
+	logger := logging.New(os.Stderr, logFormat, logLevel)
+
+	shutdownTracing, err := tracing.Setup(context.Background(), tracing.Options{
+		ServiceName: cfg.TracingServiceName,
+		Exporter:    cfg.TracingExporter,
+		File:        cfg.TracingFile,
+		SampleRatio: cfg.TracingSampleRatio,
 	})
+	if err != nil {
+		logger.Fatal("Erreur lors de la configuration des traces", "error", err)
+	}
 
-	err := database_actions.InitMigrator(MysqlDSN)
+	// MySQL peut démarrer après l'API (docker compose up) : la connexion est
+	// relancée tant que l'erreur est passagère, jusqu'à DBStartupTimeout
+	startupCtx, cancelStartup := context.WithTimeout(context.Background(), cfg.DBStartupTimeout)
+	defer cancelStartup()
+	startup := retry.Policy{
+		Backoff: retry.Backoff{
+			Initial: cfg.DBRetryInitialDelay,
+			Max:     cfg.DBRetryMaxDelay,
+		},
+		Retryable: retry.Transient,
+		OnRetry: func(attempt int, delay time.Duration, err error) {
+			logger.Warn("MySQL indisponible, nouvelle tentative", "attempt", attempt, "delay", delay, "error", err)
+		},
+	}
+
+	err = retry.Do(startupCtx, startup, func(context.Context) error {
+		return database_actions.InitMigrator(MysqlDSN)
+	})
 	if err != nil {
 		logger.Fatal(err.Error())
 	}
@@ -47,35 +89,152 @@ func main() {
 		logger.Fatal(err.Error())
 		os.Exit(1)
 	}
-	defer db.Close()
-	db.SetMaxIdleConns(0)
+	configurePool(db, cfg)
 
-	err = db.Ping()
+	err = retry.Do(startupCtx, startup, db.PingContext)
 	if err != nil {
 		logger.Fatal(err.Error())
 		os.Exit(1)
 	}
+	cancelStartup()
 
 	logger.Info("Database connected")
 
+	// Une réplique injoignable n'empêche pas le démarrage : les lectures vont
+	// à la base principale jusqu'à ce qu'elle réponde
+	var replicas []*sql.DB
+	for _, dsn := range cfg.DBReplicaDSNs {
+		replica, err := sql.Open("mysql", dsn)
+		if err != nil {
+			logger.Fatal("DSN de réplique invalide", "error", err)
+		}
+		configurePool(replica, cfg)
+		replicas = append(replicas, replica)
+	}
+
 	// Pour passer la base de données à l'application
-	app := internal.NewApp(logger, db)
+	app := internal.NewApp(logger, db, replicas, cfg)
+
+	// Pour pouvoir créer les premières clés d'API
+	err = app.EnsureBootstrapKey(cfg.AuthBootstrapKey)
+	if err != nil {
+		logger.Fatal("Erreur lors de l'enregistrement de la clé initiale", "error", err)
+	}
 
 	r := mux.NewRouter()
 	app.RegisterRoutes(r)
 
-	r.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.WriteHeader(http.StatusOK)
-	}).Methods(http.MethodGet)
+	// Le serveur gRPC, destiné aux consommateurs internes, écoute sur son
+	// propre port à côté de l'API HTTP
+	grpcServer := app.NewGRPCServer()
 
-	logger.Info(fmt.Sprintf("Service started and listen on port %s", ApiPort))
+	grpcListener, err := net.Listen("tcp", net.JoinHostPort("", cfg.GRPCPort))
+	if err != nil {
+		logger.Fatal("Erreur lors de l'ouverture du port gRPC", "error", err)
+	}
+	go func() {
+		logger.Info(fmt.Sprintf("gRPC service started and listen on port %s", cfg.GRPCPort))
+		if err := grpcServer.Serve(grpcListener); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+			logger.Fatal("Erreur lors du démarrage du serveur gRPC", "error", err)
+		}
+	}()
 
-	err = http.ListenAndServe(
-		net.JoinHostPort("", ApiPort),
-		r,
-	)
+	// Le front Vue est servi sur une autre origine : les requêtes préalables
+	// CORS sont traitées avant le routeur et l'authentification
+	handler := secheaders.Middleware(secheaders.Policy{
+		ContentSecurityPolicy: cfg.ContentSecurityPolicy,
+		FrameOptions:          cfg.FrameOptions,
+	})(cors.Middleware(cors.Policy{
+		AllowedOrigins:   cfg.CORSAllowedOrigins,
+		AllowedMethods:   cfg.CORSAllowedMethods,
+		AllowedHeaders:   cfg.CORSAllowedHeaders,
+		ExposedHeaders:   internal.ExposedHeaders,
+		AllowCredentials: cfg.CORSAllowCredentials,
+		MaxAge:           cfg.CORSMaxAge,
+	})(r))
 
+	// Délais explicites : sans eux, un client lent garde sa connexion
+	// indéfiniment
+	server := &http.Server{
+		Addr:              net.JoinHostPort("", ApiPort),
+		Handler:           requestid.Middleware(tracing.Handler(handler, internal.QuietPaths...)),
+		ReadHeaderTimeout: cfg.HTTPReadHeaderTimeout,
+		ReadTimeout:       cfg.HTTPReadTimeout,
+		WriteTimeout:      cfg.HTTPWriteTimeout,
+		IdleTimeout:       cfg.HTTPIdleTimeout,
+		MaxHeaderBytes:    cfg.HTTPMaxHeaderBytes,
+		ErrorLog:          logger.StandardLog(charmLog.StandardLogOptions{ForceLevel: charmLog.WarnLevel}),
+	}
+
+	go func() {
+		logger.Info(fmt.Sprintf("Service started and listen on port %s", ApiPort))
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Fatal("Erreur lors du démarrage du serveur", "error", err)
+		}
+	}()
+
+	// Sur SIGTERM (docker stop) ou SIGINT, les serveurs cessent d'accepter
+	// des connexions et laissent aux requêtes en cours, imports compris,
+	// jusqu'à ShutdownTimeout pour se terminer
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	<-ctx.Done()
+	stop()
+
+	// /readyz échoue pendant que l'instance est retirée du trafic
+	logger.Info("Arrêt du service, retrait du trafic", "delay", cfg.ShutdownDrainDelay)
+	app.BeginShutdown()
+	time.Sleep(cfg.ShutdownDrainDelay)
+
+	logger.Info("Attente des requêtes en cours", "timeout", cfg.ShutdownTimeout)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+
+	grpcStopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(grpcStopped)
+	}()
+
+	err = server.Shutdown(shutdownCtx)
 	if err != nil {
-		logger.Fatal("Erreur lors du démarrage du serveur", "error", err)
+		logger.Error("Requêtes HTTP interrompues par l'arrêt", "error", err)
+	}
+
+	select {
+	case <-grpcStopped:
+	case <-shutdownCtx.Done():
+		logger.Error("Appels gRPC interrompus par l'arrêt")
+		grpcServer.Stop()
 	}
-}
\ No newline at end of file
+
+	// La base n'est fermée qu'une fois plus rien ne l'utilise
+	app.Close()
+	err = db.Close()
+	if err != nil {
+		logger.Error("Erreur lors de la fermeture de la base", "error", err)
+	}
+	for _, replica := range replicas {
+		if err := replica.Close(); err != nil {
+			logger.Error("Erreur lors de la fermeture d'une réplique", "error", err)
+		}
+	}
+
+	// Les derniers spans sont envoyés avant de quitter
+	tracingCtx, cancelTracing := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancelTracing()
+	err = shutdownTracing(tracingCtx)
+	if err != nil {
+		logger.Error("Erreur lors de l'envoi des derniers spans", "error", err)
+	}
+
+	logger.Info("Service arrêté")
+}
+
+// configurePool applique les réglages du pool de connexions MySQL
+func configurePool(db *sql.DB, cfg config.Config) {
+	db.SetMaxOpenConns(cfg.DBMaxOpenConns)
+	db.SetMaxIdleConns(cfg.DBMaxIdleConns)
+	db.SetConnMaxLifetime(cfg.DBConnMaxLifetime)
+	db.SetConnMaxIdleTime(cfg.DBConnMaxIdleTime)
+}